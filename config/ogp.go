@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// OGPFont はOGP画像の文字を描くフォントを返します
+// OGP_FONT_FILE が指定されていなければ nil を返し，欧文だけを含むGoフォントを使います
+func OGPFont() ([]byte, error) {
+	return readOptionalFile("OGP_FONT_FILE")
+}
+
+// OGPTemplate はOGP画像の背景にする画像を返します
+// OGP_TEMPLATE_FILE が指定されていなければ nil を返し，枠だけの背景を使います
+func OGPTemplate() ([]byte, error) {
+	return readOptionalFile("OGP_TEMPLATE_FILE")
+}
+
+func readOptionalFile(key string) ([]byte, error) {
+	path := os.Getenv(key)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %v path=%v: %w", key, path, err)
+	}
+	return data, nil
+}
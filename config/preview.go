@@ -0,0 +1,28 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// defaultPreviewTTL はプレビューリンクの有効期間のデフォルト値です
+const defaultPreviewTTL = 72 * time.Hour
+
+// PreviewSecret はプレビューリンクの署名に使う鍵を返します
+// PREVIEW_SECRET が指定されていなければ AUTH_KEY を使います
+func PreviewSecret() []byte {
+	secret := os.Getenv("PREVIEW_SECRET")
+	if secret == "" {
+		secret = os.Getenv("AUTH_KEY")
+	}
+	return []byte(secret)
+}
+
+// PreviewTTL はプレビューリンクの有効期間のデフォルト値を返します
+func PreviewTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("PREVIEW_TTL"))
+	if err != nil || ttl <= 0 {
+		return defaultPreviewTTL
+	}
+	return ttl
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+const defaultRobotsTxt = "User-agent: *\nDisallow: /api/\n"
+
+// RobotsTxt は robots.txt の内容を返します
+// ROBOTS_TXT_FILE が指定されていればそのファイルの内容を使います
+func RobotsTxt() (string, error) {
+	path := os.Getenv("ROBOTS_TXT_FILE")
+	if path == "" {
+		return defaultRobotsTxt, nil
+	}
+	robotsTxt, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read robots.txt path=%v: %w", path, err)
+	}
+	return string(robotsTxt), nil
+}
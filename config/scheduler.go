@@ -0,0 +1,18 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// defaultPublishInterval は予約投稿を確認する間隔のデフォルト値です
+const defaultPublishInterval = time.Minute
+
+// PublishInterval は予約投稿を確認する間隔を返します
+func PublishInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("PUBLISH_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return defaultPublishInterval
+	}
+	return interval
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// SiteURL はフロントエンドのURLを末尾のスラッシュなしで返します
+func SiteURL() string {
+	siteURL := os.Getenv("SITE_URL")
+	if siteURL == "" {
+		siteURL = "https://mesimasi.com"
+	}
+	return strings.TrimSuffix(siteURL, "/")
+}
+
+// SiteTitle はブログのタイトルを返します
+func SiteTitle() string {
+	siteTitle := os.Getenv("SITE_TITLE")
+	if siteTitle == "" {
+		siteTitle = "mesimasi.com"
+	}
+	return siteTitle
+}
+
+// SiteAuthor はフィードに載せるブログの著者の名前を返します．設定されていない場合はブログのタイトルを返します
+func SiteAuthor() string {
+	siteAuthor := os.Getenv("SITE_AUTHOR")
+	if siteAuthor == "" {
+		siteAuthor = SiteTitle()
+	}
+	return siteAuthor
+}
+
+// SiteDescription はブログの説明を返します
+func SiteDescription() string {
+	return os.Getenv("SITE_DESCRIPTION")
+}
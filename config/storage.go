@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	// ImageStorageS3 は画像をS3(またはMinIOなどのS3互換ストレージ)に保存します
+	ImageStorageS3 = "s3"
+	// ImageStorageLocal は画像をサーバーのファイルシステムに保存し，サーバーから配信します
+	ImageStorageLocal = "local"
+)
+
+// defaultImageStorageDir はローカルに画像を保存するディレクトリのデフォルト値です
+const defaultImageStorageDir = "uploads"
+
+// LocalImagePath はローカルに保存した画像を配信するパスです
+const LocalImagePath = "/api/v1/uploads"
+
+// ImageStorage は画像の保存先を返します
+func ImageStorage() string {
+	if os.Getenv("IMAGE_STORAGE") == ImageStorageLocal {
+		return ImageStorageLocal
+	}
+	return ImageStorageS3
+}
+
+// ImageStorageDir はローカルに画像を保存するディレクトリを返します
+func ImageStorageDir() string {
+	dir := os.Getenv("IMAGE_STORAGE_DIR")
+	if dir == "" {
+		dir = defaultImageStorageDir
+	}
+	return dir
+}
+
+// ImagePublicURL は画像を公開しているURLを末尾のスラッシュなしで返します
+// 指定されていなければ空文字を返し，保存先ごとのURLを使います
+func ImagePublicURL() string {
+	return strings.TrimSuffix(os.Getenv("IMAGE_PUBLIC_URL"), "/")
+}
+
+// ImageUploadSecret はローカルに保存する際のアップロード用URLの署名に使う鍵を返します
+// IMAGE_UPLOAD_SECRET が指定されていなければ AUTH_KEY を使います
+func ImageUploadSecret() []byte {
+	secret := os.Getenv("IMAGE_UPLOAD_SECRET")
+	if secret == "" {
+		secret = os.Getenv("AUTH_KEY")
+	}
+	return []byte(secret)
+}
+
+// S3Config はS3に接続するための設定です
+type S3Config struct {
+	AccessKey string
+	SecretKey string
+	Region    string
+	Bucket    string
+	// Endpoint はMinIOなどS3互換ストレージのエンドポイントです．空ならAWSのS3を使います
+	Endpoint string
+}
+
+// S3 はS3に接続するための設定を返します
+func S3() S3Config {
+	return S3Config{
+		AccessKey: os.Getenv("AWS_ACCESS_KEY"),
+		SecretKey: os.Getenv("AWS_SECRET_KEY"),
+		Region:    os.Getenv("AWS_REGION"),
+		Bucket:    os.Getenv("AWS_S3_BUCKET_NAME"),
+		Endpoint:  strings.TrimSuffix(os.Getenv("AWS_S3_ENDPOINT"), "/"),
+	}
+}
@@ -1,9 +1,48 @@
 package constant
 
-// TODO 画像の投稿を実装したらデフォルト画像のURLを入れる
-
 // DefaultThumbnailURL はサムネイルのデフォルトURLです
+// 空のまま公開するとタイトルとタグを描いたOGP画像を自動で作ってサムネイルにします
 var DefaultThumbnailURL = ""
 
 // IdentityKey はjwtのIdentityKeyです
 var IdentityKey = "id"
+
+// FeedSize はフィードに含める投稿の数です
+var FeedSize = 20
+
+// SitemapMaxURLs は1つのサイトマップに含められるURLの上限です https://www.sitemaps.org/protocol.html
+var SitemapMaxURLs = 50000
+
+// SnippetLength は検索結果に含める本文の抜粋の文字数です
+var SnippetLength = 120
+
+// SnippetLeadingLength は抜粋に含める最初に一致した語より前の文字数です
+var SnippetLeadingLength = 30
+
+// DescriptionLength は本文から作る説明文の文字数です
+var DescriptionLength = 120
+
+// ExcerptLength は一覧に載せる本文の抜粋の文字数です
+var ExcerptLength = 200
+
+// CJKCharsPerMinute は日本語などの文章を1分間に読める文字数です
+var CJKCharsPerMinute = 500
+
+// WordsPerMinute は英語などの文章を1分間に読める単語数です
+var WordsPerMinute = 200
+
+// MaxImageSize はアップロードできる画像の最大バイト数です
+var MaxImageSize int64 = 10 << 20
+
+// MaxImagePixels はアップロードできる画像の最大画素数です．展開すると巨大になる画像を拒否するために使います
+var MaxImagePixels = 50000000
+
+// ImageWidths はアップロードした画像から作る画像の幅です(小さい順)
+var ImageWidths = []int{320, 640, 1280, 1920}
+
+// RelatedPostsSize は関連する投稿として返す投稿の数です
+var RelatedPostsSize = 5
+
+// RelatedTagWeight は関連する投稿の順位を決めるときの共通するタグ1つあたりの重みです
+// 本文の類似度(0から1)よりタグを重視します
+var RelatedTagWeight = 0.5
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/masibw/blog-server/domain/entity"
+	"gorm.io/gorm"
+)
+
+type ImageRepository struct {
+	db *gorm.DB
+}
+
+func NewImageRepository(db *gorm.DB) *ImageRepository {
+	return &ImageRepository{db: db}
+}
+
+// withAssociations は幅や形式を変えた画像を小さい順に，画像を使っている投稿や固定ページと合わせて読み込みます
+func (r *ImageRepository) withAssociations() *gorm.DB {
+	return r.db.Preload("Variants", func(db *gorm.DB) *gorm.DB {
+		return db.Order("width asc").Order("id asc")
+	}).Preload("Usages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("post_id asc")
+	}).Preload("PageUsages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("page_id asc")
+	})
+}
+
+func (r *ImageRepository) FindByID(id string) (*entity.Image, error) {
+	image := &entity.Image{}
+	if err := r.withAssociations().Where("id = ?", id).First(image).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find image: %w", entity.ErrImageNotFound)
+		}
+		return nil, fmt.Errorf("find image: %w", err)
+	}
+	return image, nil
+}
+
+func (r *ImageRepository) FindByKey(key string) (*entity.Image, error) {
+	image := &entity.Image{}
+	if err := r.withAssociations().Where("`key` = ?", key).First(image).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find image: %w", entity.ErrImageNotFound)
+		}
+		return nil, fmt.Errorf("find image: %w", err)
+	}
+	return image, nil
+}
+
+// FindAll は画像を新しい順に取得します．query を指定した場合はキーか代替テキストに含む画像に絞り込みます
+func (r *ImageRepository) FindAll(offset, pageSize int, query string) (images []*entity.Image, err error) {
+	if err = r.search(r.withAssociations(), query).Order("created_at desc").Order("id desc").Limit(pageSize).Offset(offset).Find(&images).Error; err != nil {
+		err = fmt.Errorf("find all images: %w", err)
+		return
+	}
+	if len(images) == 0 {
+		err = fmt.Errorf("find all images: %w", entity.ErrImageNotFound)
+		return
+	}
+	return
+}
+
+func (r *ImageRepository) Count(query string) (count int, err error) {
+	var count64 int64
+	if err = r.search(r.db.Model(&entity.Image{}), query).Count(&count64).Error; err != nil {
+		err = fmt.Errorf("count images: %w", err)
+		return
+	}
+	return int(count64), nil
+}
+
+func (r *ImageRepository) search(db *gorm.DB, query string) *gorm.DB {
+	if query == "" {
+		return db
+	}
+	like := "%" + escapeLike(query) + "%"
+	return db.Where("`key` LIKE ? OR alt_text LIKE ?", like, like)
+}
+
+// Create は画像を幅や形式を変えた画像と合わせて保存します
+func (r *ImageRepository) Create(image *entity.Image) error {
+	if err := r.db.Create(image).Error; err != nil {
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
+			return fmt.Errorf("create image: %w", entity.ErrImageAlreadyExisted)
+		}
+		return fmt.Errorf("create image: %w", err)
+	}
+	return nil
+}
+
+// Update は画像の代替テキストと大きさを更新します
+func (r *ImageRepository) Update(image *entity.Image) error {
+	result := r.db.Model(image).Select("alt_text", "width", "height").Updates(image)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("update image: %w", err)
+	}
+	return nil
+}
+
+func (r *ImageRepository) Delete(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&entity.Image{})
+	if err := result.Error; err != nil {
+		return fmt.Errorf("delete image: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete image: %w", entity.ErrImageNotFound)
+	}
+	return nil
+}
+
+// ReplaceUsages は画像を使っている投稿の記録を usages で，固定ページの記録を pageUsages で置き換えます
+func (r *ImageRepository) ReplaceUsages(imageID string, usages []*entity.ImageUsage, pageUsages []*entity.ImagePageUsage) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("image_id = ?", imageID).Delete(&entity.ImageUsage{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("image_id = ?", imageID).Delete(&entity.ImagePageUsage{}).Error; err != nil {
+			return err
+		}
+		if len(usages) > 0 {
+			if err := tx.Create(&usages).Error; err != nil {
+				return err
+			}
+		}
+		if len(pageUsages) == 0 {
+			return nil
+		}
+		return tx.Create(&pageUsages).Error
+	})
+	if err != nil {
+		return fmt.Errorf("replace image usages: %w", err)
+	}
+	return nil
+}
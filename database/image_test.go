@@ -0,0 +1,341 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/Songmu/flextime"
+
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+func TestImageRepository_FindByID(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	if err := tx.Create(&entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxy1",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      false,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Create(&entity.Image{
+		ID:          "abcdefghijklmnopqrstuvwxy2",
+		Key:         "images/abcdefghijklmnopqrstuvwxy2/640w.jpg",
+		ContentType: "image/jpeg",
+		Width:       640,
+		Height:      480,
+		AltText:     "alt",
+		Variants: []*entity.ImageVariant{
+			{
+				ID:          "abcdefghijklmnopqrstuvwxy4",
+				ImageID:     "abcdefghijklmnopqrstuvwxy2",
+				Key:         "images/abcdefghijklmnopqrstuvwxy2/640w.jpg",
+				ContentType: "image/jpeg",
+				Width:       640,
+				Height:      480,
+				CreatedAt:   flextime.Now(),
+				UpdatedAt:   flextime.Now(),
+			},
+			{
+				ID:          "abcdefghijklmnopqrstuvwxy3",
+				ImageID:     "abcdefghijklmnopqrstuvwxy2",
+				Key:         "images/abcdefghijklmnopqrstuvwxy2/320w.jpg",
+				ContentType: "image/jpeg",
+				Width:       320,
+				Height:      240,
+				CreatedAt:   flextime.Now(),
+				UpdatedAt:   flextime.Now(),
+			},
+		},
+		Usages: []*entity.ImageUsage{
+			{
+				ID:        "abcdefghijklmnopqrstuvwxy5",
+				ImageID:   "abcdefghijklmnopqrstuvwxy2",
+				PostID:    "abcdefghijklmnopqrstuvwxy1",
+				CreatedAt: flextime.Now(),
+				UpdatedAt: flextime.Now(),
+			},
+		},
+		CreatedAt: flextime.Now(),
+		UpdatedAt: flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ID      string
+		want    *entity.Image
+		wantErr error
+	}{
+		{
+			name: "存在する画像を幅の小さい順の画像と使っている投稿と合わせて取得できる",
+			ID:   "abcdefghijklmnopqrstuvwxy2",
+			want: &entity.Image{
+				ID:          "abcdefghijklmnopqrstuvwxy2",
+				Key:         "images/abcdefghijklmnopqrstuvwxy2/640w.jpg",
+				ContentType: "image/jpeg",
+				Width:       640,
+				Height:      480,
+				AltText:     "alt",
+				Variants: []*entity.ImageVariant{
+					{
+						ID:          "abcdefghijklmnopqrstuvwxy3",
+						ImageID:     "abcdefghijklmnopqrstuvwxy2",
+						Key:         "images/abcdefghijklmnopqrstuvwxy2/320w.jpg",
+						ContentType: "image/jpeg",
+						Width:       320,
+						Height:      240,
+						CreatedAt:   flextime.Now(),
+						UpdatedAt:   flextime.Now(),
+					},
+					{
+						ID:          "abcdefghijklmnopqrstuvwxy4",
+						ImageID:     "abcdefghijklmnopqrstuvwxy2",
+						Key:         "images/abcdefghijklmnopqrstuvwxy2/640w.jpg",
+						ContentType: "image/jpeg",
+						Width:       640,
+						Height:      480,
+						CreatedAt:   flextime.Now(),
+						UpdatedAt:   flextime.Now(),
+					},
+				},
+				Usages: []*entity.ImageUsage{
+					{
+						ID:        "abcdefghijklmnopqrstuvwxy5",
+						ImageID:   "abcdefghijklmnopqrstuvwxy2",
+						PostID:    "abcdefghijklmnopqrstuvwxy1",
+						CreatedAt: flextime.Now(),
+						UpdatedAt: flextime.Now(),
+					},
+				},
+				CreatedAt: flextime.Now(),
+				UpdatedAt: flextime.Now(),
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "存在しないIDの場合ErrImageNotFoundを返す",
+			ID:      "not_found",
+			want:    nil,
+			wantErr: entity.ErrImageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ImageRepository{db: tx}
+			got, err := r.FindByID(tt.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FindByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FindByID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
+
+func TestImageRepository_FindAll(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	for i, image := range []*entity.Image{
+		{ID: "abcdefghijklmnopqrstuvwxy1", Key: "cat.png", ContentType: "image/png", AltText: "三毛猫"},
+		{ID: "abcdefghijklmnopqrstuvwxy2", Key: "dog_100%.png", ContentType: "image/png", AltText: "柴犬"},
+		{ID: "abcdefghijklmnopqrstuvwxy3", Key: "dog.png", ContentType: "image/png", AltText: ""},
+	} {
+		image.CreatedAt = flextime.Now().Add(time.Duration(i) * time.Hour)
+		image.UpdatedAt = image.CreatedAt
+		if err := tx.Create(image).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantIDs   []string
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name:      "すべての画像を新しい順に取得できる",
+			query:     "",
+			wantIDs:   []string{"abcdefghijklmnopqrstuvwxy3", "abcdefghijklmnopqrstuvwxy2", "abcdefghijklmnopqrstuvwxy1"},
+			wantCount: 3,
+			wantErr:   nil,
+		},
+		{
+			name:      "キーで検索できる",
+			query:     "dog",
+			wantIDs:   []string{"abcdefghijklmnopqrstuvwxy3", "abcdefghijklmnopqrstuvwxy2"},
+			wantCount: 2,
+			wantErr:   nil,
+		},
+		{
+			name:      "代替テキストで検索できる",
+			query:     "猫",
+			wantIDs:   []string{"abcdefghijklmnopqrstuvwxy1"},
+			wantCount: 1,
+			wantErr:   nil,
+		},
+		{
+			name:      "ワイルドカードの文字はそのまま検索される",
+			query:     "_100%",
+			wantIDs:   []string{"abcdefghijklmnopqrstuvwxy2"},
+			wantCount: 1,
+			wantErr:   nil,
+		},
+		{
+			name:      "一致する画像が無い場合ErrImageNotFoundを返す",
+			query:     "bird",
+			wantIDs:   nil,
+			wantCount: 0,
+			wantErr:   entity.ErrImageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ImageRepository{db: tx}
+			got, err := r.FindAll(0, 10, tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FindAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var gotIDs []string
+			for _, image := range got {
+				gotIDs = append(gotIDs, image.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("FindAll() mismatch (-want +got):\n%s", diff)
+			}
+
+			count, err := r.Count(tt.query)
+			if err != nil {
+				t.Errorf("Count() error = %v", err)
+			}
+			if count != tt.wantCount {
+				t.Errorf("Count() = %v, want = %v", count, tt.wantCount)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
+
+func TestImageRepository_ReplaceUsages(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	for _, post := range []*entity.Post{
+		{ID: "abcdefghijklmnopqrstuvwxy1", Permalink: "post1"},
+		{ID: "abcdefghijklmnopqrstuvwxy2", Permalink: "post2"},
+	} {
+		post.CreatedAt, post.UpdatedAt, post.PublishedAt = flextime.Now(), flextime.Now(), flextime.Now()
+		if err := tx.Create(post).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tx.Create(&entity.Page{ID: "abcdefghijklmnopqrstuvwxy6", Title: "About", Path: "about"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Create(&entity.Image{
+		ID:          "abcdefghijklmnopqrstuvwxy3",
+		Key:         "image.png",
+		ContentType: "image/png",
+		Usages: []*entity.ImageUsage{
+			{ID: "abcdefghijklmnopqrstuvwxy4", ImageID: "abcdefghijklmnopqrstuvwxy3", PostID: "abcdefghijklmnopqrstuvwxy1"},
+		},
+		CreatedAt: flextime.Now(),
+		UpdatedAt: flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		usages      []*entity.ImageUsage
+		pageUsages  []*entity.ImagePageUsage
+		wantPostIDs []string
+		wantPageIDs []string
+	}{
+		{
+			name: "使っている投稿と固定ページの記録を置き換えられる",
+			usages: []*entity.ImageUsage{
+				{ID: "abcdefghijklmnopqrstuvwxy5", ImageID: "abcdefghijklmnopqrstuvwxy3", PostID: "abcdefghijklmnopqrstuvwxy2"},
+			},
+			pageUsages: []*entity.ImagePageUsage{
+				{ID: "abcdefghijklmnopqrstuvwxy7", ImageID: "abcdefghijklmnopqrstuvwxy3", PageID: "abcdefghijklmnopqrstuvwxy6"},
+			},
+			wantPostIDs: []string{"abcdefghijklmnopqrstuvwxy2"},
+			wantPageIDs: []string{"abcdefghijklmnopqrstuvwxy6"},
+		},
+		{
+			name:        "空にすると記録が無くなる",
+			usages:      []*entity.ImageUsage{},
+			pageUsages:  []*entity.ImagePageUsage{},
+			wantPostIDs: nil,
+			wantPageIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ImageRepository{db: tx}
+			if err := r.ReplaceUsages("abcdefghijklmnopqrstuvwxy3", tt.usages, tt.pageUsages); err != nil {
+				t.Fatalf("ReplaceUsages() error = %v", err)
+			}
+			image, err := r.FindByID("abcdefghijklmnopqrstuvwxy3")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotPostIDs []string
+			for _, usage := range image.Usages {
+				gotPostIDs = append(gotPostIDs, usage.PostID)
+			}
+			if diff := cmp.Diff(tt.wantPostIDs, gotPostIDs, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ReplaceUsages() mismatch (-want +got):\n%s", diff)
+			}
+			var gotPageIDs []string
+			for _, usage := range image.PageUsages {
+				gotPageIDs = append(gotPageIDs, usage.PageID)
+			}
+			if diff := cmp.Diff(tt.wantPageIDs, gotPageIDs, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ReplaceUsages() page mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
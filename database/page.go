@@ -0,0 +1,112 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/masibw/blog-server/domain/entity"
+	"gorm.io/gorm"
+)
+
+type PageRepository struct {
+	db *gorm.DB
+}
+
+func NewPageRepository(db *gorm.DB) *PageRepository {
+	return &PageRepository{db: db}
+}
+
+func (r *PageRepository) FindByID(id string) (*entity.Page, error) {
+	page := &entity.Page{}
+	if err := r.db.Where("id = ?", id).First(page).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find page: %w", entity.ErrPageNotFound)
+		}
+		return nil, fmt.Errorf("find page: %w", err)
+	}
+	return page, nil
+}
+
+func (r *PageRepository) FindByPath(path string) (*entity.Page, error) {
+	page := &entity.Page{}
+	if err := r.db.Where("path = ?", path).First(page).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find page path=%v: %w", path, entity.ErrPageNotFound)
+		}
+		return nil, fmt.Errorf("find page path=%v: %w", path, err)
+	}
+	return page, nil
+}
+
+// FindAll は条件に一致する固定ページをパスの順に取得します．condition が空の場合は全ての固定ページを取得します
+func (r *PageRepository) FindAll(condition string, params []interface{}) (pages []*entity.Page, err error) {
+	db := r.db
+	if condition != "" {
+		db = db.Where(condition, params...)
+	}
+	if err = db.Order("path asc").Find(&pages).Error; err != nil {
+		err = fmt.Errorf("find all pages: %w", err)
+		return
+	}
+	if len(pages) == 0 {
+		err = fmt.Errorf("find all pages: %w", entity.ErrPageNotFound)
+		return
+	}
+	return
+}
+
+func (r *PageRepository) Store(page *entity.Page) error {
+	if err := r.db.Create(page).Error; err != nil {
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
+			return fmt.Errorf("create page: %w", entity.ErrPagePathAlreadyExisted)
+		}
+		return fmt.Errorf("create page: %w", err)
+	}
+	return nil
+}
+
+func (r *PageRepository) Update(page *entity.Page) error {
+	if err := r.db.Model(page).Select("title", "content", "path", "is_draft").Updates(page).Error; err != nil {
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
+			return fmt.Errorf("update page: %w", entity.ErrPagePathAlreadyExisted)
+		}
+		return fmt.Errorf("update page: %w", err)
+	}
+	return nil
+}
+
+func (r *PageRepository) Delete(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&entity.Page{})
+	if err := result.Error; err != nil {
+		return fmt.Errorf("delete page: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete page: %w", entity.ErrPageNotFound)
+	}
+	return nil
+}
+
+// FindReferencing は本文に substrings のいずれかを含む固定ページを取得します
+func (r *PageRepository) FindReferencing(substrings []string) (pages []*entity.Page, err error) {
+	if len(substrings) == 0 {
+		err = fmt.Errorf("find referencing pages: %w", entity.ErrPageNotFound)
+		return
+	}
+	conditions := make([]string, 0, len(substrings))
+	params := make([]interface{}, 0, len(substrings))
+	for _, s := range substrings {
+		conditions = append(conditions, "content LIKE ?")
+		params = append(params, "%"+escapeLike(s)+"%")
+	}
+	if err = r.db.Where(strings.Join(conditions, " OR "), params...).Order("id asc").Find(&pages).Error; err != nil {
+		err = fmt.Errorf("find referencing pages: %w", err)
+		return
+	}
+	if len(pages) == 0 {
+		err = fmt.Errorf("find referencing pages: %w", entity.ErrPageNotFound)
+		return
+	}
+	return
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+func TestPageRepository_FindAll(t *testing.T) {
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	r := &PageRepository{db: tx}
+	for _, page := range []*entity.Page{
+		{ID: "abcdefghijklmnopqrstuvwxy1", Title: "Company", Path: "company"},
+		{ID: "abcdefghijklmnopqrstuvwxy2", Title: "About", Path: "company/about"},
+		{ID: "abcdefghijklmnopqrstuvwxy3", Title: "Team", Path: "company/about/team"},
+		{ID: "abcdefghijklmnopqrstuvwxy4", Title: "Access", Path: "company/access"},
+	} {
+		if err := r.Store(page); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// company の1つ下の階層だけを取得する
+	pages, err := r.FindAll("pages.path LIKE ? AND pages.path NOT LIKE ?", []interface{}{"company/%", "company/%/%"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, page := range pages {
+		got = append(got, page.Path)
+	}
+	if diff := cmp.Diff([]string{"company/about", "company/access"}, got); diff != "" {
+		t.Errorf("FindAll() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := r.FindAll("pages.path LIKE ?", []interface{}{"company/access/%"}); !errors.Is(err, entity.ErrPageNotFound) {
+		t.Errorf("FindAll() error = %v, wantErr %v", err, entity.ErrPageNotFound)
+	}
+}
+
+func TestPageRepository_Store(t *testing.T) {
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	r := &PageRepository{db: tx}
+	if err := r.Store(&entity.Page{ID: "abcdefghijklmnopqrstuvwxy1", Title: "About", Path: "about"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Store(&entity.Page{ID: "abcdefghijklmnopqrstuvwxy2", Title: "About", Path: "about"}); !errors.Is(err, entity.ErrPagePathAlreadyExisted) {
+		t.Errorf("Store() error = %v, wantErr %v", err, entity.ErrPagePathAlreadyExisted)
+	}
+}
+
+func TestPageRepository_FindReferencing(t *testing.T) {
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	r := &PageRepository{db: tx}
+	for _, page := range []*entity.Page{
+		{ID: "abcdefghijklmnopqrstuvwxy1", Title: "About", Path: "about", Content: "![cat](https://example.com/cat.png)"},
+		{ID: "abcdefghijklmnopqrstuvwxy2", Title: "Privacy", Path: "privacy", Content: "![cat](https://example.com/cats.png)"},
+	} {
+		if err := r.Store(page); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		substrings []string
+		wantIDs    []string
+		wantErr    error
+	}{
+		{
+			name:       "本文に含む固定ページを取得できる",
+			substrings: []string{"https://example.com/cat.png"},
+			wantIDs:    []string{"abcdefghijklmnopqrstuvwxy1"},
+			wantErr:    nil,
+		},
+		{
+			name:       "含む固定ページが無い場合ErrPageNotFoundを返す",
+			substrings: []string{"https://example.com/c_t.png"},
+			wantIDs:    nil,
+			wantErr:    entity.ErrPageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.FindReferencing(tt.substrings)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FindReferencing() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var gotIDs []string
+			for _, page := range got {
+				gotIDs = append(gotIDs, page.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("FindReferencing() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/masibw/blog-server/domain/entity"
+	"gorm.io/gorm"
+)
+
+type PermalinkRedirectRepository struct {
+	db *gorm.DB
+}
+
+func NewPermalinkRedirectRepository(db *gorm.DB) *PermalinkRedirectRepository {
+	return &PermalinkRedirectRepository{db: db}
+}
+
+func (r *PermalinkRedirectRepository) FindByID(id string) (*entity.PermalinkRedirect, error) {
+	redirect := &entity.PermalinkRedirect{}
+	if err := r.db.Where("id = ?", id).First(redirect).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find permalink redirect: %w", entity.ErrPermalinkRedirectNotFound)
+		}
+		return nil, fmt.Errorf("find permalink redirect: %w", err)
+	}
+	return redirect, nil
+}
+
+func (r *PermalinkRedirectRepository) FindByPermalink(permalink string) (*entity.PermalinkRedirect, error) {
+	redirect := &entity.PermalinkRedirect{}
+	if err := r.db.Where("permalink = ?", permalink).First(redirect).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find permalink redirect: %w", entity.ErrPermalinkRedirectNotFound)
+		}
+		return nil, fmt.Errorf("find permalink redirect: %w", err)
+	}
+	return redirect, nil
+}
+
+// FindByPostID は投稿の変更前のパーマリンクを新しい順に取得します
+func (r *PermalinkRedirectRepository) FindByPostID(postID string) (redirects []*entity.PermalinkRedirect, err error) {
+	if err = r.db.Where("post_id = ?", postID).Order("created_at desc").Order("id desc").Find(&redirects).Error; err != nil {
+		err = fmt.Errorf("find permalink redirects: %w", err)
+		return
+	}
+	if len(redirects) == 0 {
+		err = fmt.Errorf("find permalink redirects: %w", entity.ErrPermalinkRedirectNotFound)
+		return
+	}
+	return
+}
+
+func (r *PermalinkRedirectRepository) Create(redirect *entity.PermalinkRedirect) error {
+	if err := r.db.Create(redirect).Error; err != nil {
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
+			return fmt.Errorf("create permalink redirect: %w", entity.ErrPermalinkRedirectAlreadyExisted)
+		}
+		return fmt.Errorf("create permalink redirect: %w", err)
+	}
+	return nil
+}
+
+func (r *PermalinkRedirectRepository) Delete(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&entity.PermalinkRedirect{})
+	if err := result.Error; err != nil {
+		return fmt.Errorf("delete permalink redirect: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete permalink redirect: %w", entity.ErrPermalinkRedirectNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Songmu/flextime"
+
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+func TestPermalinkRedirectRepository_FindByPermalink(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	if err := tx.Create(&entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxy1",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      false,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Create(&entity.PermalinkRedirect{
+		ID:        "abcdefghijklmnopqrstuvwxy2",
+		PostID:    "abcdefghijklmnopqrstuvwxy1",
+		Permalink: "old_permalink",
+		CreatedAt: flextime.Now(),
+		UpdatedAt: flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		permalink string
+		want      *entity.PermalinkRedirect
+		wantErr   error
+	}{
+		{
+			name:      "変更前のパーマリンクからリダイレクトを取得できる",
+			permalink: "old_permalink",
+			want: &entity.PermalinkRedirect{
+				ID:        "abcdefghijklmnopqrstuvwxy2",
+				PostID:    "abcdefghijklmnopqrstuvwxy1",
+				Permalink: "old_permalink",
+				CreatedAt: flextime.Now(),
+				UpdatedAt: flextime.Now(),
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "リダイレクトがない場合ErrPermalinkRedirectNotFoundを返す",
+			permalink: "new_permalink",
+			want:      nil,
+			wantErr:   entity.ErrPermalinkRedirectNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PermalinkRedirectRepository{db: tx}
+			got, err := r.FindByPermalink(tt.permalink)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FindByPermalink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FindByPermalink() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
@@ -3,6 +3,8 @@ package database
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/masibw/blog-server/domain/entity"
@@ -51,16 +53,75 @@ func (r *PostRepository) Create(post *entity.Post) error {
 	return nil
 }
 
+// Update は投稿を更新してバージョンを1つ増やします
+// 読み込んだ後に他で更新されてバージョンが変わっていた場合は更新せず ErrPostVersionConflict を返します
 func (r *PostRepository) Update(post *entity.Post) error {
-
-	if err := r.db.Select("*").Updates(post).Error; err != nil {
+	version := post.Version
+	post.Version++
+	result := r.db.Where("version = ?", version).Select("*").Updates(post)
+	if err := result.Error; err != nil {
+		post.Version = version
 		return fmt.Errorf("update post: %w", err)
 	}
+	if result.RowsAffected == 0 {
+		post.Version = version
+		return fmt.Errorf("update post id=%v version=%v: %w", post.ID, version, entity.ErrPostVersionConflict)
+	}
 	return nil
 }
 
-func (r *PostRepository) FindAll(offset, pageSize int, condition string, params []interface{}, sortCondition string) (posts []*entity.Post, err error) {
-	if err = r.db.Distinct().Where(condition, params...).Order(sortCondition).Limit(pageSize).Offset(offset).Joins("LEFT JOIN posts_tags on posts_tags.post_id = posts.id").Joins("LEFT JOIN tags on posts_tags.tag_id = tags.id").Find(&posts).Error; err != nil {
+// UpdateWithHistory は上書きされる前の内容を revision として残し，変更前のパーマリンクから redirect でリダイレクトするようにして投稿を更新します
+// 以前のパーマリンクに戻した場合は不要になった reclaimedRedirect を削除します
+// 1つのトランザクションで行うので，投稿を更新できなかった場合はリビジョンもリダイレクトも変わりません．nil を渡したものは作成や削除をしません
+func (r *PostRepository) UpdateWithHistory(post *entity.Post, revision *entity.PostRevision, redirect, reclaimedRedirect *entity.PermalinkRedirect) error {
+	version := post.Version
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if revision != nil {
+			if err := (&PostRevisionRepository{db: tx}).Create(revision); err != nil {
+				return err
+			}
+		}
+		if err := (&PostRepository{db: tx}).Update(post); err != nil {
+			return err
+		}
+		redirectRepository := &PermalinkRedirectRepository{db: tx}
+		if reclaimedRedirect != nil {
+			if err := redirectRepository.Delete(reclaimedRedirect.ID); err != nil {
+				return err
+			}
+		}
+		if redirect != nil {
+			return redirectRepository.Create(redirect)
+		}
+		return nil
+	})
+	if err != nil {
+		post.Version = version
+		return fmt.Errorf("update post with history: %w", err)
+	}
+	return nil
+}
+
+// UpdatePin は投稿を固定するかとおすすめの順番を更新します
+// 投稿の内容は変わらないので更新日時は変えません
+func (r *PostRepository) UpdatePin(id string, isPinned bool, featuredRank int) error {
+	if err := r.db.Model(&entity.Post{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{"is_pinned": isPinned, "featured_rank": featuredRank}).Error; err != nil {
+		return fmt.Errorf("update post pin: %w", err)
+	}
+	return nil
+}
+
+// FindAll は条件とタグに一致する投稿を取得します．fields にカラムを指定した場合はそのカラムだけを取得します
+// sortCondition が空の場合は並べ替えません
+func (r *PostRepository) FindAll(offset, pageSize int, condition string, params []interface{}, tagFilter *entity.TagFilter, sortCondition string, fields []string) (posts []*entity.Post, err error) {
+	db := filterByTags(r.db.Distinct(), tagFilter)
+	if len(fields) > 0 {
+		db = db.Select(fields)
+	}
+	if sortCondition != "" {
+		db = db.Order(sortCondition)
+	}
+	if err = db.Where(condition, params...).Limit(pageSize).Offset(offset).Joins("LEFT JOIN posts_tags on posts_tags.post_id = posts.id").Joins("LEFT JOIN tags on posts_tags.tag_id = tags.id AND tags.deleted_at IS NULL").Find(&posts).Error; err != nil {
 		err = fmt.Errorf("find all posts: %w", err)
 		return
 	}
@@ -82,9 +143,9 @@ func (r *PostRepository) Delete(id string) error {
 	return nil
 }
 
-func (r *PostRepository) Count(condition string, params []interface{}) (count int, err error) {
+func (r *PostRepository) Count(condition string, params []interface{}, tagFilter *entity.TagFilter) (count int, err error) {
 	var count64 int64
-	if err = r.db.Model(&entity.Post{}).Distinct("posts.id").Where(condition, params...).Joins("LEFT JOIN posts_tags on posts_tags.post_id = posts.id").Joins("LEFT JOIN tags on posts_tags.tag_id = tags.id").Count(&count64).Error; err != nil {
+	if err = filterByTags(r.db.Model(&entity.Post{}).Distinct("posts.id"), tagFilter).Where(condition, params...).Joins("LEFT JOIN posts_tags on posts_tags.post_id = posts.id").Joins("LEFT JOIN tags on posts_tags.tag_id = tags.id AND tags.deleted_at IS NULL").Count(&count64).Error; err != nil {
 		err = fmt.Errorf("find all posts: %w", err)
 		return
 	}
@@ -92,3 +153,181 @@ func (r *PostRepository) Count(condition string, params []interface{}) (count in
 	count = int(count64)
 	return
 }
+
+// hasTag は投稿にサブクエリで指定した名前のタグとその子孫のタグのいずれかが付いているかの条件です
+// 投稿とタグを結合した行ではなく投稿ごとに調べるので，複数のタグの条件を組み合わせても Count と結果の件数が一致します
+// 親タグが循環していても止まるようにUNION(重複除去)で再帰します
+const hasTag = "EXISTS (SELECT 1 FROM posts_tags AS filter_posts_tags WHERE filter_posts_tags.post_id = posts.id AND filter_posts_tags.tag_id IN (WITH RECURSIVE subtree (id) AS (SELECT id FROM tags WHERE name IN ? AND deleted_at IS NULL UNION SELECT child.id FROM tags AS child INNER JOIN subtree ON child.parent_id = subtree.id WHERE child.deleted_at IS NULL) SELECT id FROM subtree))"
+
+// filterByTags はタグで投稿を絞り込む条件を加えます
+func filterByTags(db *gorm.DB, tagFilter *entity.TagFilter) *gorm.DB {
+	if tagFilter.IsEmpty() {
+		return db
+	}
+	if len(tagFilter.Names) > 0 {
+		if tagFilter.MatchAll {
+			for _, name := range tagFilter.Names {
+				db = db.Where(hasTag, []string{name})
+			}
+		} else {
+			db = db.Where(hasTag, tagFilter.Names)
+		}
+	}
+	if len(tagFilter.ExcludedNames) > 0 {
+		db = db.Where("NOT "+hasTag, tagFilter.ExcludedNames)
+	}
+	return db
+}
+
+// fullTextMatch はタイトルと本文に対する全文検索の条件です
+const fullTextMatch = "MATCH (posts.title, posts.content) AGAINST (? IN NATURAL LANGUAGE MODE)"
+
+// searchCondition は全文検索の条件と追加の条件を結合します
+func searchCondition(query string, condition string, params []interface{}) (string, []interface{}) {
+	searchParams := append([]interface{}{query}, params...)
+	if condition == "" {
+		return fullTextMatch, searchParams
+	}
+	return fullTextMatch + " AND (" + condition + ")", searchParams
+}
+
+// Search は全文検索に一致する投稿を関連度の高い順に取得します
+func (r *PostRepository) Search(query string, offset, pageSize int, condition string, params []interface{}, tagFilter *entity.TagFilter) (posts []*entity.Post, err error) {
+	condition, params = searchCondition(query, condition, params)
+	if err = filterByTags(r.db.Distinct(), tagFilter).Select("posts.*, "+fullTextMatch+" AS score", query).Where(condition, params...).Order("score desc").Order("posts.id asc").Limit(pageSize).Offset(offset).Joins("LEFT JOIN posts_tags on posts_tags.post_id = posts.id").Joins("LEFT JOIN tags on posts_tags.tag_id = tags.id AND tags.deleted_at IS NULL").Find(&posts).Error; err != nil {
+		err = fmt.Errorf("search posts: %w", err)
+		return
+	}
+	if len(posts) == 0 {
+		err = fmt.Errorf("search posts: %w", entity.ErrPostNotFound)
+		return
+	}
+	return
+}
+
+func (r *PostRepository) CountSearch(query string, condition string, params []interface{}, tagFilter *entity.TagFilter) (count int, err error) {
+	var count64 int64
+	condition, params = searchCondition(query, condition, params)
+	if err = filterByTags(r.db.Model(&entity.Post{}).Distinct("posts.id"), tagFilter).Where(condition, params...).Joins("LEFT JOIN posts_tags on posts_tags.post_id = posts.id").Joins("LEFT JOIN tags on posts_tags.tag_id = tags.id AND tags.deleted_at IS NULL").Count(&count64).Error; err != nil {
+		err = fmt.Errorf("count search posts: %w", err)
+		return
+	}
+	// int64を溢れることは運用的にないのでキャストしてしまう
+	count = int(count64)
+	return
+}
+
+// CountByMonth は条件に一致する投稿の数を公開日時の年月ごとに新しい順で取得します
+// 公開日時はDSNで指定したタイムゾーン(JST)で保存しているので，その年月で集計します
+func (r *PostRepository) CountByMonth(condition string, params []interface{}) (counts []*entity.MonthlyPostCount, err error) {
+	if err = r.db.Model(&entity.Post{}).Select("YEAR(posts.published_at) AS year, MONTH(posts.published_at) AS month, COUNT(*) AS count").Where(condition, params...).Group("year, month").Order("year desc, month desc").Scan(&counts).Error; err != nil {
+		err = fmt.Errorf("count posts by month: %w", err)
+		return
+	}
+	return
+}
+
+// CountSharedTags は postID の投稿と共通するタグが付いた投稿ごとに共通するタグの数を取得します
+func (r *PostRepository) CountSharedTags(postID string) (counts map[string]int, err error) {
+	var rows []struct {
+		PostID string
+		Count  int
+	}
+	if err = r.db.Table("posts_tags AS target").Select("other.post_id AS post_id, COUNT(*) AS count").Joins("INNER JOIN posts_tags AS other ON other.tag_id = target.tag_id AND other.post_id <> target.post_id").Joins("INNER JOIN tags ON tags.id = target.tag_id AND tags.deleted_at IS NULL").Where("target.post_id = ?", postID).Group("other.post_id").Scan(&rows).Error; err != nil {
+		err = fmt.Errorf("count shared tags post id=%v: %w", postID, err)
+		return
+	}
+
+	counts = make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.PostID] = row.Count
+	}
+	return
+}
+
+// FindDeleted はゴミ箱にある投稿をゴミ箱に移動した日時が新しい順に取得します
+func (r *PostRepository) FindDeleted() (posts []*entity.Post, err error) {
+	if err = r.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&posts).Error; err != nil {
+		err = fmt.Errorf("find deleted posts: %w", err)
+		return
+	}
+	if len(posts) == 0 {
+		err = fmt.Errorf("find deleted posts: %w", entity.ErrPostNotFound)
+		return
+	}
+	return
+}
+
+func (r *PostRepository) FindDeletedByID(id string) (*entity.Post, error) {
+	post := &entity.Post{}
+	if err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(post).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find deleted post: %w", entity.ErrPostNotFound)
+		}
+		return nil, fmt.Errorf("find deleted post: %w", err)
+	}
+	return post, nil
+}
+
+// Restore はゴミ箱にある投稿を元に戻します
+func (r *PostRepository) Restore(id string) error {
+	result := r.db.Unscoped().Model(&entity.Post{}).Where("id = ? AND deleted_at IS NOT NULL", id).UpdateColumn("deleted_at", nil)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("restore post: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("restore post: %w", entity.ErrPostNotFound)
+	}
+	return nil
+}
+
+// Purge は before より前にゴミ箱に移動した投稿をタグとの関連ごと完全に削除し，削除した投稿の数を返します
+func (r *PostRepository) Purge(before time.Time) (count int, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		deletedPostIDs := tx.Unscoped().Model(&entity.Post{}).Select("id").Where("deleted_at < ?", before)
+		if err := tx.Where("post_id IN (?)", deletedPostIDs).Delete(&entity.PostsTags{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("deleted_at < ?", before).Delete(&entity.Post{})
+		if err := result.Error; err != nil {
+			return err
+		}
+		count = int(result.RowsAffected)
+		return nil
+	})
+	if err != nil {
+		err = fmt.Errorf("purge posts: %w", err)
+		return
+	}
+	return
+}
+
+// FindReferencing は本文かサムネイルのURLに substrings のいずれかを含む投稿を取得します
+// ゴミ箱にある投稿は元に戻せるので含めます
+func (r *PostRepository) FindReferencing(substrings []string) (posts []*entity.Post, err error) {
+	if len(substrings) == 0 {
+		err = fmt.Errorf("find referencing posts: %w", entity.ErrPostNotFound)
+		return
+	}
+	conditions := make([]string, 0, len(substrings))
+	params := make([]interface{}, 0, 2*len(substrings))
+	for _, s := range substrings {
+		conditions = append(conditions, "content LIKE ? OR thumbnail_url LIKE ?")
+		like := "%" + escapeLike(s) + "%"
+		params = append(params, like, like)
+	}
+	if err = r.db.Unscoped().Where(strings.Join(conditions, " OR "), params...).Order("id asc").Find(&posts).Error; err != nil {
+		err = fmt.Errorf("find referencing posts: %w", err)
+		return
+	}
+	if len(posts) == 0 {
+		err = fmt.Errorf("find referencing posts: %w", entity.ErrPostNotFound)
+		return
+	}
+	return
+}
+
+// escapeLike はLIKEのワイルドカードとして扱われる文字をエスケープします
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
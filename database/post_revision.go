@@ -0,0 +1,76 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/masibw/blog-server/domain/entity"
+	"gorm.io/gorm"
+)
+
+type PostRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewPostRevisionRepository(db *gorm.DB) *PostRevisionRepository {
+	return &PostRevisionRepository{db: db}
+}
+
+func (r *PostRevisionRepository) FindByID(id string) (*entity.PostRevision, error) {
+	revision := &entity.PostRevision{}
+	if err := r.db.Where("id = ?", id).First(revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find post revision: %w", entity.ErrPostRevisionNotFound)
+		}
+		return nil, fmt.Errorf("find post revision: %w", err)
+	}
+	return revision, nil
+}
+
+// FindByPostID は投稿のリビジョンを新しい順に取得します
+func (r *PostRevisionRepository) FindByPostID(postID string, offset, pageSize int) (revisions []*entity.PostRevision, err error) {
+	if err = r.db.Where("post_id = ?", postID).Order("created_at desc").Order("id desc").Limit(pageSize).Offset(offset).Find(&revisions).Error; err != nil {
+		err = fmt.Errorf("find post revisions: %w", err)
+		return
+	}
+	if len(revisions) == 0 {
+		err = fmt.Errorf("find post revisions: %w", entity.ErrPostRevisionNotFound)
+		return
+	}
+	return
+}
+
+// FindAll はすべての投稿のすべてのリビジョンを取得します
+func (r *PostRevisionRepository) FindAll() (revisions []*entity.PostRevision, err error) {
+	if err = r.db.Order("id asc").Find(&revisions).Error; err != nil {
+		err = fmt.Errorf("find all post revisions: %w", err)
+		return
+	}
+	if len(revisions) == 0 {
+		err = fmt.Errorf("find all post revisions: %w", entity.ErrPostRevisionNotFound)
+		return
+	}
+	return
+}
+
+func (r *PostRevisionRepository) Create(revision *entity.PostRevision) error {
+	if err := r.db.Create(revision).Error; err != nil {
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
+			return fmt.Errorf("create post revision: %w", entity.ErrPostRevisionAlreadyExisted)
+		}
+		return fmt.Errorf("create post revision: %w", err)
+	}
+	return nil
+}
+
+func (r *PostRevisionRepository) CountByPostID(postID string) (count int, err error) {
+	var count64 int64
+	if err = r.db.Model(&entity.PostRevision{}).Where("post_id = ?", postID).Count(&count64).Error; err != nil {
+		err = fmt.Errorf("count post revisions: %w", err)
+		return
+	}
+	// int64を溢れることは運用的にないのでキャストしてしまう
+	count = int(count64)
+	return
+}
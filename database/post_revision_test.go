@@ -0,0 +1,360 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Songmu/flextime"
+
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+func TestPostRevisionRepository_FindByID(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	if err := tx.Create(&entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxy1",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      false,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Create(&entity.PostRevision{
+		ID:           "abcdefghijklmnopqrstuvwxy2",
+		PostID:       "abcdefghijklmnopqrstuvwxy1",
+		Title:        "old_post",
+		ThumbnailURL: "old_thumbnail_url",
+		Content:      "old_content",
+		Permalink:    "old_permalink",
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ID      string
+		want    *entity.PostRevision
+		wantErr error
+	}{
+		{
+			name: "存在するリビジョンを正常に取得できる",
+			ID:   "abcdefghijklmnopqrstuvwxy2",
+			want: &entity.PostRevision{
+				ID:           "abcdefghijklmnopqrstuvwxy2",
+				PostID:       "abcdefghijklmnopqrstuvwxy1",
+				Title:        "old_post",
+				ThumbnailURL: "old_thumbnail_url",
+				Content:      "old_content",
+				Permalink:    "old_permalink",
+				CreatedAt:    flextime.Now(),
+				UpdatedAt:    flextime.Now(),
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "存在しないIDの場合ErrPostRevisionNotFoundを返す",
+			ID:      "not_found",
+			want:    nil,
+			wantErr: entity.ErrPostRevisionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PostRevisionRepository{db: tx}
+			got, err := r.FindByID(tt.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FindByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FindByID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
+
+func TestPostRevisionRepository_FindByPostID(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	if err := tx.Create(&entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxy1",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      false,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	oldRevision := &entity.PostRevision{
+		ID:           "abcdefghijklmnopqrstuvwxy2",
+		PostID:       "abcdefghijklmnopqrstuvwxy1",
+		Title:        "old_post",
+		ThumbnailURL: "old_thumbnail_url",
+		Content:      "old_content",
+		Permalink:    "old_permalink",
+		CreatedAt:    flextime.Now().Add(-time.Hour),
+		UpdatedAt:    flextime.Now().Add(-time.Hour),
+	}
+	newRevision := &entity.PostRevision{
+		ID:           "abcdefghijklmnopqrstuvwxy3",
+		PostID:       "abcdefghijklmnopqrstuvwxy1",
+		Title:        "old_post2",
+		ThumbnailURL: "old_thumbnail_url",
+		Content:      "old_content2",
+		Permalink:    "old_permalink",
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+	}
+	if err := tx.Create([]*entity.PostRevision{oldRevision, newRevision}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		postID   string
+		offset   int
+		pageSize int
+		want     []*entity.PostRevision
+		wantErr  error
+	}{
+		{
+			name:     "投稿のリビジョンを新しい順に取得できる",
+			postID:   "abcdefghijklmnopqrstuvwxy1",
+			offset:   0,
+			pageSize: 10,
+			want:     []*entity.PostRevision{newRevision, oldRevision},
+			wantErr:  nil,
+		},
+		{
+			name:     "ページネーションが適用される",
+			postID:   "abcdefghijklmnopqrstuvwxy1",
+			offset:   1,
+			pageSize: 1,
+			want:     []*entity.PostRevision{oldRevision},
+			wantErr:  nil,
+		},
+		{
+			name:     "リビジョンが存在しない場合ErrPostRevisionNotFoundを返す",
+			postID:   "not_found",
+			offset:   0,
+			pageSize: 10,
+			want:     nil,
+			wantErr:  entity.ErrPostRevisionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PostRevisionRepository{db: tx}
+			got, err := r.FindByPostID(tt.postID, tt.offset, tt.pageSize)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FindByPostID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FindByPostID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
+
+func TestPostRevisionRepository_Create(t *testing.T) {
+	tx := db.Begin()
+
+	if err := tx.Create(&entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxy1",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      false,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		revision *entity.PostRevision
+		wantErr  error
+	}{
+		{
+			name: "新規のリビジョンを正常に保存できる",
+			revision: &entity.PostRevision{
+				ID:           "abcdefghijklmnopqrstuvwxy2",
+				PostID:       "abcdefghijklmnopqrstuvwxy1",
+				Title:        "old_post",
+				ThumbnailURL: "old_thumbnail_url",
+				Content:      "old_content",
+				Permalink:    "old_permalink",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "既に存在するIDの場合ErrPostRevisionAlreadyExistedエラーを返す",
+			revision: &entity.PostRevision{
+				ID:           "abcdefghijklmnopqrstuvwxy2",
+				PostID:       "abcdefghijklmnopqrstuvwxy1",
+				Title:        "old_post",
+				ThumbnailURL: "old_thumbnail_url",
+				Content:      "old_content",
+				Permalink:    "old_permalink",
+			},
+			wantErr: entity.ErrPostRevisionAlreadyExisted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PostRevisionRepository{db: tx}
+			if err := r.Create(tt.revision); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
+
+func TestPostRevisionRepository_CountByPostID(t *testing.T) {
+	tx := db.Begin()
+
+	if err := tx.Create(&entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxy1",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      false,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Create([]*entity.PostRevision{
+		{
+			ID:           "abcdefghijklmnopqrstuvwxy2",
+			PostID:       "abcdefghijklmnopqrstuvwxy1",
+			ThumbnailURL: "old_thumbnail_url",
+		},
+		{
+			ID:           "abcdefghijklmnopqrstuvwxy3",
+			PostID:       "abcdefghijklmnopqrstuvwxy1",
+			ThumbnailURL: "old_thumbnail_url",
+		},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		postID  string
+		want    int
+		wantErr error
+	}{
+		{
+			name:    "投稿のリビジョン数を返す",
+			postID:  "abcdefghijklmnopqrstuvwxy1",
+			want:    2,
+			wantErr: nil,
+		},
+		{
+			name:    "リビジョンがない場合は0を返す",
+			postID:  "not_found",
+			want:    0,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PostRevisionRepository{db: tx}
+			got, err := r.CountByPostID(tt.postID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CountByPostID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CountByPostID() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
+
+func TestPostRevisionRepository_FindAll(t *testing.T) {
+	tx := db.Begin()
+
+	r := &PostRevisionRepository{db: tx}
+	if _, err := r.FindAll(); !errors.Is(err, entity.ErrPostRevisionNotFound) {
+		t.Errorf("FindAll() error = %v, wantErr %v", err, entity.ErrPostRevisionNotFound)
+	}
+
+	for _, postID := range []string{"abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy2"} {
+		if err := tx.Create(&entity.Post{
+			ID:          postID,
+			Permalink:   postID,
+			CreatedAt:   flextime.Now(),
+			UpdatedAt:   flextime.Now(),
+			PublishedAt: flextime.Now(),
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tx.Create([]*entity.PostRevision{
+		{ID: "abcdefghijklmnopqrstuvwxy3", PostID: "abcdefghijklmnopqrstuvwxy1", Content: "![](https://example.com/cat.png)"},
+		{ID: "abcdefghijklmnopqrstuvwxy4", PostID: "abcdefghijklmnopqrstuvwxy2", ThumbnailURL: "https://example.com/dog.png"},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := r.FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotIDs []string
+	for _, revision := range got {
+		gotIDs = append(gotIDs, revision.ID)
+	}
+	if diff := cmp.Diff([]string{"abcdefghijklmnopqrstuvwxy3", "abcdefghijklmnopqrstuvwxy4"}, gotIDs); diff != "" {
+		t.Errorf("FindAll() mismatch (-want +got):\n%s", diff)
+	}
+
+	tx.Rollback()
+}
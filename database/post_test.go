@@ -2,6 +2,7 @@ package database
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -124,6 +125,7 @@ func TestPostRepository_Update(t *testing.T) {
 		Content:      "new_content",
 		Permalink:    "new_permalink",
 		IsDraft:      false,
+		Version:      1,
 		CreatedAt:    flextime.Now(),
 		UpdatedAt:    flextime.Now(),
 		PublishedAt:  flextime.Now(),
@@ -145,12 +147,29 @@ func TestPostRepository_Update(t *testing.T) {
 				Content:      "new_content2",
 				Permalink:    "new_permalink",
 				IsDraft:      false,
+				Version:      1,
 				CreatedAt:    time.Time{},
 				UpdatedAt:    time.Time{},
 				PublishedAt:  time.Time{},
 			},
 			wantErr: nil,
 		},
+		{
+			name: "読み込んだ後に更新されていた場合はErrPostVersionConflictを返す",
+			post: &entity.Post{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content3",
+				Permalink:    "new_permalink",
+				IsDraft:      false,
+				Version:      1,
+				CreatedAt:    time.Time{},
+				UpdatedAt:    time.Time{},
+				PublishedAt:  time.Time{},
+			},
+			wantErr: entity.ErrPostVersionConflict,
+		},
 	}
 
 	for _, tt := range tests {
@@ -164,6 +183,128 @@ func TestPostRepository_Update(t *testing.T) {
 
 	tx.Rollback()
 }
+
+func TestPostRepository_UpdateWithHistory(t *testing.T) {
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	stored := &entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxyz",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      false,
+		Version:      1,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}
+	if err := tx.Create(stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	reclaimed := &entity.PermalinkRedirect{
+		ID:        "abcdefghijklmnopqrstuvwxy1",
+		PostID:    stored.ID,
+		Permalink: "older_permalink",
+		CreatedAt: flextime.Now(),
+		UpdatedAt: flextime.Now(),
+	}
+	if err := tx.Create(reclaimed).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		content           string
+		version           int
+		redirect          *entity.PermalinkRedirect
+		reclaimedRedirect *entity.PermalinkRedirect
+		wantErr           error
+		wantRevisions     int
+		wantContent       string
+		wantRedirects     []string
+	}{
+		{
+			name:              "リビジョンとリダイレクトを残して投稿を更新し，不要になったリダイレクトを削除できる",
+			content:           "new_content2",
+			version:           1,
+			redirect:          &entity.PermalinkRedirect{ID: "abcdefghijklmnopqrstuvwxy2", PostID: stored.ID, Permalink: "old_permalink"},
+			reclaimedRedirect: reclaimed,
+			wantErr:           nil,
+			wantRevisions:     1,
+			wantContent:       "new_content2",
+			wantRedirects:     []string{"old_permalink"},
+		},
+		{
+			name:              "投稿を更新できなかった場合はリビジョンもリダイレクトも変えない",
+			content:           "new_content3",
+			version:           1,
+			redirect:          &entity.PermalinkRedirect{ID: "abcdefghijklmnopqrstuvwxy3", PostID: stored.ID, Permalink: "other_permalink"},
+			reclaimedRedirect: nil,
+			wantErr:           entity.ErrPostVersionConflict,
+			wantRevisions:     1,
+			wantContent:       "new_content2",
+			wantRedirects:     []string{"old_permalink"},
+		},
+		{
+			name:              "リダイレクトを作れなかった場合は投稿もリビジョンも変えない",
+			content:           "new_content4",
+			version:           2,
+			redirect:          &entity.PermalinkRedirect{ID: "abcdefghijklmnopqrstuvwxy4", PostID: stored.ID, Permalink: "old_permalink"},
+			reclaimedRedirect: nil,
+			wantErr:           entity.ErrPermalinkRedirectAlreadyExisted,
+			wantRevisions:     1,
+			wantContent:       "new_content2",
+			wantRedirects:     []string{"old_permalink"},
+		},
+	}
+
+	r := &PostRepository{db: tx}
+	revisionRepository := &PostRevisionRepository{db: tx}
+	redirectRepository := &PermalinkRedirectRepository{db: tx}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post, err := r.FindByID(stored.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			revision := entity.NewPostRevision(post)
+			post.Content = tt.content
+			post.Version = tt.version
+			if err = r.UpdateWithHistory(post, revision, tt.redirect, tt.reclaimedRedirect); !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateWithHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			count, err := revisionRepository.CountByPostID(stored.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != tt.wantRevisions {
+				t.Errorf("UpdateWithHistory() revisions = %v, want %v", count, tt.wantRevisions)
+			}
+			got, err := r.FindByID(stored.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Content != tt.wantContent {
+				t.Errorf("UpdateWithHistory() content = %v, want %v", got.Content, tt.wantContent)
+			}
+			redirects, err := redirectRepository.FindByPostID(stored.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			permalinks := make([]string, 0, len(redirects))
+			for _, redirect := range redirects {
+				permalinks = append(permalinks, redirect.Permalink)
+			}
+			if diff := cmp.Diff(tt.wantRedirects, permalinks); diff != "" {
+				t.Errorf("UpdateWithHistory() redirects mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestPostRepository_Create(t *testing.T) {
 	tx := db.Begin()
 
@@ -304,6 +445,7 @@ func TestPostRepository_FindAll(t *testing.T) { // nolint:gocognit
 		condition      string
 		params         []interface{}
 		sortCondition  string
+		fields         []string
 		want           []*entity.Post
 		wantErr        error
 	}{
@@ -622,6 +764,32 @@ func TestPostRepository_FindAll(t *testing.T) { // nolint:gocognit
 			}},
 			wantErr: nil,
 		},
+		{
+			name: "fieldsを指定した場合は指定したカラムだけを取得できる",
+			existPosts: []*entity.Post{{
+				ID:           "abcdefghijklmnopqrstuvwxy1",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content",
+				Permalink:    "new_permalink",
+				IsDraft:      false,
+				CreatedAt:    flextime.Now(),
+				UpdatedAt:    flextime.Now(),
+				PublishedAt:  flextime.Now(),
+			}},
+			offset:        0,
+			pageSize:      0,
+			condition:     "",
+			params:        []interface{}{},
+			sortCondition: "id asc",
+			fields:        []string{"posts.id", "posts.title", "posts.permalink"},
+			want: []*entity.Post{{
+				ID:        "abcdefghijklmnopqrstuvwxy1",
+				Title:     "new_post",
+				Permalink: "new_permalink",
+			}},
+			wantErr: nil,
+		},
 		{
 			name:          "投稿が存在しない場合はErrPostNotFoundを返す",
 			existPosts:    nil,
@@ -649,7 +817,7 @@ func TestPostRepository_FindAll(t *testing.T) { // nolint:gocognit
 				}
 			}
 			r := &PostRepository{db: tx.Debug()}
-			got, err := r.FindAll(tt.offset, tt.pageSize, tt.condition, tt.params, tt.sortCondition)
+			got, err := r.FindAll(tt.offset, tt.pageSize, tt.condition, tt.params, nil, tt.sortCondition, tt.fields)
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("FindAll()  error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -681,6 +849,113 @@ func TestPostRepository_FindAll(t *testing.T) { // nolint:gocognit
 	tx.Rollback()
 }
 
+func TestPostRepository_FindAllWithTagFilter(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	goID := "abcdefghijklmnopqrstuvwtg1"
+	tags := []*entity.Tag{
+		{ID: goID, Name: "go", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+		{ID: "abcdefghijklmnopqrstuvwtg2", Name: "mysql", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+		{ID: "abcdefghijklmnopqrstuvwtg3", Name: "gin", ParentID: &goID, CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+	}
+	var posts []*entity.Post
+	for i := 1; i <= 4; i++ {
+		posts = append(posts, &entity.Post{
+			ID:          fmt.Sprintf("abcdefghijklmnopqrstuvwxy%d", i),
+			Title:       "new_post",
+			Content:     "new_content",
+			Permalink:   fmt.Sprintf("new_permalink%d", i),
+			CreatedAt:   flextime.Now(),
+			UpdatedAt:   flextime.Now(),
+			PublishedAt: flextime.Now(),
+		})
+	}
+	// 1: go,mysql 2: go 3: mysql 4: gin(goの子)
+	var postsTags []*entity.PostsTags
+	for i, pair := range [][2]int{{0, 0}, {0, 1}, {1, 0}, {2, 1}, {3, 2}} {
+		postsTags = append(postsTags, &entity.PostsTags{
+			ID:        fmt.Sprintf("abcdefghijklmnopqrstuvwpt%d", i),
+			PostID:    posts[pair[0]].ID,
+			TagID:     tags[pair[1]].ID,
+			CreatedAt: flextime.Now(),
+			UpdatedAt: flextime.Now(),
+		})
+	}
+	for _, v := range []interface{}{posts, tags, postsTags} {
+		if err := tx.Create(v).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		tagFilter *entity.TagFilter
+		wantIDs   []string
+		wantErr   error
+	}{
+		{
+			name:      "いずれかのタグが付いた投稿を子孫のタグも含めて取得できる",
+			tagFilter: &entity.TagFilter{Names: []string{"go", "mysql"}},
+			wantIDs:   []string{posts[0].ID, posts[1].ID, posts[2].ID, posts[3].ID},
+		},
+		{
+			name:      "すべてのタグが付いた投稿だけを取得できる",
+			tagFilter: &entity.TagFilter{Names: []string{"go", "mysql"}, MatchAll: true},
+			wantIDs:   []string{posts[0].ID},
+		},
+		{
+			name:      "除外したタグが付いた投稿を除いて取得できる",
+			tagFilter: &entity.TagFilter{Names: []string{"go"}, ExcludedNames: []string{"mysql"}},
+			wantIDs:   []string{posts[1].ID, posts[3].ID},
+		},
+		{
+			name:      "除外するタグだけを指定した場合は子孫のタグが付いた投稿も除く",
+			tagFilter: &entity.TagFilter{ExcludedNames: []string{"go"}},
+			wantIDs:   []string{posts[2].ID},
+		},
+		{
+			name:      "すべてのタグが付いた投稿がない場合はErrPostNotFoundを返す",
+			tagFilter: &entity.TagFilter{Names: []string{"mysql", "gin"}, MatchAll: true},
+			wantIDs:   nil,
+			wantErr:   entity.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PostRepository{db: tx.Debug()}
+			got, err := r.FindAll(0, 0, "", []interface{}{}, tt.tagFilter, "id asc", nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FindAll()  error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var gotIDs []string
+			for _, post := range got {
+				gotIDs = append(gotIDs, post.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("FindAll() mismatch (-want +got):\n%s", diff)
+			}
+
+			count, err := r.Count("", []interface{}{}, tt.tagFilter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != len(tt.wantIDs) {
+				t.Errorf("Count() = %v, want %v", count, len(tt.wantIDs))
+			}
+		})
+	}
+
+	tx.Rollback()
+}
+
 func TestPostRepository_Delete(t *testing.T) {
 	tx := db.Begin()
 	loc, err := time.LoadLocation("Asia/Tokyo")
@@ -954,7 +1229,7 @@ func TestPostRepository_Count(t *testing.T) { // nolint:gocognit
 				}
 			}
 			r := &PostRepository{db: tx.Debug()}
-			got, err := r.Count(tt.condition, tt.params)
+			got, err := r.Count(tt.condition, tt.params, nil)
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("Count()  error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -985,3 +1260,457 @@ func TestPostRepository_Count(t *testing.T) { // nolint:gocognit
 
 	tx.Rollback()
 }
+
+func TestPostRepository_CountByMonth(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	publishedAts := []time.Time{
+		time.Date(2021, 1, 1, 0, 0, 0, 0, loc),
+		time.Date(2021, 1, 20, 0, 0, 0, 0, loc),
+		// UTCでは2020年12月だがJSTでは2021年1月
+		time.Date(2020, 12, 31, 15, 0, 0, 0, time.UTC),
+		time.Date(2020, 12, 1, 0, 0, 0, 0, loc),
+		time.Date(2020, 3, 15, 0, 0, 0, 0, loc),
+	}
+	var posts []*entity.Post
+	for i, publishedAt := range publishedAts {
+		posts = append(posts, &entity.Post{
+			ID:          fmt.Sprintf("abcdefghijklmnopqrstuvwxy%d", i),
+			Title:       "new_post",
+			Content:     "new_content",
+			Permalink:   fmt.Sprintf("new_permalink%d", i),
+			CreatedAt:   flextime.Now(),
+			UpdatedAt:   flextime.Now(),
+			PublishedAt: publishedAt,
+		})
+	}
+	posts = append(posts, &entity.Post{
+		ID:          "abcdefghijklmnopqrstuvwxyd",
+		Title:       "draft_post",
+		Permalink:   "draft_permalink",
+		IsDraft:     true,
+		CreatedAt:   flextime.Now(),
+		UpdatedAt:   flextime.Now(),
+		PublishedAt: time.Date(2020, 3, 1, 0, 0, 0, 0, loc),
+	})
+	if err := tx.Create(posts).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := &PostRepository{db: tx.Debug()}
+	got, err := r.CountByMonth("posts.is_draft = ? AND posts.published_at <= ?", []interface{}{false, flextime.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*entity.MonthlyPostCount{
+		{Year: 2021, Month: 1, Count: 3},
+		{Year: 2020, Month: 12, Count: 1},
+		{Year: 2020, Month: 3, Count: 1},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CountByMonth() mismatch (-want +got):\n%s", diff)
+	}
+
+	tx.Rollback()
+}
+
+func TestPostRepository_CountSharedTags(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	deletedAt := gorm.DeletedAt{Time: flextime.Now(), Valid: true}
+	tags := []*entity.Tag{
+		{ID: "abcdefghijklmnopqrstuvwtg1", Name: "go", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+		{ID: "abcdefghijklmnopqrstuvwtg2", Name: "mysql", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+		{ID: "abcdefghijklmnopqrstuvwtg3", Name: "deleted", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now(), DeletedAt: deletedAt},
+	}
+	var posts []*entity.Post
+	for i := 1; i <= 4; i++ {
+		posts = append(posts, &entity.Post{
+			ID:          fmt.Sprintf("abcdefghijklmnopqrstuvwxy%d", i),
+			Title:       "new_post",
+			Permalink:   fmt.Sprintf("new_permalink%d", i),
+			CreatedAt:   flextime.Now(),
+			UpdatedAt:   flextime.Now(),
+			PublishedAt: flextime.Now(),
+		})
+	}
+	// 1: go,mysql,deleted 2: go,mysql 3: mysql,deleted 4: なし
+	var postsTags []*entity.PostsTags
+	for i, pair := range [][2]int{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {2, 1}, {2, 2}} {
+		postsTags = append(postsTags, &entity.PostsTags{
+			ID:        fmt.Sprintf("abcdefghijklmnopqrstuvwpt%d", i),
+			PostID:    posts[pair[0]].ID,
+			TagID:     tags[pair[1]].ID,
+			CreatedAt: flextime.Now(),
+			UpdatedAt: flextime.Now(),
+		})
+	}
+	for _, v := range []interface{}{posts, tags, postsTags} {
+		if err := tx.Create(v).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &PostRepository{db: tx.Debug()}
+	got, err := r.CountSharedTags(posts[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ゴミ箱にあるタグは数えない
+	want := map[string]int{posts[1].ID: 2, posts[2].ID: 1}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CountSharedTags() mismatch (-want +got):\n%s", diff)
+	}
+
+	tx.Rollback()
+}
+
+func TestPostRepository_Search(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	// InnoDBの全文検索はコミットされていない行を検索できないため，トランザクションを使わずに作成して最後に削除する
+	existPosts := []*entity.Post{{
+		ID:           "abcdefghijklmnopqrstuvwxy1",
+		Title:        "ラーメン屋巡り",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "今日はラーメンを食べた",
+		Permalink:    "search_permalink1",
+		IsDraft:      false,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}, {
+		ID:           "abcdefghijklmnopqrstuvwxy2",
+		Title:        "うどん",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "ラーメンではなくうどんを食べた",
+		Permalink:    "search_permalink2",
+		IsDraft:      true,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}}
+	if err := db.Create(existPosts).Error; err != nil {
+		t.Fatal(err)
+	}
+	defer db.Where("id IN ?", []string{"abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy2"}).Delete(&entity.Post{})
+
+	tests := []struct {
+		name      string
+		query     string
+		condition string
+		params    []interface{}
+		wantIDs   []string
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name:      "タイトルと本文に一致する投稿を関連度の高い順に取得できる",
+			query:     "ラーメン",
+			condition: "",
+			params:    []interface{}{},
+			wantIDs:   []string{"abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy2"},
+			wantCount: 2,
+			wantErr:   nil,
+		},
+		{
+			name:      "条件を指定した場合は条件にも一致する投稿のみ取得できる",
+			query:     "ラーメン",
+			condition: "posts.is_draft = ?",
+			params:    []interface{}{true},
+			wantIDs:   []string{"abcdefghijklmnopqrstuvwxy2"},
+			wantCount: 1,
+			wantErr:   nil,
+		},
+		{
+			name:      "一致する投稿がない場合はErrPostNotFoundを返す",
+			query:     "パスタ",
+			condition: "",
+			params:    []interface{}{},
+			wantIDs:   nil,
+			wantCount: 0,
+			wantErr:   entity.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PostRepository{db: db}
+			got, err := r.Search(tt.query, 0, 0, tt.condition, tt.params, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var gotIDs []string
+			for _, post := range got {
+				gotIDs = append(gotIDs, post.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("Search() mismatch (-want +got):\n%s", diff)
+			}
+
+			count, err := r.CountSearch(tt.query, tt.condition, tt.params, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != tt.wantCount {
+				t.Errorf("CountSearch() count = %d, want %d", count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestPostRepository_Restore(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	if err := tx.Create(&entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxyz",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      false,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+		DeletedAt:    gorm.DeletedAt{Time: flextime.Now(), Valid: true},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ID      string
+		wantErr error
+	}{
+		{
+			name:    "ゴミ箱にある投稿を元に戻せる",
+			ID:      "abcdefghijklmnopqrstuvwxyz",
+			wantErr: nil,
+		},
+		{
+			name:    "ゴミ箱にない投稿の場合ErrPostNotFoundを返す",
+			ID:      "abcdefghijklmnopqrstuvwxyz",
+			wantErr: entity.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PostRepository{db: tx}
+			if err := r.Restore(tt.ID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Restore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, err := r.FindByID(tt.ID); err != nil {
+				t.Errorf("FindByID() error = %v", err)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
+
+func TestPostRepository_UpdatePin(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	if err := tx.Create(&entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxyz",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      false,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 更新日時が変わっていないことを確かめるために時刻を進める
+	flextime.Fix(time.Date(2021, 1, 23, 0, 0, 0, 0, loc))
+	r := &PostRepository{db: tx}
+	if err := r.UpdatePin("abcdefghijklmnopqrstuvwxyz", true, 2); err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.FindByID("abcdefghijklmnopqrstuvwxyz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.IsPinned || got.FeaturedRank != 2 {
+		t.Errorf("UpdatePin() isPinned = %v, featuredRank = %v, want true, 2", got.IsPinned, got.FeaturedRank)
+	}
+	if want := time.Date(2021, 1, 22, 0, 0, 0, 0, loc); !got.UpdatedAt.Equal(want) {
+		t.Errorf("UpdatePin() updatedAt = %v, want %v", got.UpdatedAt, want)
+	}
+
+	tx.Rollback()
+}
+
+func TestPostRepository_Purge(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	if err := tx.Create([]*entity.Post{{
+		ID:          "abcdefghijklmnopqrstuvwxy1",
+		Title:       "old_deleted_post",
+		Permalink:   "new_permalink1",
+		CreatedAt:   flextime.Now(),
+		UpdatedAt:   flextime.Now(),
+		PublishedAt: flextime.Now(),
+		DeletedAt:   gorm.DeletedAt{Time: flextime.Now().AddDate(0, 0, -31), Valid: true},
+	}, {
+		ID:          "abcdefghijklmnopqrstuvwxy2",
+		Title:       "new_deleted_post",
+		Permalink:   "new_permalink2",
+		CreatedAt:   flextime.Now(),
+		UpdatedAt:   flextime.Now(),
+		PublishedAt: flextime.Now(),
+		DeletedAt:   gorm.DeletedAt{Time: flextime.Now(), Valid: true},
+	}}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Create(&entity.Tag{
+		ID:        "abcdefghijklmnopqrstuvwxy3",
+		Name:      "new_tag",
+		CreatedAt: flextime.Now(),
+		UpdatedAt: flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Create(&entity.PostsTags{
+		ID:        "abcdefghijklmnopqrstuvwxy4",
+		PostID:    "abcdefghijklmnopqrstuvwxy1",
+		TagID:     "abcdefghijklmnopqrstuvwxy3",
+		CreatedAt: flextime.Now(),
+		UpdatedAt: flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := &PostRepository{db: tx}
+	count, err := r.Purge(flextime.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Purge() count = %d, want 1", count)
+	}
+
+	// 期限を過ぎた投稿だけがタグとの関連ごと削除されている
+	var posts []*entity.Post
+	if err := tx.Unscoped().Find(&posts).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 1 || posts[0].ID != "abcdefghijklmnopqrstuvwxy2" {
+		t.Errorf("Purge() remaining posts = %v", posts)
+	}
+	var postsTagsCount int64
+	if err := tx.Model(&entity.PostsTags{}).Where("post_id = ?", "abcdefghijklmnopqrstuvwxy1").Count(&postsTagsCount).Error; err != nil {
+		t.Fatal(err)
+	}
+	if postsTagsCount != 0 {
+		t.Errorf("Purge() remaining posts_tags = %d, want 0", postsTagsCount)
+	}
+
+	tx.Rollback()
+}
+
+func TestPostRepository_FindReferencing(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	for _, post := range []*entity.Post{
+		{ID: "abcdefghijklmnopqrstuvwxy1", Permalink: "content", Content: "![cat](https://example.com/cat.png)"},
+		{ID: "abcdefghijklmnopqrstuvwxy2", Permalink: "thumbnail", ThumbnailURL: "https://example.com/cat.png"},
+		{ID: "abcdefghijklmnopqrstuvwxy3", Permalink: "other", Content: "![cat](https://example.com/cats.png)"},
+	} {
+		post.CreatedAt, post.UpdatedAt, post.PublishedAt = flextime.Now(), flextime.Now(), flextime.Now()
+		if err := tx.Create(post).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		substrings []string
+		wantIDs    []string
+		wantErr    error
+	}{
+		{
+			name:       "本文かサムネイルに含む投稿を取得できる",
+			substrings: []string{"https://example.com/cat.png"},
+			wantIDs:    []string{"abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy2"},
+			wantErr:    nil,
+		},
+		{
+			name:       "いずれかを含む投稿を取得できる",
+			substrings: []string{"https://example.com/dog.png", "https://example.com/cats.png"},
+			wantIDs:    []string{"abcdefghijklmnopqrstuvwxy3"},
+			wantErr:    nil,
+		},
+		{
+			name:       "含む投稿が無い場合ErrPostNotFoundを返す",
+			substrings: []string{"https://example.com/c_t.png"},
+			wantIDs:    nil,
+			wantErr:    entity.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PostRepository{db: tx}
+			got, err := r.FindReferencing(tt.substrings)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FindReferencing() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var gotIDs []string
+			for _, post := range got {
+				gotIDs = append(gotIDs, post.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("FindReferencing() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
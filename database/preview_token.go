@@ -0,0 +1,63 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/masibw/blog-server/domain/entity"
+	"gorm.io/gorm"
+)
+
+type PreviewTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPreviewTokenRepository(db *gorm.DB) *PreviewTokenRepository {
+	return &PreviewTokenRepository{db: db}
+}
+
+func (r *PreviewTokenRepository) FindByID(id string) (*entity.PreviewToken, error) {
+	previewToken := &entity.PreviewToken{}
+	if err := r.db.Where("id = ?", id).First(previewToken).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find preview token: %w", entity.ErrPreviewTokenNotFound)
+		}
+		return nil, fmt.Errorf("find preview token: %w", err)
+	}
+	return previewToken, nil
+}
+
+// FindByPostID は投稿のプレビューリンクを新しい順に取得します
+func (r *PreviewTokenRepository) FindByPostID(postID string) (previewTokens []*entity.PreviewToken, err error) {
+	if err = r.db.Where("post_id = ?", postID).Order("created_at desc").Order("id desc").Find(&previewTokens).Error; err != nil {
+		err = fmt.Errorf("find preview tokens: %w", err)
+		return
+	}
+	if len(previewTokens) == 0 {
+		err = fmt.Errorf("find preview tokens: %w", entity.ErrPreviewTokenNotFound)
+		return
+	}
+	return
+}
+
+func (r *PreviewTokenRepository) Create(previewToken *entity.PreviewToken) error {
+	if err := r.db.Create(previewToken).Error; err != nil {
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
+			return fmt.Errorf("create preview token: %w", entity.ErrPreviewTokenAlreadyExisted)
+		}
+		return fmt.Errorf("create preview token: %w", err)
+	}
+	return nil
+}
+
+func (r *PreviewTokenRepository) Delete(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&entity.PreviewToken{})
+	if err := result.Error; err != nil {
+		return fmt.Errorf("delete preview token: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete preview token: %w", entity.ErrPreviewTokenNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/Songmu/flextime"
+
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+func TestPreviewTokenRepository_FindByID(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	if err := tx.Create(&entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxy1",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      true,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Create(&entity.PreviewToken{
+		ID:        "abcdefghijklmnopqrstuvwxy2",
+		PostID:    "abcdefghijklmnopqrstuvwxy1",
+		ExpiresAt: time.Date(2021, 1, 25, 0, 0, 0, 0, loc),
+		CreatedAt: flextime.Now(),
+		UpdatedAt: flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ID      string
+		want    *entity.PreviewToken
+		wantErr error
+	}{
+		{
+			name: "存在するプレビューリンクを正常に取得できる",
+			ID:   "abcdefghijklmnopqrstuvwxy2",
+			want: &entity.PreviewToken{
+				ID:        "abcdefghijklmnopqrstuvwxy2",
+				PostID:    "abcdefghijklmnopqrstuvwxy1",
+				ExpiresAt: time.Date(2021, 1, 25, 0, 0, 0, 0, loc),
+				CreatedAt: flextime.Now(),
+				UpdatedAt: flextime.Now(),
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "存在しないIDの場合ErrPreviewTokenNotFoundを返す",
+			ID:      "not_found",
+			want:    nil,
+			wantErr: entity.ErrPreviewTokenNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PreviewTokenRepository{db: tx}
+			got, err := r.FindByID(tt.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FindByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FindByID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
+
+func TestPreviewTokenRepository_Delete(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	if err := tx.Create(&entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxy1",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      true,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Create(&entity.PreviewToken{
+		ID:        "abcdefghijklmnopqrstuvwxy2",
+		PostID:    "abcdefghijklmnopqrstuvwxy1",
+		ExpiresAt: time.Date(2021, 1, 25, 0, 0, 0, 0, loc),
+		CreatedAt: flextime.Now(),
+		UpdatedAt: flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ID      string
+		wantErr error
+	}{
+		{
+			name:    "存在するプレビューリンクを削除できる",
+			ID:      "abcdefghijklmnopqrstuvwxy2",
+			wantErr: nil,
+		},
+		{
+			name:    "削除済みのプレビューリンクの場合ErrPreviewTokenNotFoundを返す",
+			ID:      "abcdefghijklmnopqrstuvwxy2",
+			wantErr: entity.ErrPreviewTokenNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PreviewTokenRepository{db: tx}
+			if err := r.Delete(tt.ID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	tx.Rollback()
+}
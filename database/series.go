@@ -0,0 +1,68 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/masibw/blog-server/domain/entity"
+	"gorm.io/gorm"
+)
+
+type SeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewSeriesRepository(db *gorm.DB) *SeriesRepository {
+	return &SeriesRepository{db: db}
+}
+
+func (r *SeriesRepository) FindByID(id string) (*entity.Series, error) {
+	series := &entity.Series{}
+	if err := r.db.Where("id = ?", id).First(series).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find series: %w", entity.ErrSeriesNotFound)
+		}
+		return nil, fmt.Errorf("find series: %w", err)
+	}
+	return series, nil
+}
+
+// FindAll は連載を新しい順に取得します
+func (r *SeriesRepository) FindAll() (series []*entity.Series, err error) {
+	if err = r.db.Order("created_at desc").Order("id desc").Find(&series).Error; err != nil {
+		err = fmt.Errorf("find all series: %w", err)
+		return
+	}
+	if len(series) == 0 {
+		err = fmt.Errorf("find all series: %w", entity.ErrSeriesNotFound)
+		return
+	}
+	return
+}
+
+func (r *SeriesRepository) Store(series *entity.Series) error {
+	if err := r.db.Create(series).Error; err != nil {
+		return fmt.Errorf("create series: %w", err)
+	}
+	return nil
+}
+
+// Update は連載のタイトルと説明を更新します
+func (r *SeriesRepository) Update(series *entity.Series) error {
+	if err := r.db.Model(series).Select("title", "description").Updates(series).Error; err != nil {
+		return fmt.Errorf("update series: %w", err)
+	}
+	return nil
+}
+
+// Delete は連載を削除します．連載に含まれる投稿との関連も外部キーにより削除されます
+func (r *SeriesRepository) Delete(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&entity.Series{})
+	if err := result.Error; err != nil {
+		return fmt.Errorf("delete series: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete series: %w", entity.ErrSeriesNotFound)
+	}
+	return nil
+}
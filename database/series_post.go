@@ -0,0 +1,72 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/masibw/blog-server/domain/entity"
+	"gorm.io/gorm"
+)
+
+type SeriesPostRepository struct {
+	db *gorm.DB
+}
+
+func NewSeriesPostRepository(db *gorm.DB) *SeriesPostRepository {
+	return &SeriesPostRepository{db: db}
+}
+
+// FindBySeriesID は連載に含まれる投稿を順番に取得します
+func (r *SeriesPostRepository) FindBySeriesID(seriesID string) (seriesPosts []*entity.SeriesPost, err error) {
+	if err = r.db.Where("series_id = ?", seriesID).Order("position asc").Find(&seriesPosts).Error; err != nil {
+		err = fmt.Errorf("find series posts series id=%v: %w", seriesID, err)
+		return
+	}
+	if len(seriesPosts) == 0 {
+		err = fmt.Errorf("find series posts series id=%v: %w", seriesID, entity.ErrSeriesPostNotFound)
+		return
+	}
+	return
+}
+
+func (r *SeriesPostRepository) FindByPostID(postID string) (*entity.SeriesPost, error) {
+	seriesPost := &entity.SeriesPost{}
+	if err := r.db.Where("post_id = ?", postID).First(seriesPost).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find series post post id=%v: %w", postID, entity.ErrSeriesPostNotFound)
+		}
+		return nil, fmt.Errorf("find series post post id=%v: %w", postID, err)
+	}
+	return seriesPost, nil
+}
+
+func (r *SeriesPostRepository) Store(seriesPosts []*entity.SeriesPost) error {
+	if err := r.db.Create(seriesPosts).Error; err != nil {
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
+			return fmt.Errorf("create series posts: %w", entity.ErrSeriesPostAlreadyExisted)
+		}
+		return fmt.Errorf("create series posts: %w", err)
+	}
+	return nil
+}
+
+// ReplaceBySeriesID は連載に含まれる投稿を seriesPosts で置き換えます
+func (r *SeriesPostRepository) ReplaceBySeriesID(seriesID string, seriesPosts []*entity.SeriesPost) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", seriesID).Delete(&entity.SeriesPost{}).Error; err != nil {
+			return err
+		}
+		if len(seriesPosts) == 0 {
+			return nil
+		}
+		return tx.Create(&seriesPosts).Error
+	})
+	if err != nil {
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
+			return fmt.Errorf("replace series posts series id=%v: %w", seriesID, entity.ErrSeriesPostAlreadyExisted)
+		}
+		return fmt.Errorf("replace series posts series id=%v: %w", seriesID, err)
+	}
+	return nil
+}
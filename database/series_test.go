@@ -0,0 +1,161 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+func TestSeriesPostRepository_FindBySeriesID(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	if err := tx.Create(&entity.Series{
+		ID:        "abcdefghijklmnopqrstuvwxy0",
+		Title:     "Go入門",
+		CreatedAt: flextime.Now(),
+		UpdatedAt: flextime.Now(),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 3; i++ {
+		if err := tx.Create(&entity.Post{
+			ID:          fmt.Sprintf("abcdefghijklmnopqrstuvwxy%d", i),
+			Title:       fmt.Sprintf("第%d回", i),
+			Content:     "new_content",
+			Permalink:   fmt.Sprintf("go-%d", i),
+			CreatedAt:   flextime.Now(),
+			UpdatedAt:   flextime.Now(),
+			PublishedAt: flextime.Now(),
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &SeriesPostRepository{db: tx}
+	if err := r.Store([]*entity.SeriesPost{
+		{ID: "abcdefghijklmnopqrstuvwxa1", SeriesID: "abcdefghijklmnopqrstuvwxy0", PostID: "abcdefghijklmnopqrstuvwxy3", Position: 1},
+		{ID: "abcdefghijklmnopqrstuvwxa2", SeriesID: "abcdefghijklmnopqrstuvwxy0", PostID: "abcdefghijklmnopqrstuvwxy1", Position: 2},
+		{ID: "abcdefghijklmnopqrstuvwxa3", SeriesID: "abcdefghijklmnopqrstuvwxy0", PostID: "abcdefghijklmnopqrstuvwxy2", Position: 3},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := r.FindBySeriesID("abcdefghijklmnopqrstuvwxy0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotPostIDs []string
+	for _, seriesPost := range got {
+		gotPostIDs = append(gotPostIDs, seriesPost.PostID)
+	}
+	want := []string{"abcdefghijklmnopqrstuvwxy3", "abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy2"}
+	if diff := cmp.Diff(want, gotPostIDs); diff != "" {
+		t.Errorf("FindBySeriesID() mismatch (-want +got):\n%s", diff)
+	}
+
+	// ゴミ箱から完全に削除した投稿は外部キーで連載からも外れる
+	if err := tx.Unscoped().Delete(&entity.Post{ID: "abcdefghijklmnopqrstuvwxy1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.FindByPostID("abcdefghijklmnopqrstuvwxy1"); !errors.Is(err, entity.ErrSeriesPostNotFound) {
+		t.Errorf("FindByPostID() error = %v, wantErr %v", err, entity.ErrSeriesPostNotFound)
+	}
+
+	tx.Rollback()
+}
+
+func TestSeriesPostRepository_ReplaceBySeriesID(t *testing.T) {
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	for _, series := range []*entity.Series{
+		{ID: "abcdefghijklmnopqrstuvwxy0", Title: "Go入門", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+		{ID: "abcdefghijklmnopqrstuvwxy9", Title: "Rust入門", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+	} {
+		if err := tx.Create(series).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 1; i <= 3; i++ {
+		if err := tx.Create(&entity.Post{
+			ID:          fmt.Sprintf("abcdefghijklmnopqrstuvwxy%d", i),
+			Title:       fmt.Sprintf("第%d回", i),
+			Content:     "new_content",
+			Permalink:   fmt.Sprintf("go-%d", i),
+			CreatedAt:   flextime.Now(),
+			UpdatedAt:   flextime.Now(),
+			PublishedAt: flextime.Now(),
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &SeriesPostRepository{db: tx}
+	if err := r.Store([]*entity.SeriesPost{
+		{ID: "abcdefghijklmnopqrstuvwxa1", SeriesID: "abcdefghijklmnopqrstuvwxy0", PostID: "abcdefghijklmnopqrstuvwxy1", Position: 1},
+		{ID: "abcdefghijklmnopqrstuvwxa2", SeriesID: "abcdefghijklmnopqrstuvwxy9", PostID: "abcdefghijklmnopqrstuvwxy3", Position: 1},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		seriesPosts []*entity.SeriesPost
+		wantErr     error
+		wantPostIDs []string
+	}{
+		{
+			name: "連載に含まれる投稿を置き換えられる",
+			seriesPosts: []*entity.SeriesPost{
+				{ID: "abcdefghijklmnopqrstuvwxb1", SeriesID: "abcdefghijklmnopqrstuvwxy0", PostID: "abcdefghijklmnopqrstuvwxy2", Position: 1},
+				{ID: "abcdefghijklmnopqrstuvwxb2", SeriesID: "abcdefghijklmnopqrstuvwxy0", PostID: "abcdefghijklmnopqrstuvwxy1", Position: 2},
+			},
+			wantErr:     nil,
+			wantPostIDs: []string{"abcdefghijklmnopqrstuvwxy2", "abcdefghijklmnopqrstuvwxy1"},
+		},
+		{
+			name: "別の連載に含まれている投稿を指定した場合はErrSeriesPostAlreadyExistedを返し，元の投稿を残す",
+			seriesPosts: []*entity.SeriesPost{
+				{ID: "abcdefghijklmnopqrstuvwxc1", SeriesID: "abcdefghijklmnopqrstuvwxy0", PostID: "abcdefghijklmnopqrstuvwxy3", Position: 1},
+			},
+			wantErr:     entity.ErrSeriesPostAlreadyExisted,
+			wantPostIDs: []string{"abcdefghijklmnopqrstuvwxy2", "abcdefghijklmnopqrstuvwxy1"},
+		},
+		{
+			name:        "空で指定した場合は連載から全ての投稿を外す",
+			seriesPosts: []*entity.SeriesPost{},
+			wantErr:     nil,
+			wantPostIDs: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.ReplaceBySeriesID("abcdefghijklmnopqrstuvwxy0", tt.seriesPosts); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReplaceBySeriesID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got, err := r.FindBySeriesID("abcdefghijklmnopqrstuvwxy0")
+			if err != nil && !errors.Is(err, entity.ErrSeriesPostNotFound) {
+				t.Fatal(err)
+			}
+			var gotPostIDs []string
+			for _, seriesPost := range got {
+				gotPostIDs = append(gotPostIDs, seriesPost.PostID)
+			}
+			if diff := cmp.Diff(tt.wantPostIDs, gotPostIDs); diff != "" {
+				t.Errorf("ReplaceBySeriesID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
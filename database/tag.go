@@ -3,6 +3,7 @@ package database
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/masibw/blog-server/domain/entity"
@@ -41,6 +42,29 @@ func (r *TagRepository) FindByName(name string) (*entity.Tag, error) {
 	return tag, nil
 }
 
+// postTag は付いている投稿のIDと一緒に取得したタグです
+type postTag struct {
+	entity.Tag
+	PostID string
+}
+
+// FindByPostIDs は投稿ごとに付いているタグを1回のクエリで取得します．タグが付いていない投稿はmapに含みません
+func (r *TagRepository) FindByPostIDs(postIDs []string) (map[string][]*entity.Tag, error) {
+	tags := make(map[string][]*entity.Tag, len(postIDs))
+	if len(postIDs) == 0 {
+		return tags, nil
+	}
+	var postTags []*postTag
+	if err := r.db.Table("tags").Select("tags.*, posts_tags.post_id").Joins("INNER JOIN posts_tags on posts_tags.tag_id = tags.id").Where("posts_tags.post_id IN ?", postIDs).Order("tags.name asc").Find(&postTags).Error; err != nil {
+		return nil, fmt.Errorf("find tags by post ids: %w", err)
+	}
+	for _, postTag := range postTags {
+		tag := postTag.Tag
+		tags[postTag.PostID] = append(tags[postTag.PostID], &tag)
+	}
+	return tags, nil
+}
+
 func (r *TagRepository) Store(tag *entity.Tag) error {
 	if err := r.db.Create(tag).Error; err != nil {
 		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
@@ -51,8 +75,53 @@ func (r *TagRepository) Store(tag *entity.Tag) error {
 	return nil
 }
 
-func (r *TagRepository) FindAll(offset, pageSize int, condition string, params []interface{}) (tags []*entity.Tag, err error) {
-	if err = r.db.Distinct().Where(condition, params...).Limit(pageSize).Offset(offset).Joins("LEFT JOIN posts_tags on posts_tags.tag_id = tags.id").Joins("LEFT JOIN posts on posts_tags.post_id = posts.id").Find(&tags).Error; err != nil {
+// Update はタグの名前と親タグを更新します
+func (r *TagRepository) Update(tag *entity.Tag) error {
+	if err := r.db.Model(tag).Select("name", "parent_id").Updates(tag).Error; err != nil {
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
+			return fmt.Errorf("update tag: %w", entity.ErrTagNameAlreadyExisted)
+		}
+		return fmt.Errorf("update tag: %w", err)
+	}
+	return nil
+}
+
+// Merge は sourceID のタグの投稿との関連と子タグを targetID のタグへ付け替え，sourceID のタグを完全に削除します
+func (r *TagRepository) Merge(sourceID, targetID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// 両方のタグが付いている投稿は統合先との関連だけを残す
+		// MySQLは更新するテーブルを直接サブクエリで参照できないため派生テーブルを挟む
+		if err := tx.Exec("DELETE FROM posts_tags WHERE tag_id = ? AND post_id IN (SELECT post_id FROM (SELECT post_id FROM posts_tags WHERE tag_id = ?) AS target_posts_tags)", sourceID, targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.PostsTags{}).Where("tag_id = ?", sourceID).Update("tag_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&entity.Tag{}).Where("parent_id = ?", sourceID).Update("parent_id", targetID).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("id = ?", sourceID).Delete(&entity.Tag{})
+		if err := result.Error; err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return entity.ErrTagNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("merge tag id=%v into id=%v: %w", sourceID, targetID, err)
+	}
+	return nil
+}
+
+// FindAll は条件に一致するタグを取得します．sortCondition が空の場合は並べ替えません
+func (r *TagRepository) FindAll(offset, pageSize int, condition string, params []interface{}, sortCondition string) (tags []*entity.Tag, err error) {
+	db := r.db.Distinct()
+	if sortCondition != "" {
+		db = db.Order(sortCondition)
+	}
+	if err = db.Where(condition, params...).Limit(pageSize).Offset(offset).Joins("LEFT JOIN posts_tags on posts_tags.tag_id = tags.id").Joins("LEFT JOIN posts on posts_tags.post_id = posts.id AND posts.deleted_at IS NULL").Find(&tags).Error; err != nil {
 		err = fmt.Errorf("find all tags: %w", err)
 		return
 	}
@@ -84,3 +153,60 @@ func (r *TagRepository) Count() (count int, err error) {
 	count = int(count64)
 	return
 }
+
+// FindDeleted はゴミ箱にあるタグをゴミ箱に移動した日時が新しい順に取得します
+func (r *TagRepository) FindDeleted() (tags []*entity.Tag, err error) {
+	if err = r.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&tags).Error; err != nil {
+		err = fmt.Errorf("find deleted tags: %w", err)
+		return
+	}
+	if len(tags) == 0 {
+		err = fmt.Errorf("find deleted tags: %w", entity.ErrTagNotFound)
+		return
+	}
+	return
+}
+
+func (r *TagRepository) FindDeletedByName(name string) (*entity.Tag, error) {
+	tag := &entity.Tag{}
+	if err := r.db.Unscoped().Where("name = ? AND deleted_at IS NOT NULL", name).First(tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("find deleted tag: %w", entity.ErrTagNotFound)
+		}
+		return nil, fmt.Errorf("find deleted tag: %w", err)
+	}
+	return tag, nil
+}
+
+// Restore はゴミ箱にあるタグを元に戻します
+func (r *TagRepository) Restore(id string) error {
+	result := r.db.Unscoped().Model(&entity.Tag{}).Where("id = ? AND deleted_at IS NOT NULL", id).UpdateColumn("deleted_at", nil)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("restore tag: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("restore tag: %w", entity.ErrTagNotFound)
+	}
+	return nil
+}
+
+// Purge は before より前にゴミ箱に移動したタグを投稿との関連ごと完全に削除し，削除したタグの数を返します
+func (r *TagRepository) Purge(before time.Time) (count int, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		deletedTagIDs := tx.Unscoped().Model(&entity.Tag{}).Select("id").Where("deleted_at < ?", before)
+		if err := tx.Where("tag_id IN (?)", deletedTagIDs).Delete(&entity.PostsTags{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("deleted_at < ?", before).Delete(&entity.Tag{})
+		if err := result.Error; err != nil {
+			return err
+		}
+		count = int(result.RowsAffected)
+		return nil
+	})
+	if err != nil {
+		err = fmt.Errorf("purge tags: %w", err)
+		return
+	}
+	return
+}
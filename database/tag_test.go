@@ -135,6 +135,7 @@ func TestTagRepository_FindAll(t *testing.T) { // nolint:gocognit
 		pageSize       int
 		condition      string
 		params         []interface{}
+		sortCondition  string
 		want           []*entity.Tag
 		wantErr        error
 	}{
@@ -259,6 +260,41 @@ func TestTagRepository_FindAll(t *testing.T) { // nolint:gocognit
 				UpdatedAt: flextime.Now(),
 			}},
 			wantErr: nil,
+		}, {
+			name: "sortConditionを適用して取得できる",
+			existTags: []*entity.Tag{{
+				ID:        "abcdefghijklmnopqrstuvwx11",
+				Name:      "sort_tag",
+				CreatedAt: flextime.Now(),
+				UpdatedAt: flextime.Now(),
+			}, {
+				ID:        "abcdefghijklmnopqrstuvwx12",
+				Name:      "sort_tag2",
+				CreatedAt: flextime.Now(),
+				UpdatedAt: flextime.Now(),
+			}, {
+				ID:        "abcdefghijklmnopqrstuvwx13",
+				Name:      "sort_tag3",
+				CreatedAt: flextime.Now(),
+				UpdatedAt: flextime.Now(),
+			}},
+			offset:        0,
+			pageSize:      0,
+			condition:     "tags.id < ?",
+			params:        []interface{}{"abcdefghijklmnopqrstuvwx13"},
+			sortCondition: "tags.id desc",
+			want: []*entity.Tag{{
+				ID:        "abcdefghijklmnopqrstuvwx12",
+				Name:      "sort_tag2",
+				CreatedAt: flextime.Now(),
+				UpdatedAt: flextime.Now(),
+			}, {
+				ID:        "abcdefghijklmnopqrstuvwx11",
+				Name:      "sort_tag",
+				CreatedAt: flextime.Now(),
+				UpdatedAt: flextime.Now(),
+			}},
+			wantErr: nil,
 		}, {
 			name:      "タグが存在しない場合はErrTagNotFoundを返す",
 			existTags: nil,
@@ -286,7 +322,7 @@ func TestTagRepository_FindAll(t *testing.T) { // nolint:gocognit
 			}
 
 			r := &TagRepository{db: tx}
-			got, err := r.FindAll(tt.offset, tt.pageSize, tt.condition, tt.params)
+			got, err := r.FindAll(tt.offset, tt.pageSize, tt.condition, tt.params, tt.sortCondition)
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("FindAll()  error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -473,3 +509,155 @@ func TestTagRepository_Count(t *testing.T) {
 
 	tx.Rollback()
 }
+
+func TestTagRepository_Merge(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	sourceID := "abcdefghijklmnopqrstuvwxy1"
+	targetID := "abcdefghijklmnopqrstuvwxy2"
+	childID := "abcdefghijklmnopqrstuvwxy3"
+	tags := []*entity.Tag{
+		{ID: sourceID, Name: "golang", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+		{ID: targetID, Name: "Go", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+		{ID: childID, Name: "gin", ParentID: &sourceID, CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+	}
+	for _, tag := range tags {
+		if err := tx.Create(tag).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, postID := range []string{"abcdefghijklmnopqrstuvwxy4", "abcdefghijklmnopqrstuvwxy5"} {
+		if err := tx.Create(&entity.Post{
+			ID:          postID,
+			Title:       "new_post",
+			Content:     "new_content",
+			Permalink:   postID,
+			IsDraft:     false,
+			CreatedAt:   flextime.Now(),
+			UpdatedAt:   flextime.Now(),
+			PublishedAt: flextime.Now(),
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 1つ目の投稿には統合元と統合先の両方のタグが付いている
+	postsTags := []*entity.PostsTags{
+		{ID: "abcdefghijklmnopqrstuvwxy6", PostID: "abcdefghijklmnopqrstuvwxy4", TagID: sourceID},
+		{ID: "abcdefghijklmnopqrstuvwxy7", PostID: "abcdefghijklmnopqrstuvwxy4", TagID: targetID},
+		{ID: "abcdefghijklmnopqrstuvwxy8", PostID: "abcdefghijklmnopqrstuvwxy5", TagID: sourceID},
+	}
+	for _, pt := range postsTags {
+		if err := tx.Create(pt).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &TagRepository{db: tx}
+	if err := r.Merge(sourceID, targetID); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	var postIDs []string
+	if err := tx.Model(&entity.PostsTags{}).Where("tag_id = ?", targetID).Order("post_id asc").Pluck("post_id", &postIDs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"abcdefghijklmnopqrstuvwxy4", "abcdefghijklmnopqrstuvwxy5"}, postIDs); diff != "" {
+		t.Errorf("Merge() posts of target mismatch (-want +got):\n%s", diff)
+	}
+
+	child, err := r.FindByID(childID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.ParentID == nil || *child.ParentID != targetID {
+		t.Errorf("Merge() parent of child = %v, want = %v", child.ParentID, targetID)
+	}
+
+	if _, err := r.FindByID(sourceID); !errors.Is(err, entity.ErrTagNotFound) {
+		t.Errorf("Merge() source tag still exists error = %v", err)
+	}
+
+	if err := r.Merge(sourceID, targetID); !errors.Is(err, entity.ErrTagNotFound) {
+		t.Errorf("Merge() error = %v, wantErr %v", err, entity.ErrTagNotFound)
+	}
+
+	tx.Rollback()
+}
+
+func TestTagRepository_FindByPostIDs(t *testing.T) {
+	tx := db.Begin()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	tags := []*entity.Tag{
+		{ID: "abcdefghijklmnopqrstuvwxy1", Name: "golang", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+		{ID: "abcdefghijklmnopqrstuvwxy2", Name: "docker", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+		{ID: "abcdefghijklmnopqrstuvwxy3", Name: "deleted", CreatedAt: flextime.Now(), UpdatedAt: flextime.Now()},
+	}
+	for _, tag := range tags {
+		if err := tx.Create(tag).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, postID := range []string{"abcdefghijklmnopqrstuvwxy4", "abcdefghijklmnopqrstuvwxy5", "abcdefghijklmnopqrstuvwxy6"} {
+		if err := tx.Create(&entity.Post{
+			ID:          postID,
+			Title:       "new_post",
+			Content:     "new_content",
+			Permalink:   postID,
+			IsDraft:     false,
+			CreatedAt:   flextime.Now(),
+			UpdatedAt:   flextime.Now(),
+			PublishedAt: flextime.Now(),
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 3つ目の投稿にはタグが付いていない
+	postsTags := []*entity.PostsTags{
+		{ID: "abcdefghijklmnopqrstuvwxy7", PostID: "abcdefghijklmnopqrstuvwxy4", TagID: "abcdefghijklmnopqrstuvwxy1"},
+		{ID: "abcdefghijklmnopqrstuvwxy8", PostID: "abcdefghijklmnopqrstuvwxy4", TagID: "abcdefghijklmnopqrstuvwxy2"},
+		{ID: "abcdefghijklmnopqrstuvwxy9", PostID: "abcdefghijklmnopqrstuvwxy5", TagID: "abcdefghijklmnopqrstuvwxy1"},
+		{ID: "abcdefghijklmnopqrstuvwx10", PostID: "abcdefghijklmnopqrstuvwxy5", TagID: "abcdefghijklmnopqrstuvwxy3"},
+	}
+	for _, pt := range postsTags {
+		if err := tx.Create(pt).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tx.Delete(tags[2]).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := &TagRepository{db: tx}
+	got, err := r.FindByPostIDs([]string{"abcdefghijklmnopqrstuvwxy4", "abcdefghijklmnopqrstuvwxy5", "abcdefghijklmnopqrstuvwxy6"})
+	if err != nil {
+		t.Fatalf("FindByPostIDs() error = %v", err)
+	}
+
+	names := make(map[string][]string)
+	for postID, postTags := range got {
+		for _, tag := range postTags {
+			names[postID] = append(names[postID], tag.Name)
+		}
+	}
+	want := map[string][]string{
+		"abcdefghijklmnopqrstuvwxy4": {"docker", "golang"},
+		"abcdefghijklmnopqrstuvwxy5": {"golang"},
+	}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("FindByPostIDs() mismatch (-want +got):\n%s", diff)
+	}
+
+	tx.Rollback()
+}
@@ -0,0 +1,12 @@
+package dto
+
+type ArchiveDTO struct {
+	Year   int                  `json:"year"`
+	Count  int                  `json:"count"`
+	Months []*MonthlyArchiveDTO `json:"months"`
+}
+
+type MonthlyArchiveDTO struct {
+	Month int `json:"month"`
+	Count int `json:"count"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// ImageDTO はメディアライブラリの画像です．サーバーで縮小した画像では，url,width,heightは最も大きいJPEGかPNGの画像を表します
+type ImageDTO struct {
+	ID          string             `json:"id"`
+	Key         string             `json:"key"`
+	URL         string             `json:"url"`
+	ContentType string             `json:"contentType"`
+	Width       int                `json:"width"`
+	Height      int                `json:"height"`
+	AltText     string             `json:"altText"`
+	UserID      *string            `json:"userId"`
+	Srcset      string             `json:"srcset"`
+	WebPSrcset  string             `json:"webpSrcset"`
+	Variants    []*ImageVariantDTO `json:"variants"`
+	UsedBy      []string           `json:"usedBy"`
+	UsedByPages []string           `json:"usedByPages"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+}
+
+type ImageVariantDTO struct {
+	URL         string `json:"url"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	ContentType string `json:"contentType"`
+}
+
+// StoredImageDTO は画像の保存先にあるファイルです
+type StoredImageDTO struct {
+	Key          string    `json:"key"`
+	URL          string    `json:"url"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+}
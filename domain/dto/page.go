@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type PageDTO struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title" binding:"required"`
+	Content   string    `json:"content"`
+	Path      string    `json:"path" binding:"required"`
+	IsDraft   *bool     `json:"isDraft" binding:"required"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
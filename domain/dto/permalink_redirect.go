@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+type PermalinkRedirectDTO struct {
+	ID        string    `json:"id"`
+	PostID    string    `json:"postId"`
+	Permalink string    `json:"permalink"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -5,13 +5,23 @@ import (
 )
 
 type PostDTO struct {
-	ID           string    `json:"id"`
-	Title        string    `json:"title" binding:"required"`
-	ThumbnailURL string    `json:"thumbnailUrl" binding:"required"`
-	Content      string    `json:"content" binding:"required"`
-	Permalink    string    `json:"permalink" binding:"required"`
-	IsDraft      *bool     `json:"isDraft" binding:"required"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
-	PublishedAt  time.Time `json:"publishedAt"`
+	ID           string     `json:"id"`
+	Title        string     `json:"title" binding:"required"`
+	ThumbnailURL string     `json:"thumbnailUrl" binding:"required"`
+	Content      string     `json:"content" binding:"required"`
+	Excerpt      string     `json:"excerpt"`
+	CharCount    int        `json:"charCount"`
+	WordCount    int        `json:"wordCount"`
+	ReadingTime  int        `json:"readingTime"` // 本文を読むのにかかる時間(分)の目安
+	Permalink    string     `json:"permalink" binding:"required"`
+	IsDraft      *bool      `json:"isDraft" binding:"required"`
+	IsScheduled  bool       `json:"isScheduled"`
+	IsPinned     bool       `json:"isPinned"`
+	FeaturedRank int        `json:"featuredRank"`
+	Version      int        `json:"version"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	PublishedAt  time.Time  `json:"publishedAt"`
+	Snippet      string     `json:"snippet,omitempty"`
+	DeletedAt    *time.Time `json:"deletedAt,omitempty"`
 }
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type PostRevisionDTO struct {
+	ID           string    `json:"id"`
+	PostID       string    `json:"postId"`
+	Title        string    `json:"title"`
+	ThumbnailURL string    `json:"thumbnailUrl"`
+	Content      string    `json:"content"`
+	Permalink    string    `json:"permalink"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
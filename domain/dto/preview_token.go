@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type PreviewTokenDTO struct {
+	ID        string    `json:"id"`
+	PostID    string    `json:"postId"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type SeriesDTO struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description"`
+	Posts       []*PostDTO `json:"posts,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// PostSeriesDTO は投稿が連載の何回目かと前後の投稿を表します
+type PostSeriesDTO struct {
+	ID    string         `json:"id"`
+	Title string         `json:"title"`
+	Part  int            `json:"part"`
+	Total int            `json:"total"`
+	Prev  *SeriesPartDTO `json:"prev"`
+	Next  *SeriesPartDTO `json:"next"`
+}
+
+type SeriesPartDTO struct {
+	Title     string `json:"title"`
+	Permalink string `json:"permalink"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "time"
+
+type SitemapEntryDTO struct {
+	Path         string
+	LastModified time.Time
+}
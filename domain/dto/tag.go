@@ -3,8 +3,10 @@ package dto
 import "time"
 
 type TagDTO struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name" binding:"required"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name" binding:"required"`
+	ParentID  *string    `json:"parentId"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
 }
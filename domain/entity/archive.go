@@ -0,0 +1,8 @@
+package entity
+
+// MonthlyPostCount は公開日時の年月ごとの投稿数です
+type MonthlyPostCount struct {
+	Year  int
+	Month int
+	Count int
+}
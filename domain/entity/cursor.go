@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cursor はキーセットページネーションでページを取得し始める位置です
+// 並べ替えるカラムの値とIDの組で位置を表すので，途中で投稿が増えてもページの境目がずれません
+type Cursor struct {
+	Column string `json:"c"`
+	Value  string `json:"v"`
+	ID     string `json:"i"`
+	// Before が true の場合は前のページを取得します
+	Before bool `json:"b,omitempty"`
+	// Pinned は位置を表す行が固定した投稿かどうかです
+	Pinned bool `json:"p,omitempty"`
+}
+
+// NewCursor は column の値が value，IDが id の行の次(before が true の場合は前)を指す Cursor を作成します
+func NewCursor(column string, value interface{}, id string, before bool) *Cursor {
+	var v string
+	switch value := value.(type) {
+	case time.Time:
+		v = value.Format(time.RFC3339Nano)
+	default:
+		v = fmt.Sprint(value)
+	}
+	return &Cursor{
+		Column: column,
+		Value:  v,
+		ID:     id,
+		Before: before,
+	}
+}
+
+// IsCursorColumn は column をカーソルに使えるかどうかを返します
+// カーソルにはカラムの値がそのまま入るので，本文やURLのように長くなるカラムは使えません
+func IsCursorColumn(column string) bool {
+	switch column {
+	case "id", "title", "permalink":
+		return true
+	default:
+		return strings.HasSuffix(column, "_at")
+	}
+}
+
+// DecodeCursor はクライアントから受け取った文字列を Cursor に戻します
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode cursor: %w", ErrCursorInvalid)
+	}
+	cursor := &Cursor{}
+	if err = json.Unmarshal(b, cursor); err != nil || !IsCursorColumn(cursor.Column) || cursor.ID == "" {
+		return nil, fmt.Errorf("decode cursor: %w", ErrCursorInvalid)
+	}
+	if _, err = cursor.value(); err != nil {
+		return nil, fmt.Errorf("decode cursor: %w", err)
+	}
+	return cursor, nil
+}
+
+// Encode はクライアントに渡す不透明な文字列にします
+func (c *Cursor) Encode() string {
+	// フィールドは文字列と真偽値だけなので失敗しない
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// value はカラムの型に合わせて値をSQLのパラメータにします
+func (c *Cursor) value() (interface{}, error) {
+	if strings.HasSuffix(c.Column, "_at") {
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, ErrCursorInvalid
+		}
+		return t, nil
+	}
+	return c.Value, nil
+}
+
+// Condition は table の行のうち Cursor より後(Before の場合は前)の行に絞り込む条件です
+// desc は並べ替えの向きで，カラムの値が同じ行はIDで並べます
+func (c *Cursor) Condition(table string, desc bool) (string, []interface{}, error) {
+	op := ">"
+	if desc != c.Before {
+		op = "<"
+	}
+	if c.Column == "id" {
+		return table + ".id " + op + " ?", []interface{}{c.ID}, nil
+	}
+	value, err := c.value()
+	if err != nil {
+		return "", nil, fmt.Errorf("cursor condition: %w", err)
+	}
+	column := table + "." + c.Column
+	return "(" + column + " " + op + " ? OR (" + column + " = ? AND " + table + ".id " + op + " ?))", []interface{}{value, value, c.ID}, nil
+}
+
+// CursorSortCondition はキーセットページネーションで使う並べ替えの条件です．before が true の場合は逆順にします
+func CursorSortCondition(table, column string, desc, before bool) string {
+	order := " asc"
+	if desc != before {
+		order = " desc"
+	}
+	if column == "id" {
+		return table + ".id" + order
+	}
+	return table + "." + column + order + ", " + table + ".id" + order
+}
+
+// PinnedCondition は固定した行を先頭に並べるときの Condition です
+func (c *Cursor) PinnedCondition(table string, desc bool) (string, []interface{}, error) {
+	condition, params, err := c.Condition(table, desc)
+	if err != nil {
+		return "", nil, fmt.Errorf("cursor pinned condition: %w", err)
+	}
+	op := "<"
+	if c.Before {
+		op = ">"
+	}
+	column := table + ".is_pinned"
+	return "(" + column + " " + op + " ? OR (" + column + " = ? AND " + condition + "))", append([]interface{}{c.Pinned, c.Pinned}, params...), nil
+}
+
+// PinnedCursorSortCondition は固定した行を先頭に並べるときの CursorSortCondition です
+func PinnedCursorSortCondition(table, column string, desc, before bool) string {
+	order := " desc"
+	if before {
+		order = " asc"
+	}
+	return table + ".is_pinned" + order + ", " + CursorSortCondition(table, column, desc, before)
+}
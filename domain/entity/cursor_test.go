@@ -0,0 +1,220 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	publishedAt := time.Date(2021, 1, 22, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name    string
+		cursor  string
+		want    *Cursor
+		wantErr error
+	}{
+		{
+			name:   "Encodeした文字列から元に戻せる",
+			cursor: NewCursor("published_at", publishedAt, "abcdefghijklmnopqrstuvwxy1", true).Encode(),
+			want: &Cursor{
+				Column: "published_at",
+				Value:  "2021-01-22T00:00:00+09:00",
+				ID:     "abcdefghijklmnopqrstuvwxy1",
+				Before: true,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "base64でない場合はErrCursorInvalidを返す",
+			cursor:  "!!!",
+			want:    nil,
+			wantErr: ErrCursorInvalid,
+		},
+		{
+			name:    "IDがない場合はErrCursorInvalidを返す",
+			cursor:  (&Cursor{Column: "id", Value: "abcdefghijklmnopqrstuvwxy1"}).Encode(),
+			want:    nil,
+			wantErr: ErrCursorInvalid,
+		},
+		{
+			name:    "カーソルに使えないカラムの場合はErrCursorInvalidを返す",
+			cursor:  (&Cursor{Column: "content", Value: "# hello", ID: "abcdefghijklmnopqrstuvwxy1"}).Encode(),
+			want:    nil,
+			wantErr: ErrCursorInvalid,
+		},
+		{
+			name:    "日時のカラムの値が日時でない場合はErrCursorInvalidを返す",
+			cursor:  (&Cursor{Column: "published_at", Value: "yesterday", ID: "abcdefghijklmnopqrstuvwxy1"}).Encode(),
+			want:    nil,
+			wantErr: ErrCursorInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.cursor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DecodeCursor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DecodeCursor() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCursor_Condition(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	publishedAt := time.Date(2021, 1, 22, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name          string
+		cursor        *Cursor
+		desc          bool
+		wantCondition string
+		wantParams    []interface{}
+	}{
+		{
+			name:          "昇順で次のページはカーソルより大きい行に絞り込む",
+			cursor:        NewCursor("title", "new_post", "abcdefghijklmnopqrstuvwxy1", false),
+			desc:          false,
+			wantCondition: "(posts.title > ? OR (posts.title = ? AND posts.id > ?))",
+			wantParams:    []interface{}{"new_post", "new_post", "abcdefghijklmnopqrstuvwxy1"},
+		},
+		{
+			name:          "降順で次のページはカーソルより小さい行に絞り込む",
+			cursor:        NewCursor("published_at", publishedAt, "abcdefghijklmnopqrstuvwxy1", false),
+			desc:          true,
+			wantCondition: "(posts.published_at < ? OR (posts.published_at = ? AND posts.id < ?))",
+			wantParams:    []interface{}{publishedAt, publishedAt, "abcdefghijklmnopqrstuvwxy1"},
+		},
+		{
+			name:          "降順で前のページはカーソルより大きい行に絞り込む",
+			cursor:        NewCursor("permalink", "new_permalink", "abcdefghijklmnopqrstuvwxy1", true),
+			desc:          true,
+			wantCondition: "(posts.permalink > ? OR (posts.permalink = ? AND posts.id > ?))",
+			wantParams:    []interface{}{"new_permalink", "new_permalink", "abcdefghijklmnopqrstuvwxy1"},
+		},
+		{
+			name:          "IDで並べる場合はIDだけで絞り込む",
+			cursor:        NewCursor("id", "abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy1", true),
+			desc:          false,
+			wantCondition: "posts.id < ?",
+			wantParams:    []interface{}{"abcdefghijklmnopqrstuvwxy1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, params, err := tt.cursor.Condition("posts", tt.desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if condition != tt.wantCondition {
+				t.Errorf("Condition() condition = %q, want = %q", condition, tt.wantCondition)
+			}
+			if diff := cmp.Diff(tt.wantParams, params, cmp.Comparer(func(x, y time.Time) bool { return x.Equal(y) })); diff != "" {
+				t.Errorf("Condition() params mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCursorSortCondition(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		column string
+		desc   bool
+		before bool
+		want   string
+	}{
+		{
+			name:   "カラムの値が同じ行はIDで並べる",
+			column: "published_at",
+			desc:   true,
+			before: false,
+			want:   "posts.published_at desc, posts.id desc",
+		},
+		{
+			name:   "前のページは逆順に並べる",
+			column: "published_at",
+			desc:   true,
+			before: true,
+			want:   "posts.published_at asc, posts.id asc",
+		},
+		{
+			name:   "IDで並べる場合はIDだけで並べる",
+			column: "id",
+			desc:   false,
+			before: false,
+			want:   "posts.id asc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CursorSortCondition("posts", tt.column, tt.desc, tt.before); got != tt.want {
+				t.Errorf("CursorSortCondition() = %q, want = %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursor_PinnedCondition(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		cursor        *Cursor
+		wantCondition string
+		wantParams    []interface{}
+	}{
+		{
+			name:          "次のページは固定した投稿の後に固定していない投稿を続ける",
+			cursor:        &Cursor{Column: "id", Value: "abcdefghijklmnopqrstuvwxy1", ID: "abcdefghijklmnopqrstuvwxy1", Pinned: true},
+			wantCondition: "(posts.is_pinned < ? OR (posts.is_pinned = ? AND posts.id > ?))",
+			wantParams:    []interface{}{true, true, "abcdefghijklmnopqrstuvwxy1"},
+		},
+		{
+			name:          "前のページは固定していない投稿の前に固定した投稿を続ける",
+			cursor:        &Cursor{Column: "id", Value: "abcdefghijklmnopqrstuvwxy1", ID: "abcdefghijklmnopqrstuvwxy1", Before: true},
+			wantCondition: "(posts.is_pinned > ? OR (posts.is_pinned = ? AND posts.id < ?))",
+			wantParams:    []interface{}{false, false, "abcdefghijklmnopqrstuvwxy1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, params, err := tt.cursor.PinnedCondition("posts", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if condition != tt.wantCondition {
+				t.Errorf("PinnedCondition() condition = %q, want = %q", condition, tt.wantCondition)
+			}
+			if diff := cmp.Diff(tt.wantParams, params); diff != "" {
+				t.Errorf("PinnedCondition() params mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPinnedCursorSortCondition(t *testing.T) {
+	if got, want := PinnedCursorSortCondition("posts", "published_at", true, false), "posts.is_pinned desc, posts.published_at desc, posts.id desc"; got != want {
+		t.Errorf("PinnedCursorSortCondition() = %q, want = %q", got, want)
+	}
+	if got, want := PinnedCursorSortCondition("posts", "published_at", true, true), "posts.is_pinned asc, posts.published_at asc, posts.id asc"; got != want {
+		t.Errorf("PinnedCursorSortCondition() = %q, want = %q", got, want)
+	}
+}
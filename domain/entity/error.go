@@ -11,6 +11,8 @@ var (
 	ErrPasswordTooLong = errors.New("password too long")
 
 	ErrInternalServerError = errors.New("internal server error")
+	// ErrCursorInvalid はページネーションのカーソルが不正なエラーを表します．
+	ErrCursorInvalid = errors.New("cursor is invalid")
 	// ErrUserNotFound はユーザが存在しないエラーを表します。
 	ErrUserNotFound = errors.New("user not found")
 	// ErrUserAlreadyExisted はユーザが既に存在しているエラーを表します。
@@ -28,6 +30,37 @@ var (
 	ErrPostHasEmptyField = errors.New("some fields that have not been filled")
 	// ErrPostColumnNotFound は存在しないカラムが指定されたエラーを表します．
 	ErrPostColumnNotFound = errors.New("specified column does not exist on post")
+	// ErrPostIncludeInvalid は投稿と一緒に取得できないものが指定されたエラーを表します．
+	ErrPostIncludeInvalid = errors.New("specified include is not supported on post")
+	// ErrTagModeInvalid はタグの絞り込み方に all と any 以外が指定されたエラーを表します．
+	ErrTagModeInvalid = errors.New("tag-mode must be all or any")
+	// ErrPublishedAtInvalid は公開日時の範囲の指定が不正なエラーを表します．
+	ErrPublishedAtInvalid = errors.New("published-after and published-before must be RFC3339 or YYYY-MM-DD")
+	// ErrFeaturedRankInvalid はおすすめの順番に負の数が指定されたエラーを表します．
+	ErrFeaturedRankInvalid = errors.New("featuredRank must not be negative")
+	// ErrPostVersionConflict は編集中に投稿が他で更新されていたエラーを表します。
+	ErrPostVersionConflict = errors.New("post has been updated by another request")
+	// ErrPostMoved は投稿のパーマリンクが変更されているエラーを表します。
+	ErrPostMoved = errors.New("post has been moved")
+
+	// ErrPostRevisionNotFound は投稿のリビジョンが存在しないエラーを表します。
+	ErrPostRevisionNotFound = errors.New("post revision not found")
+	// ErrPostRevisionAlreadyExisted は投稿のリビジョンが既に存在しているエラーを表します。
+	ErrPostRevisionAlreadyExisted = errors.New("post revision has already existed")
+
+	// ErrPermalinkRedirectNotFound はパーマリンクのリダイレクトが存在しないエラーを表します。
+	ErrPermalinkRedirectNotFound = errors.New("permalink redirect not found")
+	// ErrPermalinkRedirectAlreadyExisted はパーマリンクのリダイレクトが既に存在しているエラーを表します。
+	ErrPermalinkRedirectAlreadyExisted = errors.New("permalink redirect has already existed")
+
+	// ErrPreviewTokenNotFound はプレビューリンクが存在しないエラーを表します。
+	ErrPreviewTokenNotFound = errors.New("preview token not found")
+	// ErrPreviewTokenAlreadyExisted はプレビューリンクが既に存在しているエラーを表します。
+	ErrPreviewTokenAlreadyExisted = errors.New("preview token has already existed")
+	// ErrPreviewTokenInvalid はプレビューリンクの署名が正しくないエラーを表します。
+	ErrPreviewTokenInvalid = errors.New("preview token is invalid")
+	// ErrPreviewTokenExpired はプレビューリンクの有効期限が切れているエラーを表します。
+	ErrPreviewTokenExpired = errors.New("preview token has expired")
 
 	// ErrTagNotFound はタグが存在しないエラーを表します。
 	ErrTagNotFound = errors.New("tag not found")
@@ -35,6 +68,12 @@ var (
 	ErrTagAlreadyExisted = errors.New("tag has already existed")
 	// ErrTagNameAlreadyExisted はその名前のタグが既に存在しているエラーを表します。
 	ErrTagNameAlreadyExisted = errors.New("tag name has already existed")
+	// ErrTagParentNotFound は親タグが存在しないエラーを表します。
+	ErrTagParentNotFound = errors.New("parent tag not found")
+	// ErrTagParentCycle は親タグに自身かその子孫のタグを指定したエラーを表します。
+	ErrTagParentCycle = errors.New("tag cannot be a child of itself or its descendants")
+	// ErrTagMergeTargetInvalid は統合先に自身かその子孫のタグを指定したエラーを表します。
+	ErrTagMergeTargetInvalid = errors.New("tag cannot be merged into itself or its descendants")
 
 	// ErrPostsTagsNotFound はタグが存在しないエラーを表します。
 	ErrPostsTagsNotFound = errors.New("posts_tags not found")
@@ -42,4 +81,44 @@ var (
 	ErrPostsTagsAlreadyExisted = errors.New("posts_tags has already existed")
 	// ErrPostsTagsCombinationAlreadyExisted はその投稿に同じタグが既に存在しているエラーを表します。
 	ErrPostsTagsCombinationAlreadyExisted = errors.New("posts_tags combination has already existed")
+
+	// ErrSeriesNotFound は連載が存在しないエラーを表します。
+	ErrSeriesNotFound = errors.New("series not found")
+	// ErrSeriesPostNotFound は投稿が連載に含まれていないエラーを表します。
+	ErrSeriesPostNotFound = errors.New("series post not found")
+	// ErrSeriesPostAlreadyExisted は投稿が既に別の連載に含まれているエラーを表します。
+	ErrSeriesPostAlreadyExisted = errors.New("post already belongs to another series")
+
+	// ErrPageNotFound は固定ページが存在しないエラーを表します。
+	ErrPageNotFound = errors.New("page not found")
+	// ErrPagePathAlreadyExisted はそのパスの固定ページが既に存在しているエラーを表します。
+	ErrPagePathAlreadyExisted = errors.New("page path has already existed")
+	// ErrPagePathInvalid は固定ページのパスが正しくないエラーを表します。
+	ErrPagePathInvalid = errors.New("page path must be lowercase letters, digits and hyphens separated by slashes")
+	// ErrPageParentNotFound は親の固定ページが存在しないエラーを表します。
+	ErrPageParentNotFound = errors.New("parent page not found")
+	// ErrPageHasChildren は子の固定ページがあるためパスの変更や削除ができないエラーを表します。
+	ErrPageHasChildren = errors.New("page has child pages")
+
+	// ErrImageNotFound は画像が存在しないエラーを表します。
+	ErrImageNotFound = errors.New("image not found")
+	// ErrImageAlreadyExisted は画像が既に存在しているエラーを表します。
+	ErrImageAlreadyExisted = errors.New("image has already existed")
+	// ErrImageInUse は削除しようとした画像が投稿で使われているエラーを表します。
+	ErrImageInUse = errors.New("image is used by posts")
+	// ErrImageObjectNotFound は画像のファイルが保存先に存在しないエラーを表します。
+	ErrImageObjectNotFound = errors.New("image object not found")
+	// ErrImageKeyInvalid は画像の保存先のキーが正しくないエラーを表します。
+	ErrImageKeyInvalid = errors.New("image key is invalid")
+	// ErrUploadSignatureInvalid はアップロード用URLの署名が正しくないエラーを表します。
+	ErrUploadSignatureInvalid = errors.New("upload signature is invalid")
+	// ErrUploadURLExpired はアップロード用URLの有効期限が切れているエラーを表します。
+	ErrUploadURLExpired = errors.New("upload url has expired")
+	// ErrImageTypeUnsupported はアップロードできない形式の画像のエラーを表します。
+	ErrImageTypeUnsupported = errors.New("image type is not supported")
+	// ErrImageTooLarge は画像が大きすぎるエラーを表します。
+	ErrImageTooLarge = errors.New("image is too large")
+
+	// ErrSitemapNotFound はサイトマップが存在しないエラーを表します。
+	ErrSitemapNotFound = errors.New("sitemap not found")
 )
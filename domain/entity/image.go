@@ -0,0 +1,192 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/util"
+)
+
+// Image はメディアライブラリに登録した画像です
+// サーバーでアップロードを受け付けた画像は，幅や形式を変えた複数の画像(ImageVariant)として保存します
+type Image struct {
+	ID          string `gorm:"PRIMARY_KEY"`
+	Key         string
+	ContentType string
+	Width       int
+	Height      int
+	AltText     string
+	UserID      *string
+	Variants    []*ImageVariant
+	Usages      []*ImageUsage
+	PageUsages  []*ImagePageUsage
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// StoredImage は画像の保存先にあるファイルです
+type StoredImage struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// ConvertToDTO は url で key から公開URLを作って StoredImage を変換します
+func (s *StoredImage) ConvertToDTO(url func(key string) string) *dto.StoredImageDTO {
+	return &dto.StoredImageDTO{
+		Key:          s.Key,
+		URL:          url(s.Key),
+		Size:         s.Size,
+		LastModified: s.LastModified,
+	}
+}
+
+// ImageVariant は幅や形式を変えた画像の1つです
+type ImageVariant struct {
+	ID          string `gorm:"PRIMARY_KEY"`
+	ImageID     string
+	Key         string
+	ContentType string
+	Width       int
+	Height      int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ImageUsage は画像が投稿の本文かサムネイルで使われていることを表します
+type ImageUsage struct {
+	ID        string `gorm:"PRIMARY_KEY"`
+	ImageID   string
+	PostID    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ImagePageUsage は画像が固定ページの本文で使われていることを表します
+type ImagePageUsage struct {
+	ID        string `gorm:"PRIMARY_KEY"`
+	ImageID   string
+	PageID    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewImage は key に保存した画像を作成します．userID はアップロードしたユーザーです
+func NewImage(key, contentType string, userID *string) *Image {
+	return &Image{
+		ID:          util.Generate(flextime.Now()),
+		Key:         key,
+		ContentType: contentType,
+		UserID:      userID,
+	}
+}
+
+func NewImageVariant(imageID, key, contentType string, width, height int) *ImageVariant {
+	return &ImageVariant{
+		ID:          util.Generate(flextime.Now()),
+		ImageID:     imageID,
+		Key:         key,
+		ContentType: contentType,
+		Width:       width,
+		Height:      height,
+	}
+}
+
+func NewImageUsage(imageID, postID string) *ImageUsage {
+	return &ImageUsage{
+		ID:      util.Generate(flextime.Now()),
+		ImageID: imageID,
+		PostID:  postID,
+	}
+}
+
+func NewImagePageUsage(imageID, pageID string) *ImagePageUsage {
+	return &ImagePageUsage{
+		ID:      util.Generate(flextime.Now()),
+		ImageID: imageID,
+		PageID:  pageID,
+	}
+}
+
+// InUse は画像を使っている投稿か固定ページがあるかを返します
+func (i *Image) InUse() bool {
+	return len(i.Usages) > 0 || len(i.PageUsages) > 0
+}
+
+// VariantKey は幅が width で拡張子が ext の画像の保存先のキーを返します
+func (i *Image) VariantKey(width int, ext string) string {
+	return "images/" + i.ID + "/" + strconv.Itoa(width) + "w." + ext
+}
+
+// Keys は画像と幅や形式を変えた画像の保存先のキーを返します
+func (i *Image) Keys() []string {
+	keys := []string{i.Key}
+	for _, v := range i.Variants {
+		if v.Key != i.Key {
+			keys = append(keys, v.Key)
+		}
+	}
+	return keys
+}
+
+// ConvertToDTO は url で保存先のキーを公開URLに変換し，srcsetに使える形にします
+func (i *Image) ConvertToDTO(url func(key string) string) *dto.ImageDTO {
+	imageDTO := &dto.ImageDTO{
+		ID:          i.ID,
+		Key:         i.Key,
+		URL:         url(i.Key),
+		ContentType: i.ContentType,
+		Width:       i.Width,
+		Height:      i.Height,
+		AltText:     i.AltText,
+		UserID:      i.UserID,
+		Variants:    make([]*dto.ImageVariantDTO, 0, len(i.Variants)),
+		UsedBy:      make([]string, 0, len(i.Usages)),
+		UsedByPages: make([]string, 0, len(i.PageUsages)),
+		CreatedAt:   i.CreatedAt,
+		UpdatedAt:   i.UpdatedAt,
+	}
+	var srcset, webpSrcset []string
+	for _, v := range i.Variants {
+		variantURL := url(v.Key)
+		imageDTO.Variants = append(imageDTO.Variants, &dto.ImageVariantDTO{
+			URL:         variantURL,
+			Width:       v.Width,
+			Height:      v.Height,
+			ContentType: v.ContentType,
+		})
+
+		candidate := variantURL + " " + strconv.Itoa(v.Width) + "w"
+		if v.ContentType == "image/webp" {
+			webpSrcset = append(webpSrcset, candidate)
+			continue
+		}
+		srcset = append(srcset, candidate)
+	}
+	imageDTO.Srcset = strings.Join(srcset, ", ")
+	imageDTO.WebPSrcset = strings.Join(webpSrcset, ", ")
+	for _, usage := range i.Usages {
+		imageDTO.UsedBy = append(imageDTO.UsedBy, usage.PostID)
+	}
+	for _, usage := range i.PageUsages {
+		imageDTO.UsedByPages = append(imageDTO.UsedByPages, usage.PageID)
+	}
+	return imageDTO
+}
+
+// IsValidImageKey は画像の保存先のキーとして使えるかを返します
+// 空のキーや絶対パス，親ディレクトリを含むキーは保存先の外を指すので使えません
+func IsValidImageKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/util"
+)
+
+// pagePathMaxLength はDBに保存できる固定ページのパスの長さです
+const pagePathMaxLength = 255
+
+// Page は「About」や「プライバシーポリシー」のように投稿の一覧やフィードに含めない固定ページです
+// パスを / で区切って階層にでき，about/team は about の子ページになります
+type Page struct {
+	ID        string `gorm:"PRIMARY_KEY"`
+	Title     string
+	Content   string
+	Path      string
+	IsDraft   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewPage(title, content, path string, isDraft bool) *Page {
+	return &Page{
+		ID:      util.Generate(flextime.Now()),
+		Title:   title,
+		Content: content,
+		Path:    path,
+		IsDraft: isDraft,
+	}
+}
+
+func (p *Page) ConvertToDTO() *dto.PageDTO {
+	return &dto.PageDTO{
+		ID:        p.ID,
+		Title:     p.Title,
+		Content:   p.Content,
+		Path:      p.Path,
+		IsDraft:   &p.IsDraft,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+// ConvertContentToHTML は本文を投稿と同じようにMarkdownからHTMLにします
+func (p *Page) ConvertContentToHTML() {
+	p.Content = markdownToHTML(p.Content)
+}
+
+// ParentPath は親ページのパスを返します．一番上の階層のページの場合は空文字列を返します
+func (p *Page) ParentPath() string {
+	i := strings.LastIndex(p.Path, "/")
+	if i < 0 {
+		return ""
+	}
+	return p.Path[:i]
+}
+
+// NormalizePagePath は前後の / を取り除いた固定ページのパスを返します
+// パスは英小文字と数字とハイフンからなる区切りを / でつないだものでなければなりません
+func NormalizePagePath(path string) (string, error) {
+	path = strings.Trim(path, "/")
+	if path == "" || len(path) > pagePathMaxLength {
+		return "", fmt.Errorf("normalize page path=%v: %w", path, ErrPagePathInvalid)
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			return "", fmt.Errorf("normalize page path=%v: %w", path, ErrPagePathInvalid)
+		}
+		for _, r := range segment {
+			if !('a' <= r && r <= 'z' || '0' <= r && r <= '9' || r == '-') {
+				return "", fmt.Errorf("normalize page path=%v: %w", path, ErrPagePathInvalid)
+			}
+		}
+	}
+	return path, nil
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPage_ConvertContentToHTML(t *testing.T) {
+	page := &Page{Content: "# About\n\n<script>alert(1)</script>"}
+	page.ConvertContentToHTML()
+	// 投稿と同じようにMarkdownをHTMLにしてサニタイズする
+	if want := "<h1>About</h1>\n\n<p></p>\n"; page.Content != want {
+		t.Errorf("ConvertContentToHTML() = %q, want = %q", page.Content, want)
+	}
+}
+
+func TestPage_ParentPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "子ページは親ページのパスを返す",
+			path: "company/about/team",
+			want: "company/about",
+		},
+		{
+			name: "一番上の階層のページは空文字列を返す",
+			path: "about",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&Page{Path: tt.path}).ParentPath(); got != tt.want {
+				t.Errorf("ParentPath() = %q, want = %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePagePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "前後の/を取り除く",
+			path:    "/company/about/",
+			want:    "company/about",
+			wantErr: nil,
+		},
+		{
+			name:    "空のパスはErrPagePathInvalidを返す",
+			path:    "/",
+			want:    "",
+			wantErr: ErrPagePathInvalid,
+		},
+		{
+			name:    "空の区切りがある場合はErrPagePathInvalidを返す",
+			path:    "company//about",
+			want:    "",
+			wantErr: ErrPagePathInvalid,
+		},
+		{
+			name:    "英小文字と数字とハイフン以外を含む場合はErrPagePathInvalidを返す",
+			path:    "company/About_us",
+			want:    "",
+			wantErr: ErrPagePathInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePagePath(tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NormalizePagePath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizePagePath() = %q, want = %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/util"
+)
+
+// PermalinkRedirect は投稿の変更前のパーマリンクです
+// 古いパーマリンクへのアクセスは投稿の現在のパーマリンクへリダイレクトします
+type PermalinkRedirect struct {
+	ID        string `gorm:"PRIMARY_KEY"`
+	PostID    string
+	Permalink string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewPermalinkRedirect は permalink から投稿へのリダイレクトを作成します
+func NewPermalinkRedirect(postID, permalink string) *PermalinkRedirect {
+	return &PermalinkRedirect{
+		ID:        util.Generate(flextime.Now()),
+		PostID:    postID,
+		Permalink: permalink,
+	}
+}
+
+func (r *PermalinkRedirect) ConvertToDTO() *dto.PermalinkRedirectDTO {
+	return &dto.PermalinkRedirectDTO{
+		ID:        r.ID,
+		PostID:    r.PostID,
+		Permalink: r.Permalink,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
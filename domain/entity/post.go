@@ -1,8 +1,9 @@
 package entity
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
-	"unsafe"
 
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/russross/blackfriday/v2"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/Songmu/flextime"
 	"github.com/masibw/blog-server/domain/dto"
+	"gorm.io/gorm"
 )
 
 type Post struct {
@@ -19,11 +21,20 @@ type Post struct {
 	Title        string
 	ThumbnailURL string
 	Content      string
+	Excerpt      string
+	CharCount    int
+	WordCount    int
+	ReadingTime  int
 	Permalink    string
 	IsDraft      bool
+	IsScheduled  bool
+	IsPinned     bool // 並べ替えに関わらず一覧の先頭に表示する
+	FeaturedRank int  // 1以上の場合はトップページのおすすめにこの順番で表示する
+	Version      int  // 更新するたびに1ずつ増やし，同時に編集された時の競合を見つけるのに使う
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	PublishedAt  time.Time
+	DeletedAt    gorm.DeletedAt
 }
 
 func NewPost() *Post {
@@ -34,21 +45,49 @@ func NewPost() *Post {
 		Content:      "",
 		Permalink:    "",
 		IsDraft:      true,
+		IsScheduled:  false,
+		Version:      1,
 		PublishedAt:  time.Time{},
 	}
 }
 
+// OGPImageKey は投稿から自動で作るOGP画像を保存するキーです
+// 描いたタイトルとタグのハッシュを含むので，タイトルやタグを変えた時だけ別のキーになります
+func OGPImageKey(postID, title string, tags []string) string {
+	h := sha256.New()
+	h.Write([]byte(title))
+	for _, tag := range tags {
+		h.Write([]byte{0})
+		h.Write([]byte(tag))
+	}
+	return OGPImageKeyPrefix(postID) + "-" + hex.EncodeToString(h.Sum(nil)[:8]) + ".png"
+}
+
+// OGPImageKeyPrefix は投稿から自動で作ったOGP画像のキーの先頭です．ハッシュを含まない以前のキー(ogp/{id}.png)もこれで始まります
+func OGPImageKeyPrefix(postID string) string {
+	return "ogp/" + postID
+}
+
 func (p *Post) ConvertToDTO() *dto.PostDTO {
 	return &dto.PostDTO{
 		ID:           p.ID,
 		Title:        p.Title,
 		ThumbnailURL: p.ThumbnailURL,
 		Content:      p.Content,
+		Excerpt:      p.Excerpt,
+		CharCount:    p.CharCount,
+		WordCount:    p.WordCount,
+		ReadingTime:  p.ReadingTime,
 		Permalink:    p.Permalink,
 		IsDraft:      &p.IsDraft,
+		IsScheduled:  p.IsScheduled,
+		IsPinned:     p.IsPinned,
+		FeaturedRank: p.FeaturedRank,
+		Version:      p.Version,
 		UpdatedAt:    p.UpdatedAt,
 		CreatedAt:    p.CreatedAt,
 		PublishedAt:  p.PublishedAt,
+		DeletedAt:    deletedAtToDTO(p.DeletedAt),
 	}
 }
 
@@ -64,10 +103,52 @@ func (p *Post) ConvertFromDTO(postDTO *dto.PostDTO) {
 	p.PublishedAt = postDTO.PublishedAt
 }
 
+// deletedAtToDTO はゴミ箱に移動した日時を返し，移動していなければnilを返します
+func deletedAtToDTO(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return &deletedAt.Time
+}
+
+// IsBeforePublish は公開済みだが公開日時がまだ来ていない投稿かどうかを返します
+func (p *Post) IsBeforePublish(now time.Time) bool {
+	return !p.IsDraft && p.PublishedAt.After(now)
+}
+
+// IsModifiedSince は編集を始めた時点のバージョン version か更新日時 updatedAt の後に投稿が更新されているかを返します
+// version が0の場合は更新日時で比較しますが，DBには秒単位で保存されるため秒に丸めて比較します
+func (p *Post) IsModifiedSince(version int, updatedAt time.Time) bool {
+	if version != 0 {
+		return p.Version != version
+	}
+	return !p.UpdatedAt.Round(time.Second).Equal(updatedAt.Round(time.Second))
+}
+
+// ColumnValue はカーソルに使うカラム(IsCursorColumn)の値を返します
+func (p *Post) ColumnValue(column string) interface{} {
+	switch column {
+	case "title":
+		return p.Title
+	case "permalink":
+		return p.Permalink
+	case "created_at":
+		return p.CreatedAt
+	case "updated_at":
+		return p.UpdatedAt
+	case "published_at":
+		return p.PublishedAt
+	default:
+		return p.ID
+	}
+}
+
 func (p *Post) ConvertContentToHTML() {
-	bytesContent := *(*[]byte)(unsafe.Pointer(&p.Content))
-	unsafeHTML := blackfriday.Run(bytesContent)
-	sanitizedHTML := bluemonday.UGCPolicy().SanitizeBytes(unsafeHTML)
-	content := *(*string)(unsafe.Pointer(&sanitizedHTML))
-	p.Content = content
+	p.Content = markdownToHTML(p.Content)
+}
+
+// markdownToHTML はMarkdownをサニタイズしたHTMLにします．投稿と固定ページで同じ表示にするために共通で使います
+func markdownToHTML(markdown string) string {
+	unsafeHTML := blackfriday.Run([]byte(markdown))
+	return string(bluemonday.UGCPolicy().SanitizeBytes(unsafeHTML))
 }
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/util"
+)
+
+// PostRevision は更新前の投稿の内容を保存したものです
+type PostRevision struct {
+	ID           string `gorm:"PRIMARY_KEY"`
+	PostID       string
+	Title        string
+	ThumbnailURL string
+	Content      string
+	Permalink    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewPostRevision は投稿の現在の内容からリビジョンを作成します
+func NewPostRevision(post *Post) *PostRevision {
+	return &PostRevision{
+		ID:           util.Generate(flextime.Now()),
+		PostID:       post.ID,
+		Title:        post.Title,
+		ThumbnailURL: post.ThumbnailURL,
+		Content:      post.Content,
+		Permalink:    post.Permalink,
+	}
+}
+
+// HasSameContent は投稿とリビジョンの内容が同一かどうかを返します
+func (r *PostRevision) HasSameContent(post *Post) bool {
+	return r.Title == post.Title &&
+		r.ThumbnailURL == post.ThumbnailURL &&
+		r.Content == post.Content &&
+		r.Permalink == post.Permalink
+}
+
+// ApplyTo はリビジョンの内容で投稿を上書きします
+func (r *PostRevision) ApplyTo(post *Post) {
+	post.Title = r.Title
+	post.ThumbnailURL = r.ThumbnailURL
+	post.Content = r.Content
+	post.Permalink = r.Permalink
+}
+
+func (r *PostRevision) ConvertToDTO() *dto.PostRevisionDTO {
+	return &dto.PostRevisionDTO{
+		ID:           r.ID,
+		PostID:       r.PostID,
+		Title:        r.Title,
+		ThumbnailURL: r.ThumbnailURL,
+		Content:      r.Content,
+		Permalink:    r.Permalink,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -52,3 +53,103 @@ func TestPost_ConvertContentToHTML(t *testing.T) {
 	}
 
 }
+
+func TestPost_IsModifiedSince(t *testing.T) {
+	updatedAt := time.Date(2021, 1, 22, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		version   int
+		updatedAt time.Time
+		want      bool
+	}{
+		{
+			name:      "更新日時が同じ場合はfalseを返す",
+			version:   0,
+			updatedAt: updatedAt,
+			want:      false,
+		},
+		{
+			name:      "秒未満の違いはDBの精度に合わせて無視する",
+			version:   0,
+			updatedAt: updatedAt.Add(300 * time.Millisecond),
+			want:      false,
+		},
+		{
+			name:      "編集を始めた後に更新されている場合はtrueを返す",
+			version:   0,
+			updatedAt: updatedAt.Add(-time.Second),
+			want:      true,
+		},
+		{
+			name:      "バージョンを指定した場合は更新日時が同じでもバージョンで比較する",
+			version:   2,
+			updatedAt: updatedAt,
+			want:      true,
+		},
+		{
+			name:      "バージョンが同じ場合はfalseを返す",
+			version:   3,
+			updatedAt: time.Time{},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Post{Version: 3, UpdatedAt: updatedAt}
+			if got := p.IsModifiedSince(tt.version, tt.updatedAt); got != tt.want {
+				t.Errorf("IsModifiedSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOGPImageKey(t *testing.T) {
+	postID := "abcdefghijklmnopqrstuvwxy1"
+	key := OGPImageKey(postID, "title", []string{"go", "blog"})
+
+	tests := []struct {
+		name  string
+		title string
+		tags  []string
+		want  bool
+	}{
+		{
+			name:  "タイトルとタグが同じ場合は同じキーになる",
+			title: "title",
+			tags:  []string{"go", "blog"},
+			want:  true,
+		},
+		{
+			name:  "タイトルが変わると別のキーになる",
+			title: "title2",
+			tags:  []string{"go", "blog"},
+			want:  false,
+		},
+		{
+			name:  "タグが変わると別のキーになる",
+			title: "title",
+			tags:  []string{"go"},
+			want:  false,
+		},
+		{
+			name:  "タイトルとタグの区切りが変わると別のキーになる",
+			title: "titlego",
+			tags:  []string{"blog"},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OGPImageKey(postID, tt.title, tt.tags)
+			if (got == key) != tt.want {
+				t.Errorf("OGPImageKey() = %v, key = %v, want same %v", got, key, tt.want)
+			}
+			if !strings.HasPrefix(got, OGPImageKeyPrefix(postID)+"-") || !strings.HasSuffix(got, ".png") {
+				t.Errorf("OGPImageKey() = %v, want ogp/{id}-{hash}.png", got)
+			}
+		})
+	}
+}
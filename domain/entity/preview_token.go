@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/util"
+)
+
+// PreviewToken は下書きをログインしていない人に見せるためのプレビューリンクです
+// トークンの行を削除するとリンクは無効になります
+type PreviewToken struct {
+	ID        string `gorm:"PRIMARY_KEY"`
+	PostID    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewPreviewToken は expiresAt まで有効なプレビューリンクを作成します
+func NewPreviewToken(postID string, expiresAt time.Time) *PreviewToken {
+	return &PreviewToken{
+		ID:        util.Generate(flextime.Now()),
+		PostID:    postID,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// IsExpired は now の時点で有効期限が切れているかを返します
+func (t *PreviewToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// Sign はIDと有効期限にHMAC-SHA256の署名を付けたトークン文字列を返します
+func (t *PreviewToken) Sign(secret []byte) string {
+	payload := t.ID + "." + strconv.FormatInt(t.ExpiresAt.Unix(), 10)
+	return payload + "." + previewTokenSignature(secret, payload)
+}
+
+// ParsePreviewToken は Sign で作ったトークン文字列の署名を検証し，IDと有効期限を返します
+func ParsePreviewToken(token string, secret []byte) (id string, expiresAt time.Time, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		err = ErrPreviewTokenInvalid
+		return
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(previewTokenSignature(secret, payload))) {
+		err = ErrPreviewTokenInvalid
+		return
+	}
+	var unix int64
+	unix, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		err = ErrPreviewTokenInvalid
+		return
+	}
+	return parts[0], time.Unix(unix, 0), nil
+}
+
+func previewTokenSignature(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (t *PreviewToken) ConvertToDTO(secret []byte) *dto.PreviewTokenDTO {
+	return &dto.PreviewTokenDTO{
+		ID:        t.ID,
+		PostID:    t.PostID,
+		Token:     t.Sign(secret),
+		ExpiresAt: t.ExpiresAt,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
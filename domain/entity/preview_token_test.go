@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParsePreviewToken(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("secret")
+	previewToken := &PreviewToken{
+		ID:        "abcdefghijklmnopqrstuvwxy1",
+		PostID:    "abcdefghijklmnopqrstuvwxyz",
+		ExpiresAt: time.Date(2021, 1, 25, 0, 0, 0, 0, loc),
+	}
+	token := previewToken.Sign(secret)
+
+	tests := []struct {
+		name          string
+		token         string
+		secret        []byte
+		wantID        string
+		wantExpiresAt time.Time
+		wantErr       error
+	}{
+		{
+			name:          "署名したトークンからIDと有効期限を取り出せる",
+			token:         token,
+			secret:        secret,
+			wantID:        "abcdefghijklmnopqrstuvwxy1",
+			wantExpiresAt: time.Date(2021, 1, 25, 0, 0, 0, 0, loc),
+			wantErr:       nil,
+		},
+		{
+			name:    "別の鍵で署名したトークンはErrPreviewTokenInvalidを返す",
+			token:   token,
+			secret:  []byte("another secret"),
+			wantErr: ErrPreviewTokenInvalid,
+		},
+		{
+			name:    "有効期限を書き換えたトークンはErrPreviewTokenInvalidを返す",
+			token:   "abcdefghijklmnopqrstuvwxy1.1924959600." + token[len(token)-43:],
+			secret:  secret,
+			wantErr: ErrPreviewTokenInvalid,
+		},
+		{
+			name:    "形式が違うトークンはErrPreviewTokenInvalidを返す",
+			token:   "abcdefghijklmnopqrstuvwxy1",
+			secret:  secret,
+			wantErr: ErrPreviewTokenInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, expiresAt, err := ParsePreviewToken(tt.token, tt.secret)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParsePreviewToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if id != tt.wantID {
+				t.Errorf("ParsePreviewToken() id = %v, want %v", id, tt.wantID)
+			}
+			if !expiresAt.Equal(tt.wantExpiresAt) {
+				t.Errorf("ParsePreviewToken() expiresAt = %v, want %v", expiresAt, tt.wantExpiresAt)
+			}
+		})
+	}
+}
+
+func TestPreviewToken_IsExpired(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previewToken := &PreviewToken{ExpiresAt: time.Date(2021, 1, 25, 0, 0, 0, 0, loc)}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{
+			name: "有効期限より前であれば有効",
+			now:  time.Date(2021, 1, 24, 23, 59, 59, 0, loc),
+			want: false,
+		},
+		{
+			name: "有効期限ちょうどであれば期限切れ",
+			now:  time.Date(2021, 1, 25, 0, 0, 0, 0, loc),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := previewToken.IsExpired(tt.now); got != tt.want {
+				t.Errorf("IsExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
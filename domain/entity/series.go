@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/util"
+)
+
+// Series は複数回に分けて書いた投稿をまとめる連載です
+type Series struct {
+	ID          string `gorm:"PRIMARY_KEY"`
+	Title       string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (Series) TableName() string {
+	return "series"
+}
+
+func NewSeries(title, description string) *Series {
+	return &Series{
+		ID:          util.Generate(flextime.Now()),
+		Title:       title,
+		Description: description,
+	}
+}
+
+func (s *Series) ConvertToDTO() *dto.SeriesDTO {
+	return &dto.SeriesDTO{
+		ID:          s.ID,
+		Title:       s.Title,
+		Description: s.Description,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+// SeriesPost は連載に含まれる投稿とその連載の中での順番(1から)です
+// 1つの投稿は1つの連載にだけ含められます
+type SeriesPost struct {
+	ID        string `gorm:"PRIMARY_KEY"`
+	SeriesID  string
+	PostID    string
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (SeriesPost) TableName() string {
+	return "series_posts"
+}
+
+func NewSeriesPost(seriesID, postID string, position int) *SeriesPost {
+	return &SeriesPost{
+		ID:       util.Generate(flextime.Now()),
+		SeriesID: seriesID,
+		PostID:   postID,
+		Position: position,
+	}
+}
+
+// NewPostSeries は連載の公開済みの投稿 posts (順番に並べたもの)から post が何回目かと前後の投稿を表すものを作ります
+// post が posts に含まれない場合は nil を返します
+func NewPostSeries(series *Series, post *Post, posts []*Post) *dto.PostSeriesDTO {
+	for i, p := range posts {
+		if p.ID != post.ID {
+			continue
+		}
+		postSeries := &dto.PostSeriesDTO{
+			ID:    series.ID,
+			Title: series.Title,
+			Part:  i + 1,
+			Total: len(posts),
+		}
+		if i > 0 {
+			postSeries.Prev = &dto.SeriesPartDTO{Title: posts[i-1].Title, Permalink: posts[i-1].Permalink}
+		}
+		if i+1 < len(posts) {
+			postSeries.Next = &dto.SeriesPartDTO{Title: posts[i+1].Title, Permalink: posts[i+1].Permalink}
+		}
+		return postSeries
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"math"
+	"strings"
+)
+
+// TermVector は語ごとの重みです
+type TermVector map[string]float64
+
+// Tokenize は文章の類似度を計算するために文章を語に分けます
+// 英語などは空白や記号で区切って小文字にした2文字以上の単語に，日本語などは辞書を使わずに隣り合う2文字ずつに分けます
+func Tokenize(text string) []string {
+	var tokens []string
+	var word, cjk []rune
+	flush := func() {
+		if len(word) > 1 {
+			tokens = append(tokens, string(word))
+		}
+		if len(cjk) == 1 {
+			tokens = append(tokens, string(cjk))
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			tokens = append(tokens, string(cjk[i:i+2]))
+		}
+		word, cjk = word[:0], cjk[:0]
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		// 長音符はカタカナの語の一部として扱う
+		case isCJK(r) || r == 'ー':
+			if len(word) > 0 {
+				flush()
+			}
+			cjk = append(cjk, r)
+		case isWordRune(r):
+			if len(cjk) > 0 {
+				flush()
+			}
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// NewTFIDFVectors は文書ごとの語を TF-IDF で重み付けし，長さを1にしたベクトルを返します
+// 多くの文書に出てくる語ほど軽くなるので，共通する珍しい語が多い文書同士ほど類似度が高くなります
+func NewTFIDFVectors(documents [][]string) []TermVector {
+	df := make(map[string]int)
+	for _, tokens := range documents {
+		seen := make(map[string]bool)
+		for _, token := range tokens {
+			if !seen[token] {
+				seen[token] = true
+				df[token]++
+			}
+		}
+	}
+
+	n := float64(len(documents))
+	vectors := make([]TermVector, 0, len(documents))
+	for _, tokens := range documents {
+		vector := make(TermVector)
+		for _, token := range tokens {
+			vector[token]++
+		}
+		var norm float64
+		for token, tf := range vector {
+			// すべての文書に出てくる語も0にならないように1を足す
+			w := tf * (math.Log((1+n)/(1+float64(df[token]))) + 1)
+			vector[token] = w
+			norm += w * w
+		}
+		norm = math.Sqrt(norm)
+		for token := range vector {
+			vector[token] /= norm
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors
+}
+
+// Cosine は長さを1にしたベクトル同士のコサイン類似度を返します
+func (v TermVector) Cosine(other TermVector) float64 {
+	if len(other) < len(v) {
+		v, other = other, v
+	}
+	var dot float64
+	for token, w := range v {
+		dot += w * other[token]
+	}
+	return dot
+}
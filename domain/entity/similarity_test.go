@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTokenize(t *testing.T) {
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "英語は小文字にした2文字以上の単語に分ける",
+			text: "Go and a MySQL, v8",
+			want: []string{"go", "and", "mysql", "v8"},
+		},
+		{
+			name: "日本語は隣り合う2文字ずつに分ける",
+			text: "東京タワー",
+			want: []string{"東京", "京タ", "タワ", "ワー"},
+		},
+		{
+			name: "英語と日本語が混ざっていても分ける",
+			text: "Goで書く。字",
+			want: []string{"go", "で書", "書く", "字"},
+		},
+		{
+			name: "空の文章は語がない",
+			text: " 、",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, Tokenize(tt.text)); diff != "" {
+				t.Errorf("Tokenize() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewTFIDFVectors(t *testing.T) {
+	vectors := NewTFIDFVectors([][]string{
+		{"go", "mysql", "blog"},
+		{"go", "mysql", "blog"},
+		{"go", "gin", "blog"},
+		{"rust", "wasm"},
+	})
+
+	tests := []struct {
+		name string
+		a, b TermVector
+		want func(got float64) bool
+	}{
+		{
+			name: "同じ語の文書は類似度が1になる",
+			a:    vectors[0],
+			b:    vectors[1],
+			want: func(got float64) bool { return math.Abs(got-1) < 1e-9 },
+		},
+		{
+			name: "共通する語がない文書は類似度が0になる",
+			a:    vectors[0],
+			b:    vectors[3],
+			want: func(got float64) bool { return got == 0 },
+		},
+		{
+			name: "一部の語が共通する文書は類似度が0と1の間になる",
+			a:    vectors[0],
+			b:    vectors[2],
+			want: func(got float64) bool { return got > 0 && got < 1 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Cosine(tt.b); !tt.want(got) {
+				t.Errorf("Cosine() = %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/masibw/blog-server/constant"
+)
+
+// Snippet は本文から検索語を含む部分を抜き出し，検索語を<mark>で囲んだ抜粋を返します
+// 抜粋はHTMLとしてそのまま表示できるようにエスケープしています
+func (p *Post) Snippet(query string) string {
+	text := []rune(strings.Join(strings.Fields(p.Content), " "))
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	terms := make([][]rune, 0)
+	for _, term := range strings.Fields(query) {
+		terms = append(terms, []rune(strings.ToLower(term)))
+	}
+
+	// 最初に一致した語の少し前から抜き出す
+	start := 0
+	if pos := indexOfTerms(lower, terms); pos >= 0 {
+		start = pos - constant.SnippetLeadingLength
+		if start < 0 {
+			start = 0
+		}
+	}
+	end := start + constant.SnippetLength
+	if end > len(text) {
+		end = len(text)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		if n := matchTerms(lower, terms, i); n > 0 {
+			if i+n > end {
+				n = end - i
+			}
+			b.WriteString("<mark>" + html.EscapeString(string(text[i:i+n])) + "</mark>")
+			i += n
+			continue
+		}
+		b.WriteString(html.EscapeString(string(text[i])))
+		i++
+	}
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// indexOfTerms は最初にいずれかの語に一致する位置を返します
+func indexOfTerms(text []rune, terms [][]rune) int {
+	for i := range text {
+		if matchTerms(text, terms, i) > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchTerms は i の位置から一致する最も長い語の文字数を返します
+func matchTerms(text []rune, terms [][]rune, i int) int {
+	longest := 0
+	for _, term := range terms {
+		if len(term) <= longest || i+len(term) > len(text) {
+			continue
+		}
+		if string(text[i:i+len(term)]) == string(term) {
+			longest = len(term)
+		}
+	}
+	return longest
+}
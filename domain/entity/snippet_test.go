@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPost_Snippet(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		content string
+		query   string
+		want    string
+	}{
+		{
+			name:    "検索語を<mark>で囲んだ抜粋を返す",
+			content: "今日は美味しいラーメンを食べた",
+			query:   "ラーメン",
+			want:    "今日は美味しい<mark>ラーメン</mark>を食べた",
+		},
+		{
+			name:    "複数の検索語と大文字小文字の違いに一致する",
+			content: "Go言語でgoroutineを使う",
+			query:   "go 使う",
+			want:    "<mark>Go</mark>言語で<mark>go</mark>routineを<mark>使う</mark>",
+		},
+		{
+			name:    "本文はHTMLエスケープされ改行は空白にまとめられる",
+			content: "<script>\n\nalert(1)</script>",
+			query:   "alert",
+			want:    "&lt;script&gt; <mark>alert</mark>(1)&lt;/script&gt;",
+		},
+		{
+			name:    "一致する語がない場合は本文の先頭を返す",
+			content: "ラーメン",
+			query:   "うどん",
+			want:    "ラーメン",
+		},
+		{
+			name:    "長い本文は一致した語の周辺だけを抜き出す",
+			content: strings.Repeat("あ", 100) + "ラーメン" + strings.Repeat("い", 200),
+			query:   "ラーメン",
+			want:    "…" + strings.Repeat("あ", 30) + "<mark>ラーメン</mark>" + strings.Repeat("い", 86) + "…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Post{Content: tt.content}
+			if got := p.Snippet(tt.query); got != tt.want {
+				t.Errorf("Snippet() got = %s, want = %s", got, tt.want)
+			}
+		})
+	}
+}
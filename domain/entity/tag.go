@@ -6,13 +6,16 @@ import (
 	"github.com/Songmu/flextime"
 	"github.com/masibw/blog-server/domain/dto"
 	"github.com/masibw/blog-server/util"
+	"gorm.io/gorm"
 )
 
 type Tag struct {
 	ID        string `gorm:"PRIMARY_KEY"`
 	Name      string
+	ParentID  *string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
 }
 
 func NewTag(name string) *Tag {
@@ -26,7 +29,9 @@ func (p *Tag) ConvertToDTO() *dto.TagDTO {
 	return &dto.TagDTO{
 		ID:        p.ID,
 		Name:      p.Name,
+		ParentID:  p.ParentID,
 		UpdatedAt: p.UpdatedAt,
 		CreatedAt: p.CreatedAt,
+		DeletedAt: deletedAtToDTO(p.DeletedAt),
 	}
 }
@@ -0,0 +1,16 @@
+package entity
+
+// TagFilter は投稿をタグで絞り込む条件です．タグには子孫のタグも含みます
+type TagFilter struct {
+	// Names のタグが付いた投稿に絞り込みます
+	Names []string
+	// MatchAll が true の場合は Names のすべてのタグ，false の場合はいずれかのタグが付いた投稿に絞り込みます
+	MatchAll bool
+	// ExcludedNames のタグのいずれかが付いた投稿は除きます
+	ExcludedNames []string
+}
+
+// IsEmpty は絞り込む条件がないかを返します
+func (f *TagFilter) IsEmpty() bool {
+	return f == nil || (len(f.Names) == 0 && len(f.ExcludedNames) == 0)
+}
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/russross/blackfriday/v2"
+
+	"github.com/masibw/blog-server/constant"
+)
+
+// PlainText は Markdown から記法とコードブロック，HTML，画像を取り除いた文章を返します
+// 段落や見出しの区切りと改行は空白1つにまとめます
+func PlainText(markdown string) string {
+	node := blackfriday.New(blackfriday.WithExtensions(blackfriday.CommonExtensions)).Parse([]byte(markdown))
+
+	var b strings.Builder
+	node.Walk(func(n *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		switch n.Type {
+		case blackfriday.CodeBlock, blackfriday.HTMLBlock, blackfriday.HTMLSpan, blackfriday.Image:
+			return blackfriday.SkipChildren
+		case blackfriday.Text, blackfriday.Code:
+			b.Write(n.Literal)
+		case blackfriday.Paragraph, blackfriday.Heading, blackfriday.Item, blackfriday.TableCell, blackfriday.Softbreak, blackfriday.Hardbreak:
+			b.WriteString(" ")
+		}
+		return blackfriday.GoToNext
+	})
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Description は Markdown の本文の先頭から説明文を作ります．長い場合は省略して末尾に…を付けます
+func Description(markdown string) string {
+	return truncateText(PlainText(markdown), constant.DescriptionLength)
+}
+
+// Summarize は本文から一覧に載せる抜粋と文字数，単語数，読むのにかかる時間を計算します
+// 日本語などは1文字を1語として数え，英語などとは別の速さで読むものとして時間を見積もります
+func (p *Post) Summarize() {
+	text := PlainText(p.Content)
+	p.Excerpt = truncateText(text, constant.ExcerptLength)
+
+	var chars, cjkChars, words int
+	inWord := false
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			inWord = false
+			continue
+		}
+		chars++
+		switch {
+		case isCJK(r):
+			cjkChars++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		default:
+			inWord = false
+		}
+	}
+	p.CharCount = chars
+	p.WordCount = cjkChars + words
+
+	// 1分未満でも本文があれば1分とする
+	minutes := float64(cjkChars)/float64(constant.CJKCharsPerMinute) + float64(words)/float64(constant.WordsPerMinute)
+	p.ReadingTime = int(math.Ceil(minutes))
+	if p.ReadingTime == 0 && p.Content != "" {
+		p.ReadingTime = 1
+	}
+}
+
+// EnsureSummary は抜粋などを計算する前に保存した投稿の場合だけ Summarize します
+// 本文があれば読むのにかかる時間は1分以上になるので，0の場合はまだ計算していません
+func (p *Post) EnsureSummary() {
+	if p.ReadingTime == 0 && p.Content != "" {
+		p.Summarize()
+	}
+}
+
+// truncateText は text が length 文字より長い場合は省略して末尾に…を付けます
+// 英単語の途中で切らないように直前の空白まで戻しますが，日本語などはどこで切っても構いません
+func truncateText(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	cut := length
+	if isWordRune(runes[cut-1]) && isWordRune(runes[cut]) {
+		for i := cut - 1; i >= length/2; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace) + "…"
+}
+
+// isCJK は漢字，ひらがな，カタカナ，ハングルかどうかを返します
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// isWordRune は空白で区切る言語の単語に含まれる文字かどうかを返します
+func isWordRune(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !isCJK(r)
+}
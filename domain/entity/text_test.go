@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlainText(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "記法を取り除いた文章を返す",
+			markdown: "# 見出し\n\n**太字**と[リンク](https://example.com)と`code`\n\n- 項目1\n- 項目2",
+			want:     "見出し 太字とリンクとcode 項目1 項目2",
+		},
+		{
+			name:     "コードブロックとHTMLと画像は取り除く",
+			markdown: "前\n\n```go\nfmt.Println()\n```\n\n<div>html</div>\n\n![画像](https://example.com/image.png)後",
+			want:     "前 後",
+		},
+		{
+			name:     "改行は空白にまとめる",
+			markdown: "1行目\n2行目  \n3行目",
+			want:     "1行目 2行目 3行目",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlainText(tt.markdown); got != tt.want {
+				t.Errorf("PlainText() = %q, want = %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescription(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "短い本文はそのまま返す",
+			markdown: "ラーメンを食べた",
+			want:     "ラーメンを食べた",
+		},
+		{
+			name:     "長い本文は省略する",
+			markdown: strings.Repeat("あ", 200),
+			want:     strings.Repeat("あ", 120) + "…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Description(tt.markdown); got != tt.want {
+				t.Errorf("Description() = %q, want = %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPost_Summarize(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		content         string
+		wantExcerpt     string
+		wantCharCount   int
+		wantWordCount   int
+		wantReadingTime int
+	}{
+		{
+			name:            "日本語は1文字を1語として数える",
+			content:         "# 見出し\n\nラーメンを食べた",
+			wantExcerpt:     "見出し ラーメンを食べた",
+			wantCharCount:   11,
+			wantWordCount:   11,
+			wantReadingTime: 1,
+		},
+		{
+			name:            "英語は空白や記号で区切って単語を数える",
+			content:         "Hello, **world**! It's 2021.",
+			wantExcerpt:     "Hello, world! It's 2021.",
+			wantCharCount:   21,
+			wantWordCount:   5,
+			wantReadingTime: 1,
+		},
+		{
+			name:            "日本語と英語が混ざっていても数える",
+			content:         "Goで書いた",
+			wantExcerpt:     "Goで書いた",
+			wantCharCount:   6,
+			wantWordCount:   5,
+			wantReadingTime: 1,
+		},
+		{
+			name:            "長い本文は抜粋を省略し読む時間を切り上げる",
+			content:         strings.Repeat("あ", 1001),
+			wantExcerpt:     strings.Repeat("あ", 200) + "…",
+			wantCharCount:   1001,
+			wantWordCount:   1001,
+			wantReadingTime: 3,
+		},
+		{
+			name:            "本文が空の場合は0を返す",
+			content:         "",
+			wantExcerpt:     "",
+			wantCharCount:   0,
+			wantWordCount:   0,
+			wantReadingTime: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Post{Content: tt.content}
+			p.Summarize()
+			if p.Excerpt != tt.wantExcerpt {
+				t.Errorf("Summarize() Excerpt = %q, want = %q", p.Excerpt, tt.wantExcerpt)
+			}
+			if p.CharCount != tt.wantCharCount {
+				t.Errorf("Summarize() CharCount = %d, want = %d", p.CharCount, tt.wantCharCount)
+			}
+			if p.WordCount != tt.wantWordCount {
+				t.Errorf("Summarize() WordCount = %d, want = %d", p.WordCount, tt.wantWordCount)
+			}
+			if p.ReadingTime != tt.wantReadingTime {
+				t.Errorf("Summarize() ReadingTime = %d, want = %d", p.ReadingTime, tt.wantReadingTime)
+			}
+		})
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		text   string
+		length int
+		want   string
+	}{
+		{
+			name:   "短い文章はそのまま返す",
+			text:   "hello world",
+			length: 20,
+			want:   "hello world",
+		},
+		{
+			name:   "英単語の途中で切らずに空白まで戻る",
+			text:   "hello wonderful world",
+			length: 10,
+			want:   "hello…",
+		},
+		{
+			name:   "空白が遠い場合は単語の途中で切る",
+			text:   "a wonderful",
+			length: 8,
+			want:   "a wonder…",
+		},
+		{
+			name:   "日本語はどこで切ってもよい",
+			text:   "ラーメンを食べた",
+			length: 4,
+			want:   "ラーメン…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateText(tt.text, tt.length); got != tt.want {
+				t.Errorf("truncateText() = %q, want = %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/image.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/masibw/blog-server/domain/entity"
+)
+
+// MockImage is a mock of Image interface.
+type MockImage struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageMockRecorder
+}
+
+// MockImageMockRecorder is the mock recorder for MockImage.
+type MockImageMockRecorder struct {
+	mock *MockImage
+}
+
+// NewMockImage creates a new mock instance.
+func NewMockImage(ctrl *gomock.Controller) *MockImage {
+	mock := &MockImage{ctrl: ctrl}
+	mock.recorder = &MockImageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImage) EXPECT() *MockImageMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockImage) Count(query string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", query)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockImageMockRecorder) Count(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockImage)(nil).Count), query)
+}
+
+// Create mocks base method.
+func (m *MockImage) Create(image *entity.Image) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", image)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImageMockRecorder) Create(image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImage)(nil).Create), image)
+}
+
+// Delete mocks base method.
+func (m *MockImage) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockImageMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockImage)(nil).Delete), id)
+}
+
+// FindAll mocks base method.
+func (m *MockImage) FindAll(offset, pageSize int, query string) ([]*entity.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", offset, pageSize, query)
+	ret0, _ := ret[0].([]*entity.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockImageMockRecorder) FindAll(offset, pageSize, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockImage)(nil).FindAll), offset, pageSize, query)
+}
+
+// FindByID mocks base method.
+func (m *MockImage) FindByID(id string) (*entity.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*entity.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockImageMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockImage)(nil).FindByID), id)
+}
+
+// FindByKey mocks base method.
+func (m *MockImage) FindByKey(key string) (*entity.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByKey", key)
+	ret0, _ := ret[0].(*entity.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByKey indicates an expected call of FindByKey.
+func (mr *MockImageMockRecorder) FindByKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByKey", reflect.TypeOf((*MockImage)(nil).FindByKey), key)
+}
+
+// ReplaceUsages mocks base method.
+func (m *MockImage) ReplaceUsages(imageID string, usages []*entity.ImageUsage, pageUsages []*entity.ImagePageUsage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceUsages", imageID, usages, pageUsages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceUsages indicates an expected call of ReplaceUsages.
+func (mr *MockImageMockRecorder) ReplaceUsages(imageID, usages, pageUsages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceUsages", reflect.TypeOf((*MockImage)(nil).ReplaceUsages), imageID, usages, pageUsages)
+}
+
+// Update mocks base method.
+func (m *MockImage) Update(image *entity.Image) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", image)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockImageMockRecorder) Update(image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockImage)(nil).Update), image)
+}
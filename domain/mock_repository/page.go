@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/page.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/masibw/blog-server/domain/entity"
+)
+
+// MockPage is a mock of Page interface.
+type MockPage struct {
+	ctrl     *gomock.Controller
+	recorder *MockPageMockRecorder
+}
+
+// MockPageMockRecorder is the mock recorder for MockPage.
+type MockPageMockRecorder struct {
+	mock *MockPage
+}
+
+// NewMockPage creates a new mock instance.
+func NewMockPage(ctrl *gomock.Controller) *MockPage {
+	mock := &MockPage{ctrl: ctrl}
+	mock.recorder = &MockPageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPage) EXPECT() *MockPageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPage) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPageMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPage)(nil).Delete), id)
+}
+
+// FindAll mocks base method.
+func (m *MockPage) FindAll(condition string, params []interface{}) ([]*entity.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", condition, params)
+	ret0, _ := ret[0].([]*entity.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPageMockRecorder) FindAll(condition, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPage)(nil).FindAll), condition, params)
+}
+
+// FindByID mocks base method.
+func (m *MockPage) FindByID(id string) (*entity.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*entity.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPageMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPage)(nil).FindByID), id)
+}
+
+// FindByPath mocks base method.
+func (m *MockPage) FindByPath(path string) (*entity.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPath", path)
+	ret0, _ := ret[0].(*entity.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPath indicates an expected call of FindByPath.
+func (mr *MockPageMockRecorder) FindByPath(path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPath", reflect.TypeOf((*MockPage)(nil).FindByPath), path)
+}
+
+// FindReferencing mocks base method.
+func (m *MockPage) FindReferencing(substrings []string) ([]*entity.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReferencing", substrings)
+	ret0, _ := ret[0].([]*entity.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReferencing indicates an expected call of FindReferencing.
+func (mr *MockPageMockRecorder) FindReferencing(substrings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReferencing", reflect.TypeOf((*MockPage)(nil).FindReferencing), substrings)
+}
+
+// Store mocks base method.
+func (m *MockPage) Store(page *entity.Page) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", page)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockPageMockRecorder) Store(page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockPage)(nil).Store), page)
+}
+
+// Update mocks base method.
+func (m *MockPage) Update(page *entity.Page) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", page)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPageMockRecorder) Update(page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPage)(nil).Update), page)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/permalink_redirect.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/masibw/blog-server/domain/entity"
+)
+
+// MockPermalinkRedirect is a mock of PermalinkRedirect interface.
+type MockPermalinkRedirect struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermalinkRedirectMockRecorder
+}
+
+// MockPermalinkRedirectMockRecorder is the mock recorder for MockPermalinkRedirect.
+type MockPermalinkRedirectMockRecorder struct {
+	mock *MockPermalinkRedirect
+}
+
+// NewMockPermalinkRedirect creates a new mock instance.
+func NewMockPermalinkRedirect(ctrl *gomock.Controller) *MockPermalinkRedirect {
+	mock := &MockPermalinkRedirect{ctrl: ctrl}
+	mock.recorder = &MockPermalinkRedirectMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermalinkRedirect) EXPECT() *MockPermalinkRedirectMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPermalinkRedirect) Create(redirect *entity.PermalinkRedirect) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", redirect)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPermalinkRedirectMockRecorder) Create(redirect interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPermalinkRedirect)(nil).Create), redirect)
+}
+
+// Delete mocks base method.
+func (m *MockPermalinkRedirect) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPermalinkRedirectMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPermalinkRedirect)(nil).Delete), id)
+}
+
+// FindByID mocks base method.
+func (m *MockPermalinkRedirect) FindByID(id string) (*entity.PermalinkRedirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*entity.PermalinkRedirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPermalinkRedirectMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPermalinkRedirect)(nil).FindByID), id)
+}
+
+// FindByPermalink mocks base method.
+func (m *MockPermalinkRedirect) FindByPermalink(permalink string) (*entity.PermalinkRedirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPermalink", permalink)
+	ret0, _ := ret[0].(*entity.PermalinkRedirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPermalink indicates an expected call of FindByPermalink.
+func (mr *MockPermalinkRedirectMockRecorder) FindByPermalink(permalink interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPermalink", reflect.TypeOf((*MockPermalinkRedirect)(nil).FindByPermalink), permalink)
+}
+
+// FindByPostID mocks base method.
+func (m *MockPermalinkRedirect) FindByPostID(postID string) ([]*entity.PermalinkRedirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPostID", postID)
+	ret0, _ := ret[0].([]*entity.PermalinkRedirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPostID indicates an expected call of FindByPostID.
+func (mr *MockPermalinkRedirectMockRecorder) FindByPostID(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPostID", reflect.TypeOf((*MockPermalinkRedirect)(nil).FindByPostID), postID)
+}
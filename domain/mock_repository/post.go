@@ -6,6 +6,7 @@ package mock_repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	entity "github.com/masibw/blog-server/domain/entity"
@@ -35,18 +36,63 @@ func (m *MockPost) EXPECT() *MockPostMockRecorder {
 }
 
 // Count mocks base method.
-func (m *MockPost) Count(condition string, params []interface{}) (int, error) {
+func (m *MockPost) Count(condition string, params []interface{}, tagFilter *entity.TagFilter) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count", condition, params)
+	ret := m.ctrl.Call(m, "Count", condition, params, tagFilter)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Count indicates an expected call of Count.
-func (mr *MockPostMockRecorder) Count(condition, params interface{}) *gomock.Call {
+func (mr *MockPostMockRecorder) Count(condition, params, tagFilter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockPost)(nil).Count), condition, params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockPost)(nil).Count), condition, params, tagFilter)
+}
+
+// CountByMonth mocks base method.
+func (m *MockPost) CountByMonth(condition string, params []interface{}) ([]*entity.MonthlyPostCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByMonth", condition, params)
+	ret0, _ := ret[0].([]*entity.MonthlyPostCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByMonth indicates an expected call of CountByMonth.
+func (mr *MockPostMockRecorder) CountByMonth(condition, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByMonth", reflect.TypeOf((*MockPost)(nil).CountByMonth), condition, params)
+}
+
+// CountSearch mocks base method.
+func (m *MockPost) CountSearch(query, condition string, params []interface{}, tagFilter *entity.TagFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSearch", query, condition, params, tagFilter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSearch indicates an expected call of CountSearch.
+func (mr *MockPostMockRecorder) CountSearch(query, condition, params, tagFilter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSearch", reflect.TypeOf((*MockPost)(nil).CountSearch), query, condition, params, tagFilter)
+}
+
+// CountSharedTags mocks base method.
+func (m *MockPost) CountSharedTags(postID string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSharedTags", postID)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSharedTags indicates an expected call of CountSharedTags.
+func (mr *MockPostMockRecorder) CountSharedTags(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSharedTags", reflect.TypeOf((*MockPost)(nil).CountSharedTags), postID)
 }
 
 // Create mocks base method.
@@ -78,18 +124,18 @@ func (mr *MockPostMockRecorder) Delete(id interface{}) *gomock.Call {
 }
 
 // FindAll mocks base method.
-func (m *MockPost) FindAll(offset, pageSize int, condition string, params []interface{}, sortCondition string) ([]*entity.Post, error) {
+func (m *MockPost) FindAll(offset, pageSize int, condition string, params []interface{}, tagFilter *entity.TagFilter, sortCondition string, fields []string) ([]*entity.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", offset, pageSize, condition, params, sortCondition)
+	ret := m.ctrl.Call(m, "FindAll", offset, pageSize, condition, params, tagFilter, sortCondition, fields)
 	ret0, _ := ret[0].([]*entity.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockPostMockRecorder) FindAll(offset, pageSize, condition, params, sortCondition interface{}) *gomock.Call {
+func (mr *MockPostMockRecorder) FindAll(offset, pageSize, condition, params, tagFilter, sortCondition, fields interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPost)(nil).FindAll), offset, pageSize, condition, params, sortCondition)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPost)(nil).FindAll), offset, pageSize, condition, params, tagFilter, sortCondition, fields)
 }
 
 // FindByID mocks base method.
@@ -122,6 +168,95 @@ func (mr *MockPostMockRecorder) FindByPermalink(permalink interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPermalink", reflect.TypeOf((*MockPost)(nil).FindByPermalink), permalink)
 }
 
+// FindDeleted mocks base method.
+func (m *MockPost) FindDeleted() ([]*entity.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeleted")
+	ret0, _ := ret[0].([]*entity.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeleted indicates an expected call of FindDeleted.
+func (mr *MockPostMockRecorder) FindDeleted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeleted", reflect.TypeOf((*MockPost)(nil).FindDeleted))
+}
+
+// FindDeletedByID mocks base method.
+func (m *MockPost) FindDeletedByID(id string) (*entity.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByID", id)
+	ret0, _ := ret[0].(*entity.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByID indicates an expected call of FindDeletedByID.
+func (mr *MockPostMockRecorder) FindDeletedByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByID", reflect.TypeOf((*MockPost)(nil).FindDeletedByID), id)
+}
+
+// FindReferencing mocks base method.
+func (m *MockPost) FindReferencing(substrings []string) ([]*entity.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReferencing", substrings)
+	ret0, _ := ret[0].([]*entity.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReferencing indicates an expected call of FindReferencing.
+func (mr *MockPostMockRecorder) FindReferencing(substrings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReferencing", reflect.TypeOf((*MockPost)(nil).FindReferencing), substrings)
+}
+
+// Purge mocks base method.
+func (m *MockPost) Purge(before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockPostMockRecorder) Purge(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockPost)(nil).Purge), before)
+}
+
+// Restore mocks base method.
+func (m *MockPost) Restore(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockPostMockRecorder) Restore(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockPost)(nil).Restore), id)
+}
+
+// Search mocks base method.
+func (m *MockPost) Search(query string, offset, pageSize int, condition string, params []interface{}, tagFilter *entity.TagFilter) ([]*entity.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query, offset, pageSize, condition, params, tagFilter)
+	ret0, _ := ret[0].([]*entity.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockPostMockRecorder) Search(query, offset, pageSize, condition, params, tagFilter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPost)(nil).Search), query, offset, pageSize, condition, params, tagFilter)
+}
+
 // Update mocks base method.
 func (m *MockPost) Update(post *entity.Post) error {
 	m.ctrl.T.Helper()
@@ -135,3 +270,31 @@ func (mr *MockPostMockRecorder) Update(post interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPost)(nil).Update), post)
 }
+
+// UpdatePin mocks base method.
+func (m *MockPost) UpdatePin(id string, isPinned bool, featuredRank int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePin", id, isPinned, featuredRank)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePin indicates an expected call of UpdatePin.
+func (mr *MockPostMockRecorder) UpdatePin(id, isPinned, featuredRank interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePin", reflect.TypeOf((*MockPost)(nil).UpdatePin), id, isPinned, featuredRank)
+}
+
+// UpdateWithHistory mocks base method.
+func (m *MockPost) UpdateWithHistory(post *entity.Post, revision *entity.PostRevision, redirect, reclaimedRedirect *entity.PermalinkRedirect) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithHistory", post, revision, redirect, reclaimedRedirect)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWithHistory indicates an expected call of UpdateWithHistory.
+func (mr *MockPostMockRecorder) UpdateWithHistory(post, revision, redirect, reclaimedRedirect interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithHistory", reflect.TypeOf((*MockPost)(nil).UpdateWithHistory), post, revision, redirect, reclaimedRedirect)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/post_revision.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/masibw/blog-server/domain/entity"
+)
+
+// MockPostRevision is a mock of PostRevision interface.
+type MockPostRevision struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostRevisionMockRecorder
+}
+
+// MockPostRevisionMockRecorder is the mock recorder for MockPostRevision.
+type MockPostRevisionMockRecorder struct {
+	mock *MockPostRevision
+}
+
+// NewMockPostRevision creates a new mock instance.
+func NewMockPostRevision(ctrl *gomock.Controller) *MockPostRevision {
+	mock := &MockPostRevision{ctrl: ctrl}
+	mock.recorder = &MockPostRevisionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostRevision) EXPECT() *MockPostRevisionMockRecorder {
+	return m.recorder
+}
+
+// CountByPostID mocks base method.
+func (m *MockPostRevision) CountByPostID(postID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByPostID", postID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByPostID indicates an expected call of CountByPostID.
+func (mr *MockPostRevisionMockRecorder) CountByPostID(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByPostID", reflect.TypeOf((*MockPostRevision)(nil).CountByPostID), postID)
+}
+
+// Create mocks base method.
+func (m *MockPostRevision) Create(revision *entity.PostRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPostRevisionMockRecorder) Create(revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPostRevision)(nil).Create), revision)
+}
+
+// FindAll mocks base method.
+func (m *MockPostRevision) FindAll() ([]*entity.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].([]*entity.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPostRevisionMockRecorder) FindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPostRevision)(nil).FindAll))
+}
+
+// FindByID mocks base method.
+func (m *MockPostRevision) FindByID(id string) (*entity.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*entity.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPostRevisionMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPostRevision)(nil).FindByID), id)
+}
+
+// FindByPostID mocks base method.
+func (m *MockPostRevision) FindByPostID(postID string, offset, pageSize int) ([]*entity.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPostID", postID, offset, pageSize)
+	ret0, _ := ret[0].([]*entity.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPostID indicates an expected call of FindByPostID.
+func (mr *MockPostRevisionMockRecorder) FindByPostID(postID, offset, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPostID", reflect.TypeOf((*MockPostRevision)(nil).FindByPostID), postID, offset, pageSize)
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/preview_token.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/masibw/blog-server/domain/entity"
+)
+
+// MockPreviewToken is a mock of PreviewToken interface.
+type MockPreviewToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreviewTokenMockRecorder
+}
+
+// MockPreviewTokenMockRecorder is the mock recorder for MockPreviewToken.
+type MockPreviewTokenMockRecorder struct {
+	mock *MockPreviewToken
+}
+
+// NewMockPreviewToken creates a new mock instance.
+func NewMockPreviewToken(ctrl *gomock.Controller) *MockPreviewToken {
+	mock := &MockPreviewToken{ctrl: ctrl}
+	mock.recorder = &MockPreviewTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreviewToken) EXPECT() *MockPreviewTokenMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPreviewToken) Create(previewToken *entity.PreviewToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", previewToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPreviewTokenMockRecorder) Create(previewToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPreviewToken)(nil).Create), previewToken)
+}
+
+// Delete mocks base method.
+func (m *MockPreviewToken) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPreviewTokenMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPreviewToken)(nil).Delete), id)
+}
+
+// FindByID mocks base method.
+func (m *MockPreviewToken) FindByID(id string) (*entity.PreviewToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*entity.PreviewToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPreviewTokenMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPreviewToken)(nil).FindByID), id)
+}
+
+// FindByPostID mocks base method.
+func (m *MockPreviewToken) FindByPostID(postID string) ([]*entity.PreviewToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPostID", postID)
+	ret0, _ := ret[0].([]*entity.PreviewToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPostID indicates an expected call of FindByPostID.
+func (mr *MockPreviewTokenMockRecorder) FindByPostID(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPostID", reflect.TypeOf((*MockPreviewToken)(nil).FindByPostID), postID)
+}
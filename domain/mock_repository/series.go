@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/series.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/masibw/blog-server/domain/entity"
+)
+
+// MockSeries is a mock of Series interface.
+type MockSeries struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesMockRecorder
+}
+
+// MockSeriesMockRecorder is the mock recorder for MockSeries.
+type MockSeriesMockRecorder struct {
+	mock *MockSeries
+}
+
+// NewMockSeries creates a new mock instance.
+func NewMockSeries(ctrl *gomock.Controller) *MockSeries {
+	mock := &MockSeries{ctrl: ctrl}
+	mock.recorder = &MockSeriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeries) EXPECT() *MockSeriesMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSeries) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSeriesMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSeries)(nil).Delete), id)
+}
+
+// FindAll mocks base method.
+func (m *MockSeries) FindAll() ([]*entity.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].([]*entity.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSeriesMockRecorder) FindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSeries)(nil).FindAll))
+}
+
+// FindByID mocks base method.
+func (m *MockSeries) FindByID(id string) (*entity.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*entity.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockSeriesMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSeries)(nil).FindByID), id)
+}
+
+// Store mocks base method.
+func (m *MockSeries) Store(series *entity.Series) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", series)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockSeriesMockRecorder) Store(series interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockSeries)(nil).Store), series)
+}
+
+// Update mocks base method.
+func (m *MockSeries) Update(series *entity.Series) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", series)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeriesMockRecorder) Update(series interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeries)(nil).Update), series)
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/repository/series_post.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/masibw/blog-server/domain/entity"
+)
+
+// MockSeriesPost is a mock of SeriesPost interface.
+type MockSeriesPost struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesPostMockRecorder
+}
+
+// MockSeriesPostMockRecorder is the mock recorder for MockSeriesPost.
+type MockSeriesPostMockRecorder struct {
+	mock *MockSeriesPost
+}
+
+// NewMockSeriesPost creates a new mock instance.
+func NewMockSeriesPost(ctrl *gomock.Controller) *MockSeriesPost {
+	mock := &MockSeriesPost{ctrl: ctrl}
+	mock.recorder = &MockSeriesPostMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesPost) EXPECT() *MockSeriesPostMockRecorder {
+	return m.recorder
+}
+
+// FindByPostID mocks base method.
+func (m *MockSeriesPost) FindByPostID(postID string) (*entity.SeriesPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPostID", postID)
+	ret0, _ := ret[0].(*entity.SeriesPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPostID indicates an expected call of FindByPostID.
+func (mr *MockSeriesPostMockRecorder) FindByPostID(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPostID", reflect.TypeOf((*MockSeriesPost)(nil).FindByPostID), postID)
+}
+
+// FindBySeriesID mocks base method.
+func (m *MockSeriesPost) FindBySeriesID(seriesID string) ([]*entity.SeriesPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySeriesID", seriesID)
+	ret0, _ := ret[0].([]*entity.SeriesPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySeriesID indicates an expected call of FindBySeriesID.
+func (mr *MockSeriesPostMockRecorder) FindBySeriesID(seriesID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySeriesID", reflect.TypeOf((*MockSeriesPost)(nil).FindBySeriesID), seriesID)
+}
+
+// ReplaceBySeriesID mocks base method.
+func (m *MockSeriesPost) ReplaceBySeriesID(seriesID string, seriesPosts []*entity.SeriesPost) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceBySeriesID", seriesID, seriesPosts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceBySeriesID indicates an expected call of ReplaceBySeriesID.
+func (mr *MockSeriesPostMockRecorder) ReplaceBySeriesID(seriesID, seriesPosts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceBySeriesID", reflect.TypeOf((*MockSeriesPost)(nil).ReplaceBySeriesID), seriesID, seriesPosts)
+}
+
+// Store mocks base method.
+func (m *MockSeriesPost) Store(seriesPosts []*entity.SeriesPost) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", seriesPosts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockSeriesPostMockRecorder) Store(seriesPosts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockSeriesPost)(nil).Store), seriesPosts)
+}
@@ -6,6 +6,7 @@ package mock_repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	entity "github.com/masibw/blog-server/domain/entity"
@@ -64,18 +65,18 @@ func (mr *MockTagMockRecorder) Delete(id interface{}) *gomock.Call {
 }
 
 // FindAll mocks base method.
-func (m *MockTag) FindAll(offset, pageSize int, condition string, params []interface{}) ([]*entity.Tag, error) {
+func (m *MockTag) FindAll(offset, pageSize int, condition string, params []interface{}, sortCondition string) ([]*entity.Tag, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", offset, pageSize, condition, params)
+	ret := m.ctrl.Call(m, "FindAll", offset, pageSize, condition, params, sortCondition)
 	ret0, _ := ret[0].([]*entity.Tag)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockTagMockRecorder) FindAll(offset, pageSize, condition, params interface{}) *gomock.Call {
+func (mr *MockTagMockRecorder) FindAll(offset, pageSize, condition, params, sortCondition interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTag)(nil).FindAll), offset, pageSize, condition, params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTag)(nil).FindAll), offset, pageSize, condition, params, sortCondition)
 }
 
 // FindByID mocks base method.
@@ -108,6 +109,94 @@ func (mr *MockTagMockRecorder) FindByName(name interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockTag)(nil).FindByName), name)
 }
 
+// FindByPostIDs mocks base method.
+func (m *MockTag) FindByPostIDs(postIDs []string) (map[string][]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPostIDs", postIDs)
+	ret0, _ := ret[0].(map[string][]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPostIDs indicates an expected call of FindByPostIDs.
+func (mr *MockTagMockRecorder) FindByPostIDs(postIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPostIDs", reflect.TypeOf((*MockTag)(nil).FindByPostIDs), postIDs)
+}
+
+// FindDeleted mocks base method.
+func (m *MockTag) FindDeleted() ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeleted")
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeleted indicates an expected call of FindDeleted.
+func (mr *MockTagMockRecorder) FindDeleted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeleted", reflect.TypeOf((*MockTag)(nil).FindDeleted))
+}
+
+// FindDeletedByName mocks base method.
+func (m *MockTag) FindDeletedByName(name string) (*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByName", name)
+	ret0, _ := ret[0].(*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByName indicates an expected call of FindDeletedByName.
+func (mr *MockTagMockRecorder) FindDeletedByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByName", reflect.TypeOf((*MockTag)(nil).FindDeletedByName), name)
+}
+
+// Merge mocks base method.
+func (m *MockTag) Merge(sourceID, targetID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", sourceID, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockTagMockRecorder) Merge(sourceID, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockTag)(nil).Merge), sourceID, targetID)
+}
+
+// Purge mocks base method.
+func (m *MockTag) Purge(before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTagMockRecorder) Purge(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTag)(nil).Purge), before)
+}
+
+// Restore mocks base method.
+func (m *MockTag) Restore(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTagMockRecorder) Restore(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTag)(nil).Restore), id)
+}
+
 // Store mocks base method.
 func (m *MockTag) Store(tag *entity.Tag) error {
 	m.ctrl.T.Helper()
@@ -121,3 +210,17 @@ func (mr *MockTagMockRecorder) Store(tag interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockTag)(nil).Store), tag)
 }
+
+// Update mocks base method.
+func (m *MockTag) Update(tag *entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTagMockRecorder) Update(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTag)(nil).Update), tag)
+}
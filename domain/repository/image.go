@@ -0,0 +1,14 @@
+package repository
+
+import "github.com/masibw/blog-server/domain/entity"
+
+type Image interface {
+	FindByID(id string) (*entity.Image, error)
+	FindByKey(key string) (*entity.Image, error)
+	FindAll(offset, pageSize int, query string) ([]*entity.Image, error)
+	Count(query string) (int, error)
+	Create(image *entity.Image) error
+	Update(image *entity.Image) error
+	Delete(id string) error
+	ReplaceUsages(imageID string, usages []*entity.ImageUsage, pageUsages []*entity.ImagePageUsage) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"io"
+	"time"
+
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+// ImageStorage は画像ファイルの保存先です
+type ImageStorage interface {
+	// CreatePresignedURL は key に contentType のファイルを expires の間だけPUTできる署名付きURLを返します
+	CreatePresignedURL(key, contentType string, expires time.Duration) (string, error)
+	Put(key, contentType string, body io.Reader) error
+	// Open は key のファイルを読みます．ファイルが無い場合は entity.ErrImageObjectNotFound を返します
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	// List は保存しているすべてのファイルを返します
+	List() ([]*entity.StoredImage, error)
+	// URL は key のファイルを公開しているURLを返します
+	URL(key string) string
+}
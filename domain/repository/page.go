@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/masibw/blog-server/domain/entity"
+
+type Page interface {
+	FindByID(id string) (*entity.Page, error)
+	FindByPath(path string) (*entity.Page, error)
+	FindAll(condition string, params []interface{}) ([]*entity.Page, error)
+	Store(page *entity.Page) error
+	Update(page *entity.Page) error
+	Delete(id string) error
+	FindReferencing(substrings []string) ([]*entity.Page, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/masibw/blog-server/domain/entity"
+
+type PermalinkRedirect interface {
+	FindByID(id string) (*entity.PermalinkRedirect, error)
+	FindByPermalink(permalink string) (*entity.PermalinkRedirect, error)
+	FindByPostID(postID string) ([]*entity.PermalinkRedirect, error)
+	Create(redirect *entity.PermalinkRedirect) error
+	Delete(id string) error
+}
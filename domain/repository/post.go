@@ -1,13 +1,28 @@
 package repository
 
-import "github.com/masibw/blog-server/domain/entity"
+import (
+	"time"
+
+	"github.com/masibw/blog-server/domain/entity"
+)
 
 type Post interface {
 	FindByID(id string) (*entity.Post, error)
-	FindAll(offset, pageSize int, condition string, params []interface{}, sortCondition string) ([]*entity.Post, error)
+	FindAll(offset, pageSize int, condition string, params []interface{}, tagFilter *entity.TagFilter, sortCondition string, fields []string) ([]*entity.Post, error)
 	FindByPermalink(permalink string) (*entity.Post, error)
 	Create(post *entity.Post) error
 	Update(post *entity.Post) error
+	UpdateWithHistory(post *entity.Post, revision *entity.PostRevision, redirect, reclaimedRedirect *entity.PermalinkRedirect) error
+	UpdatePin(id string, isPinned bool, featuredRank int) error
 	Delete(id string) error
-	Count(condition string, params []interface{}) (int, error)
+	Count(condition string, params []interface{}, tagFilter *entity.TagFilter) (int, error)
+	Search(query string, offset, pageSize int, condition string, params []interface{}, tagFilter *entity.TagFilter) ([]*entity.Post, error)
+	CountSearch(query string, condition string, params []interface{}, tagFilter *entity.TagFilter) (int, error)
+	CountByMonth(condition string, params []interface{}) ([]*entity.MonthlyPostCount, error)
+	CountSharedTags(postID string) (map[string]int, error)
+	FindDeleted() ([]*entity.Post, error)
+	FindDeletedByID(id string) (*entity.Post, error)
+	Restore(id string) error
+	Purge(before time.Time) (int, error)
+	FindReferencing(substrings []string) ([]*entity.Post, error)
 }
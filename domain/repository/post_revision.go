@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/masibw/blog-server/domain/entity"
+
+type PostRevision interface {
+	FindByID(id string) (*entity.PostRevision, error)
+	FindByPostID(postID string, offset, pageSize int) ([]*entity.PostRevision, error)
+	FindAll() ([]*entity.PostRevision, error)
+	Create(revision *entity.PostRevision) error
+	CountByPostID(postID string) (int, error)
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "github.com/masibw/blog-server/domain/entity"
+
+type PreviewToken interface {
+	FindByID(id string) (*entity.PreviewToken, error)
+	FindByPostID(postID string) ([]*entity.PreviewToken, error)
+	Create(previewToken *entity.PreviewToken) error
+	Delete(id string) error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/masibw/blog-server/domain/entity"
+
+type Series interface {
+	FindByID(id string) (*entity.Series, error)
+	FindAll() ([]*entity.Series, error)
+	Store(series *entity.Series) error
+	Update(series *entity.Series) error
+	Delete(id string) error
+}
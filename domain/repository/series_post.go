@@ -0,0 +1,10 @@
+package repository
+
+import "github.com/masibw/blog-server/domain/entity"
+
+type SeriesPost interface {
+	FindBySeriesID(seriesID string) ([]*entity.SeriesPost, error)
+	FindByPostID(postID string) (*entity.SeriesPost, error)
+	Store(seriesPosts []*entity.SeriesPost) error
+	ReplaceBySeriesID(seriesID string, seriesPosts []*entity.SeriesPost) error
+}
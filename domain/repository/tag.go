@@ -1,12 +1,23 @@
 package repository
 
-import "github.com/masibw/blog-server/domain/entity"
+import (
+	"time"
+
+	"github.com/masibw/blog-server/domain/entity"
+)
 
 type Tag interface {
 	FindByID(id string) (*entity.Tag, error)
-	FindAll(offset, pageSize int, condition string, params []interface{}) ([]*entity.Tag, error)
+	FindAll(offset, pageSize int, condition string, params []interface{}, sortCondition string) ([]*entity.Tag, error)
 	FindByName(name string) (*entity.Tag, error)
+	FindByPostIDs(postIDs []string) (map[string][]*entity.Tag, error)
 	Store(tag *entity.Tag) error
+	Update(tag *entity.Tag) error
+	Merge(sourceID, targetID string) error
 	Delete(id string) error
 	Count() (int, error)
+	FindDeleted() ([]*entity.Tag, error)
+	FindDeletedByName(name string) (*entity.Tag, error)
+	Restore(id string) error
+	Purge(before time.Time) (int, error)
 }
@@ -74,6 +74,21 @@ func (p *PostsTagsService) getTagEntity(postID, tagName string) (postsTags *enti
 		err = fmt.Errorf("getTagEntity() store tag name=%v: %w", tagName, err)
 		return
 	}
+	// ゴミ箱にある同じ名前のタグは一意制約により作り直せないため元に戻して使う
+	if errors.Is(err, entity.ErrTagNotFound) {
+		tag, err = p.tagRepository.FindDeletedByName(tagName)
+		if err != nil && !errors.Is(err, entity.ErrTagNotFound) {
+			err = fmt.Errorf("getTagEntity() find deleted tag name=%v: %w", tagName, err)
+			return
+		}
+		if tag != nil {
+			if err = p.tagRepository.Restore(tag.ID); err != nil {
+				err = fmt.Errorf("getTagEntity() restore tag name=%v: %w", tagName, err)
+				return
+			}
+			tag.DeletedAt.Valid = false
+		}
+	}
 	// タグが存在しなければ作成する
 	if errors.Is(err, entity.ErrTagNotFound) {
 		tag = entity.NewTag(tagName)
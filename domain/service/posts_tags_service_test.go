@@ -65,6 +65,7 @@ func TestLinkPostTags(t *testing.T) { // nolint:gocognit
 				mockPosts.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{}, nil)
 				mockPT.EXPECT().DeleteByPostID(gomock.Any()).Return(nil)
 				mockTags.EXPECT().FindByName("a").Return(nil, entity.ErrTagNotFound)
+				mockTags.EXPECT().FindDeletedByName("a").Return(nil, entity.ErrTagNotFound)
 				mockTags.EXPECT().Store(gomock.Any()).Return(nil)
 				mockTags.EXPECT().FindByName("b").Return(&entity.Tag{
 					ID:        "abcdefghijklmnopqrstuvwxy3",
@@ -77,6 +78,26 @@ func TestLinkPostTags(t *testing.T) { // nolint:gocognit
 			wantTagsLen: 2,
 			wantErr:     nil,
 		},
+		{
+			name:     "ゴミ箱に同名のタグがあれば元に戻して投稿とタグの関連を保存する",
+			postID:   "abcdefghijklmnopqrstuvwxy1",
+			tagNames: []string{"a"},
+			prepareMockTagRepoFn: func(mockTags *mock_repository.MockTag, mockPosts *mock_repository.MockPost, mockPT *mock_repository.MockPostsTags) {
+				mockPosts.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{}, nil)
+				mockPT.EXPECT().DeleteByPostID(gomock.Any()).Return(nil)
+				mockTags.EXPECT().FindByName("a").Return(nil, entity.ErrTagNotFound)
+				mockTags.EXPECT().FindDeletedByName("a").Return(&entity.Tag{
+					ID:        "abcdefghijklmnopqrstuvwxy2",
+					Name:      "a",
+					CreatedAt: flextime.Now(),
+					UpdatedAt: flextime.Now(),
+				}, nil)
+				mockTags.EXPECT().Restore("abcdefghijklmnopqrstuvwxy2").Return(nil)
+				mockPT.EXPECT().Store(gomock.AssignableToTypeOf([]*entity.PostsTags{})).Return(nil)
+			},
+			wantTagsLen: 1,
+			wantErr:     nil,
+		},
 		{
 			name:     "重複したタグがあればUniqueな分のみ作成される",
 			postID:   "abcdefghijklmnopqrstuvwxy1",
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/repository"
+)
+
+type SeriesPostsService struct {
+	seriesPostRepository repository.SeriesPost
+	seriesRepository     repository.Series
+	postRepository       repository.Post
+}
+
+func NewSeriesPostsService(seriesPostRepository repository.SeriesPost, seriesRepository repository.Series, postRepository repository.Post) *SeriesPostsService {
+	return &SeriesPostsService{
+		seriesPostRepository: seriesPostRepository,
+		seriesRepository:     seriesRepository,
+		postRepository:       postRepository,
+	}
+}
+
+// LinkSeriesPosts は連載に含まれる投稿を postIDs の順番で置き換えます
+// 別の連載に含まれている投稿は指定できません
+func (s *SeriesPostsService) LinkSeriesPosts(seriesID string, postIDs []string) ([]*entity.SeriesPost, error) {
+	// 実際に連載が存在するかのチェックであり結果は使わない
+	_, err := s.seriesRepository.FindByID(seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("LinkSeriesPosts() get series: %w", err)
+	}
+
+	// 投稿の重複を削除する
+	m := make(map[string]bool)
+	var uniqPostIDs []string
+	for _, postID := range postIDs {
+		if !m[postID] {
+			m[postID] = true
+			uniqPostIDs = append(uniqPostIDs, postID)
+		}
+	}
+
+	seriesPosts := make([]*entity.SeriesPost, 0, len(uniqPostIDs))
+	for i, postID := range uniqPostIDs {
+		if _, err = s.postRepository.FindByID(postID); err != nil {
+			return nil, fmt.Errorf("LinkSeriesPosts() get post id=%v: %w", postID, err)
+		}
+		var seriesPost *entity.SeriesPost
+		seriesPost, err = s.seriesPostRepository.FindByPostID(postID)
+		if err != nil && !errors.Is(err, entity.ErrSeriesPostNotFound) {
+			return nil, fmt.Errorf("LinkSeriesPosts() get series post post id=%v: %w", postID, err)
+		}
+		if seriesPost != nil && seriesPost.SeriesID != seriesID {
+			return nil, fmt.Errorf("LinkSeriesPosts() post id=%v series id=%v: %w", postID, seriesPost.SeriesID, entity.ErrSeriesPostAlreadyExisted)
+		}
+		seriesPosts = append(seriesPosts, entity.NewSeriesPost(seriesID, postID, i+1))
+	}
+
+	err = s.seriesPostRepository.ReplaceBySeriesID(seriesID, seriesPosts)
+	if err != nil {
+		return nil, fmt.Errorf("LinkSeriesPosts() replace series posts series id=%v post ids=%v: %w", seriesID, postIDs, err)
+	}
+	return seriesPosts, nil
+}
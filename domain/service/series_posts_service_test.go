@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+)
+
+func TestLinkSeriesPosts(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	seriesID := "abcdefghijklmnopqrstuvwxy0"
+	tests := []struct {
+		name          string
+		postIDs       []string
+		prepareMockFn func(mockSP *mock_repository.MockSeriesPost, mockSeries *mock_repository.MockSeries, mockPosts *mock_repository.MockPost)
+		wantPositions map[string]int
+		wantErr       error
+	}{
+		{
+			name:    "指定した順番で連載に投稿を含められる",
+			postIDs: []string{"abcdefghijklmnopqrstuvwxy2", "abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy2"},
+			prepareMockFn: func(mockSP *mock_repository.MockSeriesPost, mockSeries *mock_repository.MockSeries, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID}, nil)
+				mockPosts.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{}, nil).Times(2)
+				mockSP.EXPECT().FindByPostID("abcdefghijklmnopqrstuvwxy2").Return(&entity.SeriesPost{SeriesID: seriesID}, nil)
+				mockSP.EXPECT().FindByPostID("abcdefghijklmnopqrstuvwxy1").Return(nil, entity.ErrSeriesPostNotFound)
+				mockSP.EXPECT().ReplaceBySeriesID(seriesID, gomock.Len(2)).Return(nil)
+			},
+			wantPositions: map[string]int{"abcdefghijklmnopqrstuvwxy2": 1, "abcdefghijklmnopqrstuvwxy1": 2},
+			wantErr:       nil,
+		},
+		{
+			name:    "空で指定した場合は連載から全ての投稿を外す",
+			postIDs: []string{},
+			prepareMockFn: func(mockSP *mock_repository.MockSeriesPost, mockSeries *mock_repository.MockSeries, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID}, nil)
+				mockSP.EXPECT().ReplaceBySeriesID(seriesID, gomock.Len(0)).Return(nil)
+			},
+			wantPositions: map[string]int{},
+			wantErr:       nil,
+		},
+		{
+			name:    "別の連載に含まれている投稿を指定した場合はErrSeriesPostAlreadyExistedを返す",
+			postIDs: []string{"abcdefghijklmnopqrstuvwxy1"},
+			prepareMockFn: func(mockSP *mock_repository.MockSeriesPost, mockSeries *mock_repository.MockSeries, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID}, nil)
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.Post{}, nil)
+				mockSP.EXPECT().FindByPostID("abcdefghijklmnopqrstuvwxy1").Return(&entity.SeriesPost{SeriesID: "abcdefghijklmnopqrstuvwxy9"}, nil)
+			},
+			wantPositions: map[string]int{},
+			wantErr:       entity.ErrSeriesPostAlreadyExisted,
+		},
+		{
+			name:    "置き換えに失敗した場合はエラーを返す",
+			postIDs: []string{"abcdefghijklmnopqrstuvwxy1"},
+			prepareMockFn: func(mockSP *mock_repository.MockSeriesPost, mockSeries *mock_repository.MockSeries, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID}, nil)
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.Post{}, nil)
+				mockSP.EXPECT().FindByPostID("abcdefghijklmnopqrstuvwxy1").Return(nil, entity.ErrSeriesPostNotFound)
+				mockSP.EXPECT().ReplaceBySeriesID(seriesID, gomock.Len(1)).Return(entity.ErrSeriesPostAlreadyExisted)
+			},
+			wantPositions: map[string]int{},
+			wantErr:       entity.ErrSeriesPostAlreadyExisted,
+		},
+		{
+			name:    "存在しない連載を指定した場合はErrSeriesNotFoundを返す",
+			postIDs: []string{"abcdefghijklmnopqrstuvwxy1"},
+			prepareMockFn: func(mockSP *mock_repository.MockSeriesPost, mockSeries *mock_repository.MockSeries, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(nil, entity.ErrSeriesNotFound)
+			},
+			wantPositions: map[string]int{},
+			wantErr:       entity.ErrSeriesNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mSP := mock_repository.NewMockSeriesPost(ctrl)
+			mS := mock_repository.NewMockSeries(ctrl)
+			mP := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockFn(mSP, mS, mP)
+
+			s := &SeriesPostsService{
+				seriesPostRepository: mSP,
+				seriesRepository:     mS,
+				postRepository:       mP,
+			}
+
+			got, err := s.LinkSeriesPosts(seriesID, tt.postIDs)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("LinkSeriesPosts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			gotPositions := make(map[string]int, len(got))
+			for _, seriesPost := range got {
+				gotPositions[seriesPost.PostID] = seriesPost.Position
+			}
+			if diff := cmp.Diff(tt.wantPositions, gotPositions); diff != "" {
+				t.Errorf("LinkSeriesPosts() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
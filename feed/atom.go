@@ -0,0 +1,86 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/dto"
+)
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+// Atom は Atom のフィードです https://tools.ietf.org/html/rfc4287
+type Atom struct {
+	XMLName xml.Name     `xml:"feed"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Title   string       `xml:"title"`
+	ID      string       `xml:"id"`
+	Updated string       `xml:"updated"`
+	Author  *AtomAuthor  `xml:"author"`
+	Links   []*AtomLink  `xml:"link"`
+	Entries []*AtomEntry `xml:"entry"`
+}
+
+// AtomAuthor はフィードの著者です．エントリーに著者が無い場合はフィードの著者が使われます
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type AtomEntry struct {
+	Title     string       `xml:"title"`
+	ID        string       `xml:"id"`
+	Link      *AtomLink    `xml:"link"`
+	Published string       `xml:"published"`
+	Updated   string       `xml:"updated"`
+	Content   *AtomContent `xml:"content"`
+}
+
+type AtomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// NewAtom はHTMLに変換済みの投稿からAtomフィードを作成します
+// updated は必須なので，投稿が無い場合は現在の日時にします
+func NewAtom(site *Site, feedURL string, posts []*dto.PostDTO) *Atom {
+	updated := LastModified(posts)
+	if updated.IsZero() {
+		updated = flextime.Now()
+	}
+	atom := &Atom{
+		Xmlns:   atomNamespace,
+		Title:   site.Title,
+		ID:      feedURL,
+		Updated: updated.Format(time.RFC3339),
+		Author:  &AtomAuthor{Name: site.Author},
+		Links: []*AtomLink{
+			{Href: feedURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: site.URL, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]*AtomEntry, 0, len(posts)),
+	}
+
+	for _, post := range posts {
+		postURL := site.PostURL(post.Permalink)
+		atom.Entries = append(atom.Entries, &AtomEntry{
+			Title:     post.Title,
+			ID:        postURL,
+			Link:      &AtomLink{Href: postURL, Rel: "alternate", Type: "text/html"},
+			Published: post.PublishedAt.Format(time.RFC3339),
+			Updated:   post.UpdatedAt.Format(time.RFC3339),
+			Content: &AtomContent{
+				Type:  "html",
+				Value: post.Content,
+			},
+		})
+	}
+
+	return atom
+}
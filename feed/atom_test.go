@@ -0,0 +1,73 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/dto"
+)
+
+func TestNewAtom(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	site := NewSite("mesimasi.com", "description", "https://mesimasi.com", "masibw")
+
+	posts := []*dto.PostDTO{
+		{
+			ID:          "abcdefghijklmnopqrstuvwxyz",
+			Title:       "new_post",
+			Content:     "<p>new_content</p>\n",
+			Permalink:   "new_permalink",
+			UpdatedAt:   time.Date(2021, 1, 23, 0, 0, 0, 0, loc),
+			PublishedAt: time.Date(2021, 1, 22, 0, 0, 0, 0, loc),
+		},
+	}
+	want := &Atom{
+		Xmlns:   atomNamespace,
+		Title:   "mesimasi.com",
+		ID:      "https://mesimasi.com/atom.xml",
+		Updated: "2021-01-23T00:00:00+09:00",
+		Author:  &AtomAuthor{Name: "masibw"},
+		Links: []*AtomLink{
+			{Href: "https://mesimasi.com/atom.xml", Rel: "self", Type: "application/atom+xml"},
+			{Href: "https://mesimasi.com", Rel: "alternate", Type: "text/html"},
+		},
+		Entries: []*AtomEntry{
+			{
+				Title:     "new_post",
+				ID:        "https://mesimasi.com/posts/new_permalink",
+				Link:      &AtomLink{Href: "https://mesimasi.com/posts/new_permalink", Rel: "alternate", Type: "text/html"},
+				Published: "2021-01-22T00:00:00+09:00",
+				Updated:   "2021-01-23T00:00:00+09:00",
+				Content:   &AtomContent{Type: "html", Value: "<p>new_content</p>\n"},
+			},
+		},
+	}
+
+	got := NewAtom(site, "https://mesimasi.com/atom.xml", posts)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewAtom() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewAtom_NoPosts(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+	site := NewSite("mesimasi.com", "description", "https://mesimasi.com", "masibw")
+
+	got := NewAtom(site, "https://mesimasi.com/atom.xml", nil)
+	if want := "2021-01-22T00:00:00+09:00"; got.Updated != want {
+		t.Errorf("NewAtom() Updated = %v, want %v", got.Updated, want)
+	}
+	if len(got.Entries) != 0 {
+		t.Errorf("NewAtom() Entries = %v, want empty", got.Entries)
+	}
+}
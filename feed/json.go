@@ -0,0 +1,55 @@
+package feed
+
+import (
+	"time"
+
+	"github.com/masibw/blog-server/domain/dto"
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+// JSONFeed は JSON Feed 1.1 のフィードです https://www.jsonfeed.org/version/1.1/
+type JSONFeed struct {
+	Version     string          `json:"version"`
+	Title       string          `json:"title"`
+	HomePageURL string          `json:"home_page_url"`
+	FeedURL     string          `json:"feed_url"`
+	Description string          `json:"description,omitempty"`
+	Items       []*JSONFeedItem `json:"items"`
+}
+
+type JSONFeedItem struct {
+	ID            string `json:"id"`
+	URL           string `json:"url"`
+	Title         string `json:"title"`
+	ContentHTML   string `json:"content_html"`
+	Image         string `json:"image,omitempty"`
+	DatePublished string `json:"date_published"`
+	DateModified  string `json:"date_modified"`
+}
+
+// NewJSONFeed はHTMLに変換済みの投稿からJSON Feedを作成します
+func NewJSONFeed(site *Site, feedURL string, posts []*dto.PostDTO) *JSONFeed {
+	jsonFeed := &JSONFeed{
+		Version:     jsonFeedVersion,
+		Title:       site.Title,
+		HomePageURL: site.URL,
+		FeedURL:     feedURL,
+		Description: site.Description,
+		Items:       make([]*JSONFeedItem, 0, len(posts)),
+	}
+
+	for _, post := range posts {
+		jsonFeed.Items = append(jsonFeed.Items, &JSONFeedItem{
+			ID:            post.ID,
+			URL:           site.PostURL(post.Permalink),
+			Title:         post.Title,
+			ContentHTML:   post.Content,
+			Image:         post.ThumbnailURL,
+			DatePublished: post.PublishedAt.Format(time.RFC3339),
+			DateModified:  post.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return jsonFeed
+}
@@ -0,0 +1,52 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/dto"
+)
+
+func TestNewJSONFeed(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	site := NewSite("mesimasi.com", "description", "https://mesimasi.com", "masibw")
+
+	posts := []*dto.PostDTO{
+		{
+			ID:           "abcdefghijklmnopqrstuvwxyz",
+			Title:        "new_post",
+			ThumbnailURL: "new_thumbnail_url",
+			Content:      "<p>new_content</p>\n",
+			Permalink:    "new_permalink",
+			UpdatedAt:    time.Date(2021, 1, 23, 0, 0, 0, 0, loc),
+			PublishedAt:  time.Date(2021, 1, 22, 0, 0, 0, 0, loc),
+		},
+	}
+	want := &JSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       "mesimasi.com",
+		HomePageURL: "https://mesimasi.com",
+		FeedURL:     "https://mesimasi.com/feed.json",
+		Description: "description",
+		Items: []*JSONFeedItem{
+			{
+				ID:            "abcdefghijklmnopqrstuvwxyz",
+				URL:           "https://mesimasi.com/posts/new_permalink",
+				Title:         "new_post",
+				ContentHTML:   "<p>new_content</p>\n",
+				Image:         "new_thumbnail_url",
+				DatePublished: "2021-01-22T00:00:00+09:00",
+				DateModified:  "2021-01-23T00:00:00+09:00",
+			},
+		},
+	}
+
+	got := NewJSONFeed(site, "https://mesimasi.com/feed.json", posts)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewJSONFeed() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,76 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/masibw/blog-server/domain/dto"
+)
+
+// RSS は RSS 2.0 のフィードです https://www.rssboard.org/rss-specification
+type RSS struct {
+	XMLName xml.Name    `xml:"rss"`
+	Version string      `xml:"version,attr"`
+	Atom    string      `xml:"xmlns:atom,attr"`
+	Channel *RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	AtomLink      *AtomLink  `xml:"atom:link"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	Items         []*RSSItem `xml:"item"`
+}
+
+type RSSItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        *RSSGUID `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Description string   `xml:"description"`
+}
+
+type RSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// NewRSS はHTMLに変換済みの投稿からRSSフィードを作成します
+func NewRSS(site *Site, feedURL string, posts []*dto.PostDTO) *RSS {
+	channel := &RSSChannel{
+		Title:       site.Title,
+		Link:        site.URL,
+		Description: site.Description,
+		AtomLink: &AtomLink{
+			Href: feedURL,
+			Rel:  "self",
+			Type: "application/rss+xml",
+		},
+		Items: make([]*RSSItem, 0, len(posts)),
+	}
+	if lastModified := LastModified(posts); !lastModified.IsZero() {
+		channel.LastBuildDate = lastModified.Format(time.RFC1123Z)
+	}
+
+	for _, post := range posts {
+		postURL := site.PostURL(post.Permalink)
+		channel.Items = append(channel.Items, &RSSItem{
+			Title: post.Title,
+			Link:  postURL,
+			GUID: &RSSGUID{
+				IsPermaLink: true,
+				Value:       postURL,
+			},
+			PubDate:     post.PublishedAt.Format(time.RFC1123Z),
+			Description: post.Content,
+		})
+	}
+
+	return &RSS{
+		Version: "2.0",
+		Atom:    atomNamespace,
+		Channel: channel,
+	}
+}
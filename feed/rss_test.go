@@ -0,0 +1,81 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/dto"
+)
+
+func TestNewRSS(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	site := NewSite("mesimasi.com", "description", "https://mesimasi.com", "masibw")
+
+	tests := []struct {
+		name  string
+		posts []*dto.PostDTO
+		want  *RSS
+	}{
+		{
+			name: "投稿からRSSフィードを作成できる",
+			posts: []*dto.PostDTO{
+				{
+					ID:          "abcdefghijklmnopqrstuvwxyz",
+					Title:       "new_post",
+					Content:     "<p>new_content</p>\n",
+					Permalink:   "new_permalink",
+					UpdatedAt:   time.Date(2021, 1, 23, 0, 0, 0, 0, loc),
+					PublishedAt: time.Date(2021, 1, 22, 0, 0, 0, 0, loc),
+				},
+			},
+			want: &RSS{
+				Version: "2.0",
+				Atom:    atomNamespace,
+				Channel: &RSSChannel{
+					Title:         "mesimasi.com",
+					Link:          "https://mesimasi.com",
+					Description:   "description",
+					AtomLink:      &AtomLink{Href: "https://mesimasi.com/feed.xml", Rel: "self", Type: "application/rss+xml"},
+					LastBuildDate: "Sat, 23 Jan 2021 00:00:00 +0900",
+					Items: []*RSSItem{
+						{
+							Title:       "new_post",
+							Link:        "https://mesimasi.com/posts/new_permalink",
+							GUID:        &RSSGUID{IsPermaLink: true, Value: "https://mesimasi.com/posts/new_permalink"},
+							PubDate:     "Fri, 22 Jan 2021 00:00:00 +0900",
+							Description: "<p>new_content</p>\n",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "投稿がない場合は空のフィードを作成できる",
+			posts: nil,
+			want: &RSS{
+				Version: "2.0",
+				Atom:    atomNamespace,
+				Channel: &RSSChannel{
+					Title:       "mesimasi.com",
+					Link:        "https://mesimasi.com",
+					Description: "description",
+					AtomLink:    &AtomLink{Href: "https://mesimasi.com/feed.xml", Rel: "self", Type: "application/rss+xml"},
+					Items:       []*RSSItem{},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRSS(site, "https://mesimasi.com/feed.xml", tt.posts)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewRSS() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
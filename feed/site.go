@@ -0,0 +1,45 @@
+package feed
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/masibw/blog-server/domain/dto"
+)
+
+// Site はフィードに載せるブログの情報です
+type Site struct {
+	Title       string
+	Description string
+	// URL は末尾のスラッシュを含まないフロントエンドのURLです
+	URL    string
+	Author string
+}
+
+func NewSite(title, description, siteURL, author string) *Site {
+	return &Site{
+		Title:       title,
+		Description: description,
+		URL:         siteURL,
+		Author:      author,
+	}
+}
+
+// PostURL は投稿のフロントエンドでのURLを返します
+func (s *Site) PostURL(permalink string) string {
+	return s.URL + "/posts/" + url.PathEscape(permalink)
+}
+
+// LastModified は投稿の中で最も新しい更新日時を返します
+func LastModified(posts []*dto.PostDTO) time.Time {
+	var lastModified time.Time
+	for _, post := range posts {
+		if post.UpdatedAt.After(lastModified) {
+			lastModified = post.UpdatedAt
+		}
+		if post.PublishedAt.After(lastModified) {
+			lastModified = post.PublishedAt
+		}
+	}
+	return lastModified
+}
@@ -0,0 +1,143 @@
+package imageproc
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/disintegration/imaging"
+	"github.com/masibw/blog-server/domain/entity"
+
+	// WebPの画像もアップロードできるようにデコーダーを登録する
+	_ "golang.org/x/image/webp"
+)
+
+// jpegQuality はJPEGで保存する時の画質です
+const jpegQuality = 85
+
+const (
+	contentTypeJPEG = "image/jpeg"
+	contentTypePNG  = "image/png"
+	contentTypeGIF  = "image/gif"
+	contentTypeWebP = "image/webp"
+)
+
+// supportedContentTypes はアップロードできる画像の形式です
+var supportedContentTypes = map[string]bool{
+	contentTypeJPEG: true,
+	contentTypePNG:  true,
+	contentTypeGIF:  true,
+	contentTypeWebP: true,
+}
+
+// Variant は幅を変えたり形式を変換したりした画像の1つです
+type Variant struct {
+	Width       int
+	Height      int
+	ContentType string
+	Ext         string
+	Body        []byte
+}
+
+// Process は画像を widths(小さい順)の各幅に縮小し，元の形式に合わせたJPEGかPNGとWebPにエンコードします
+// 元の画像より大きい幅には拡大せず，代わりに元の幅(widths の最大値を超える場合は最大値)の画像を作ります
+// 再エンコードするのでEXIFなどのメタデータは取り除かれます．向きの情報だけは取り除く前にピクセルへ反映します
+// WebPは可逆圧縮なので，JPEGやPNGより大きくなった場合は作りません
+func Process(body []byte, widths []int, maxPixels int) ([]*Variant, error) {
+	// 拡張子やリクエストのContent-Typeは信用せず中身から形式を判定する
+	contentType := http.DetectContentType(body)
+	if !supportedContentTypes[contentType] {
+		return nil, fmt.Errorf("process image content type=%v: %w", contentType, entity.ErrImageTypeUnsupported)
+	}
+
+	// デコードする前に大きさを確かめて，展開すると巨大になる画像を拒否する
+	config, _, err := image.DecodeConfig(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("process image decode config: %w", entity.ErrImageTypeUnsupported)
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, fmt.Errorf("process image width=%v height=%v: %w", config.Width, config.Height, entity.ErrImageTooLarge)
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(body), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, fmt.Errorf("process image decode: %w", entity.ErrImageTypeUnsupported)
+	}
+
+	variants := make([]*Variant, 0, 2*len(widths))
+	for _, width := range targetWidths(img.Bounds().Dx(), widths) {
+		resized := img
+		if width < img.Bounds().Dx() {
+			resized = imaging.Resize(img, width, 0, imaging.Lanczos)
+		}
+
+		fallback, err := encodeFallback(resized, contentType)
+		if err != nil {
+			return nil, fmt.Errorf("process image width=%v: %w", width, err)
+		}
+		variants = append(variants, fallback)
+
+		var buf bytes.Buffer
+		if err = nativewebp.Encode(&buf, resized, nil); err != nil {
+			return nil, fmt.Errorf("process image webp width=%v: %w", width, err)
+		}
+		if buf.Len() < len(fallback.Body) {
+			variants = append(variants, &Variant{
+				Width:       fallback.Width,
+				Height:      fallback.Height,
+				ContentType: contentTypeWebP,
+				Ext:         "webp",
+				Body:        buf.Bytes(),
+			})
+		}
+	}
+	return variants, nil
+}
+
+// targetWidths は小さい順に並んだ widths から作る画像の幅を返します
+func targetWidths(original int, widths []int) []int {
+	targets := make([]int, 0, len(widths)+1)
+	for _, width := range widths {
+		if width >= original {
+			return append(targets, original)
+		}
+		targets = append(targets, width)
+	}
+	return targets
+}
+
+// encodeFallback はWebPに対応していないブラウザ向けに，JPEGはJPEGのまま，それ以外はPNGでエンコードします
+func encodeFallback(img image.Image, contentType string) (*Variant, error) {
+	var buf bytes.Buffer
+	v := &Variant{
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+	}
+	if contentType == contentTypeJPEG {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		v.ContentType, v.Ext = contentTypeJPEG, "jpg"
+	} else {
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+		v.ContentType, v.Ext = contentTypePNG, "png"
+	}
+	v.Body = buf.Bytes()
+	return v, nil
+}
+
+// Dimensions は画像の幅と高さを返します．ヘッダーだけを読むので画像全体はデコードしません
+func Dimensions(r io.Reader) (width, height int, err error) {
+	config, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return 0, 0, fmt.Errorf("image dimensions: %w", entity.ErrImageTypeUnsupported)
+	}
+	return config.Width, config.Height, nil
+}
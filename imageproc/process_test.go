@@ -0,0 +1,176 @@
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+func TestProcess(t *testing.T) {
+	// 可逆圧縮のWebPがJPEGより大きくなるように，規則性の無い画素にする
+	noise := newTestImage(800, 600, func(x, y int) color.NRGBA {
+		h := uint32(x*73856093^y*19349663) * 2654435761
+		return color.NRGBA{R: uint8(h >> 24), G: uint8(h >> 16), B: uint8(h >> 8), A: 0xff}
+	})
+	flat := newTestImage(400, 300, func(x, y int) color.NRGBA {
+		return color.NRGBA{R: 0x20, G: 0x40, B: 0x60, A: 0xff}
+	})
+
+	type size struct {
+		Width       int
+		Height      int
+		ContentType string
+		Ext         string
+	}
+	tests := []struct {
+		name      string
+		body      []byte
+		widths    []int
+		maxPixels int
+		want      []size
+		wantErr   error
+	}{
+		{
+			name:      "JPEGは元の幅を超えない幅のJPEGに変換され，JPEGより大きくなるWebPは作られない",
+			body:      encodeJPEG(t, noise),
+			widths:    []int{320, 640, 1280},
+			maxPixels: 1000000,
+			want: []size{
+				{Width: 320, Height: 240, ContentType: "image/jpeg", Ext: "jpg"},
+				{Width: 640, Height: 480, ContentType: "image/jpeg", Ext: "jpg"},
+				{Width: 800, Height: 600, ContentType: "image/jpeg", Ext: "jpg"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "PNGはPNGとそれより小さいWebPに変換され，最大の幅より大きい画像は縮小される",
+			body:      encodePNG(t, flat),
+			widths:    []int{100, 200},
+			maxPixels: 1000000,
+			want: []size{
+				{Width: 100, Height: 75, ContentType: "image/png", Ext: "png"},
+				{Width: 100, Height: 75, ContentType: "image/webp", Ext: "webp"},
+				{Width: 200, Height: 150, ContentType: "image/png", Ext: "png"},
+				{Width: 200, Height: 150, ContentType: "image/webp", Ext: "webp"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "画像でない時はErrImageTypeUnsupportedエラーが返る",
+			body:      []byte("<html><body>not an image</body></html>"),
+			widths:    []int{320},
+			maxPixels: 1000000,
+			want:      nil,
+			wantErr:   entity.ErrImageTypeUnsupported,
+		},
+		{
+			name:      "画素数が上限を超える時はErrImageTooLargeエラーが返る",
+			body:      encodePNG(t, flat),
+			widths:    []int{320},
+			maxPixels: 400*300 - 1,
+			want:      nil,
+			wantErr:   entity.ErrImageTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Process(tt.body, tt.widths, tt.maxPixels)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Process() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var sizes []size
+			for _, v := range got {
+				sizes = append(sizes, size{Width: v.Width, Height: v.Height, ContentType: v.ContentType, Ext: v.Ext})
+				if _, _, err := image.Decode(bytes.NewReader(v.Body)); err != nil {
+					t.Errorf("Process() variant %v could not be decoded: %v", v.ContentType, err)
+				}
+			}
+			if diff := cmp.Diff(tt.want, sizes); diff != "" {
+				t.Errorf("Process() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDimensions(t *testing.T) {
+	img := newTestImage(400, 300, func(x, y int) color.NRGBA {
+		return color.NRGBA{R: 0x20, G: 0x40, B: 0x60, A: 0xff}
+	})
+
+	tests := []struct {
+		name       string
+		body       []byte
+		wantWidth  int
+		wantHeight int
+		wantErr    error
+	}{
+		{
+			name:       "JPEGの幅と高さを返す",
+			body:       encodeJPEG(t, img),
+			wantWidth:  400,
+			wantHeight: 300,
+			wantErr:    nil,
+		},
+		{
+			name:       "PNGの幅と高さを返す",
+			body:       encodePNG(t, img),
+			wantWidth:  400,
+			wantHeight: 300,
+			wantErr:    nil,
+		},
+		{
+			name:       "画像でない場合はErrImageTypeUnsupportedを返す",
+			body:       []byte("<svg></svg>"),
+			wantWidth:  0,
+			wantHeight: 0,
+			wantErr:    entity.ErrImageTypeUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height, err := Dimensions(bytes.NewReader(tt.body))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Dimensions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if width != tt.wantWidth || height != tt.wantHeight {
+				t.Errorf("Dimensions() = %dx%d, want = %dx%d", width, height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTestImage(width, height int, fn func(x, y int) color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, fn(x, y))
+		}
+	}
+	return img
+}
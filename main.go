@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"os"
 	"time"
 
 	"github.com/masibw/blog-server/domain/service"
+	"github.com/masibw/blog-server/scheduler"
+	"github.com/masibw/blog-server/storage"
 
 	"github.com/masibw/blog-server/usecase"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/masibw/blog-server/config"
 	"github.com/masibw/blog-server/database"
 	"github.com/masibw/blog-server/log"
+	"github.com/masibw/blog-server/ogp"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/mysql"
@@ -44,7 +48,12 @@ func main() {
 	}
 
 	postRepository := database.NewPostRepository(db)
-	postUC := usecase.NewPostUseCase(postRepository)
+	postRevisionRepository := database.NewPostRevisionRepository(db)
+	permalinkRedirectRepository := database.NewPermalinkRedirectRepository(db)
+	postUC := usecase.NewPostUseCase(postRepository, postRevisionRepository, permalinkRedirectRepository)
+
+	publishScheduler := scheduler.NewPublishScheduler(postUC, config.PublishInterval())
+	go publishScheduler.Run(context.Background())
 
 	tagRepository := database.NewTagRepository(db)
 	tagUC := usecase.NewTagUseCase(tagRepository)
@@ -53,13 +62,46 @@ func main() {
 	userUC := usecase.NewUserUseCase(userRepository)
 	authMW := web.NewAuthMiddleware(userUC)
 
-	imageUC := usecase.NewImageUseCase()
+	imageStorage, localStorage, err := storage.NewImageStorage()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	pageRepository := database.NewPageRepository(db)
+	imageRepository := database.NewImageRepository(db)
+	imageUC := usecase.NewImageUseCase(imageStorage, imageRepository, postRepository, postRevisionRepository, pageRepository, userRepository)
 
 	postsTagsRepository := database.NewPostsTagsRepository(db)
 
 	postsTagsService := service.NewPostsTagsService(postsTagsRepository, postRepository, tagRepository)
 
-	e := web.NewServer(postUC, tagUC, imageUC, authMW, postsTagsService)
+	seriesRepository := database.NewSeriesRepository(db)
+	seriesPostRepository := database.NewSeriesPostRepository(db)
+	seriesUC := usecase.NewSeriesUseCase(seriesRepository, seriesPostRepository, postRepository)
+	seriesPostsService := service.NewSeriesPostsService(seriesPostRepository, seriesRepository, postRepository)
+	pageUC := usecase.NewPageUseCase(pageRepository)
+
+	sitemapUC := usecase.NewSitemapUseCase(postRepository, tagRepository)
+
+	trashUC := usecase.NewTrashUseCase(postRepository, tagRepository)
+
+	previewTokenRepository := database.NewPreviewTokenRepository(db)
+	previewUC := usecase.NewPreviewUseCase(postRepository, previewTokenRepository, config.PreviewSecret())
+
+	ogpFont, err := config.OGPFont()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	ogpTemplate, err := config.OGPTemplate()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	ogpRenderer, err := ogp.NewRenderer(ogpFont, ogpTemplate, config.SiteTitle())
+	if err != nil {
+		logger.Fatal(err)
+	}
+	thumbnailUC := usecase.NewThumbnailUseCase(postRepository, tagRepository, imageStorage, ogpRenderer)
+
+	e := web.NewServer(postUC, tagUC, imageUC, sitemapUC, trashUC, previewUC, thumbnailUC, seriesUC, pageUC, localStorage, authMW, postsTagsService, seriesPostsService)
 
 	if err := e.Run(":8080"); err != nil {
 		if err != nil {
@@ -0,0 +1,170 @@
+package meta
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/url"
+	"time"
+
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/feed"
+)
+
+// PostMeta は投稿のページの<head>に載せるOpen GraphとTwitterカードのメタデータ，構造化データです
+type PostMeta struct {
+	Title         string       `json:"title"`
+	Description   string       `json:"description"`
+	CanonicalURL  string       `json:"canonicalUrl"`
+	Image         string       `json:"image,omitempty"`
+	SiteName      string       `json:"siteName"`
+	PublishedTime time.Time    `json:"publishedTime"`
+	ModifiedTime  time.Time    `json:"modifiedTime"`
+	Tags          []string     `json:"tags"`
+	Meta          []*Tag       `json:"meta"`
+	JSONLD        *BlogPosting `json:"jsonLd"`
+}
+
+// Tag は<meta>タグです．Open Graphは property，Twitterカードは name を使います
+type Tag struct {
+	Property string `json:"property,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Content  string `json:"content"`
+}
+
+// BlogPosting はJSON-LDで表した schema.org の BlogPosting です https://schema.org/BlogPosting
+type BlogPosting struct {
+	Context          string        `json:"@context"`
+	Type             string        `json:"@type"`
+	Headline         string        `json:"headline"`
+	Description      string        `json:"description"`
+	Image            []string      `json:"image,omitempty"`
+	DatePublished    time.Time     `json:"datePublished"`
+	DateModified     time.Time     `json:"dateModified"`
+	URL              string        `json:"url"`
+	MainEntityOfPage *WebPage      `json:"mainEntityOfPage"`
+	Keywords         []string      `json:"keywords,omitempty"`
+	Publisher        *Organization `json:"publisher"`
+}
+
+type WebPage struct {
+	Type string `json:"@type"`
+	ID   string `json:"@id"`
+}
+
+type Organization struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// NewPostMeta は投稿のメタデータを作成します．post の本文は Markdown で渡してください
+// 説明文は本文の先頭から作り，サムネイルが相対URLの場合はサイトのURLからの絶対URLにします
+func NewPostMeta(site *feed.Site, post *dto.PostDTO, tags []*dto.TagDTO) *PostMeta {
+	canonicalURL := site.PostURL(post.Permalink)
+	image := absoluteURL(site.URL, post.ThumbnailURL)
+	tagNames := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+
+	m := &PostMeta{
+		Title:         post.Title,
+		Description:   entity.Description(post.Content),
+		CanonicalURL:  canonicalURL,
+		Image:         image,
+		SiteName:      site.Title,
+		PublishedTime: post.PublishedAt,
+		ModifiedTime:  post.UpdatedAt,
+		Tags:          tagNames,
+	}
+
+	m.Meta = []*Tag{
+		{Property: "og:type", Content: "article"},
+		{Property: "og:title", Content: m.Title},
+		{Property: "og:description", Content: m.Description},
+		{Property: "og:url", Content: canonicalURL},
+		{Property: "og:site_name", Content: site.Title},
+	}
+	if image != "" {
+		m.Meta = append(m.Meta, &Tag{Property: "og:image", Content: image})
+	}
+	m.Meta = append(m.Meta,
+		&Tag{Property: "article:published_time", Content: post.PublishedAt.Format(time.RFC3339)},
+		&Tag{Property: "article:modified_time", Content: post.UpdatedAt.Format(time.RFC3339)},
+	)
+	for _, name := range tagNames {
+		m.Meta = append(m.Meta, &Tag{Property: "article:tag", Content: name})
+	}
+	if image != "" {
+		m.Meta = append(m.Meta,
+			&Tag{Name: "twitter:card", Content: "summary_large_image"},
+			&Tag{Name: "twitter:image", Content: image},
+		)
+	} else {
+		m.Meta = append(m.Meta, &Tag{Name: "twitter:card", Content: "summary"})
+	}
+	m.Meta = append(m.Meta,
+		&Tag{Name: "twitter:title", Content: m.Title},
+		&Tag{Name: "twitter:description", Content: m.Description},
+	)
+
+	m.JSONLD = &BlogPosting{
+		Context:       "https://schema.org",
+		Type:          "BlogPosting",
+		Headline:      m.Title,
+		Description:   m.Description,
+		DatePublished: post.PublishedAt,
+		DateModified:  post.UpdatedAt,
+		URL:           canonicalURL,
+		MainEntityOfPage: &WebPage{
+			Type: "WebPage",
+			ID:   canonicalURL,
+		},
+		Keywords: tagNames,
+		Publisher: &Organization{
+			Type: "Organization",
+			Name: site.Title,
+			URL:  site.URL,
+		},
+	}
+	if image != "" {
+		m.JSONLD.Image = []string{image}
+	}
+	return m
+}
+
+// absoluteURL は ref をサイトのURLからの絶対URLにします
+func absoluteURL(siteURL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	base, err := url.Parse(siteURL + "/")
+	if err != nil {
+		return ref
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
+
+// headTemplate は<head>にそのまま埋め込めるHTMLです
+// html/template は type="application/ld+json" の<script>の中身をJSONとしてエスケープします
+var headTemplate = template.Must(template.New("head").Parse(`<title>{{.Title}}</title>
+<meta name="description" content="{{.Description}}">
+<link rel="canonical" href="{{.CanonicalURL}}">
+{{range .Meta}}{{if .Property}}<meta property="{{.Property}}" content="{{.Content}}">{{else}}<meta name="{{.Name}}" content="{{.Content}}">{{end}}
+{{end}}<script type="application/ld+json">{{.JSONLD}}</script>
+`))
+
+// HTML はメタデータを<head>に埋め込むHTMLにします
+func (m *PostMeta) HTML() (string, error) {
+	var buf bytes.Buffer
+	if err := headTemplate.Execute(&buf, m); err != nil {
+		return "", fmt.Errorf("execute head template: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,176 @@
+package meta
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/feed"
+)
+
+func TestNewPostMeta(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	site := feed.NewSite("mesimasi.com", "description", "https://mesimasi.com", "masibw")
+	publishedAt := time.Date(2021, 1, 22, 0, 0, 0, 0, loc)
+	updatedAt := time.Date(2021, 1, 23, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		post *dto.PostDTO
+		tags []*dto.TagDTO
+		want *PostMeta
+	}{
+		{
+			name: "説明文とタグ，サムネイルの絶対URLを含むメタデータを作成できる",
+			post: &dto.PostDTO{
+				Title:        "new_post",
+				ThumbnailURL: "/api/v1/uploads/ogp/new_post.png",
+				Content:      "# 見出し\n\n**new_content**",
+				Permalink:    "new_permalink",
+				UpdatedAt:    updatedAt,
+				PublishedAt:  publishedAt,
+			},
+			tags: []*dto.TagDTO{{Name: "go"}, {Name: "blog"}},
+			want: &PostMeta{
+				Title:         "new_post",
+				Description:   "見出し new_content",
+				CanonicalURL:  "https://mesimasi.com/posts/new_permalink",
+				Image:         "https://mesimasi.com/api/v1/uploads/ogp/new_post.png",
+				SiteName:      "mesimasi.com",
+				PublishedTime: publishedAt,
+				ModifiedTime:  updatedAt,
+				Tags:          []string{"go", "blog"},
+				Meta: []*Tag{
+					{Property: "og:type", Content: "article"},
+					{Property: "og:title", Content: "new_post"},
+					{Property: "og:description", Content: "見出し new_content"},
+					{Property: "og:url", Content: "https://mesimasi.com/posts/new_permalink"},
+					{Property: "og:site_name", Content: "mesimasi.com"},
+					{Property: "og:image", Content: "https://mesimasi.com/api/v1/uploads/ogp/new_post.png"},
+					{Property: "article:published_time", Content: "2021-01-22T00:00:00+09:00"},
+					{Property: "article:modified_time", Content: "2021-01-23T00:00:00+09:00"},
+					{Property: "article:tag", Content: "go"},
+					{Property: "article:tag", Content: "blog"},
+					{Name: "twitter:card", Content: "summary_large_image"},
+					{Name: "twitter:image", Content: "https://mesimasi.com/api/v1/uploads/ogp/new_post.png"},
+					{Name: "twitter:title", Content: "new_post"},
+					{Name: "twitter:description", Content: "見出し new_content"},
+				},
+				JSONLD: &BlogPosting{
+					Context:       "https://schema.org",
+					Type:          "BlogPosting",
+					Headline:      "new_post",
+					Description:   "見出し new_content",
+					Image:         []string{"https://mesimasi.com/api/v1/uploads/ogp/new_post.png"},
+					DatePublished: publishedAt,
+					DateModified:  updatedAt,
+					URL:           "https://mesimasi.com/posts/new_permalink",
+					MainEntityOfPage: &WebPage{
+						Type: "WebPage",
+						ID:   "https://mesimasi.com/posts/new_permalink",
+					},
+					Keywords: []string{"go", "blog"},
+					Publisher: &Organization{
+						Type: "Organization",
+						Name: "mesimasi.com",
+						URL:  "https://mesimasi.com",
+					},
+				},
+			},
+		},
+		{
+			name: "サムネイルが無い場合は画像を含めない",
+			post: &dto.PostDTO{
+				Title:       "new_post",
+				Content:     "new_content",
+				Permalink:   "new_permalink",
+				UpdatedAt:   updatedAt,
+				PublishedAt: publishedAt,
+			},
+			tags: []*dto.TagDTO{},
+			want: &PostMeta{
+				Title:         "new_post",
+				Description:   "new_content",
+				CanonicalURL:  "https://mesimasi.com/posts/new_permalink",
+				SiteName:      "mesimasi.com",
+				PublishedTime: publishedAt,
+				ModifiedTime:  updatedAt,
+				Tags:          []string{},
+				Meta: []*Tag{
+					{Property: "og:type", Content: "article"},
+					{Property: "og:title", Content: "new_post"},
+					{Property: "og:description", Content: "new_content"},
+					{Property: "og:url", Content: "https://mesimasi.com/posts/new_permalink"},
+					{Property: "og:site_name", Content: "mesimasi.com"},
+					{Property: "article:published_time", Content: "2021-01-22T00:00:00+09:00"},
+					{Property: "article:modified_time", Content: "2021-01-23T00:00:00+09:00"},
+					{Name: "twitter:card", Content: "summary"},
+					{Name: "twitter:title", Content: "new_post"},
+					{Name: "twitter:description", Content: "new_content"},
+				},
+				JSONLD: &BlogPosting{
+					Context:       "https://schema.org",
+					Type:          "BlogPosting",
+					Headline:      "new_post",
+					Description:   "new_content",
+					DatePublished: publishedAt,
+					DateModified:  updatedAt,
+					URL:           "https://mesimasi.com/posts/new_permalink",
+					MainEntityOfPage: &WebPage{
+						Type: "WebPage",
+						ID:   "https://mesimasi.com/posts/new_permalink",
+					},
+					Keywords: []string{},
+					Publisher: &Organization{
+						Type: "Organization",
+						Name: "mesimasi.com",
+						URL:  "https://mesimasi.com",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPostMeta(site, tt.post, tt.tags)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewPostMeta() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostMeta_HTML(t *testing.T) {
+	site := feed.NewSite("mesimasi.com", "description", "https://mesimasi.com", "masibw")
+	m := NewPostMeta(site, &dto.PostDTO{
+		Title:     `"></script><script>alert(1)</script>`,
+		Content:   "new_content",
+		Permalink: "new_permalink",
+	}, []*dto.TagDTO{{Name: "go"}})
+
+	got, err := m.HTML()
+	if err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	for _, want := range []string{
+		`<title>&#34;&gt;&lt;/script&gt;&lt;script&gt;alert(1)&lt;/script&gt;</title>`,
+		`<meta name="description" content="new_content">`,
+		`<link rel="canonical" href="https://mesimasi.com/posts/new_permalink">`,
+		`<meta property="article:tag" content="go">`,
+		`<meta name="twitter:card" content="summary">`,
+		`<script type="application/ld+json">`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML() = %v, want to contain %v", got, want)
+		}
+	}
+	if strings.Count(got, "</script>") != 1 {
+		t.Errorf("HTML() = %v, title must not close the script", got)
+	}
+}
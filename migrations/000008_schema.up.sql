@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `post_revisions` (
+  `id` CHAR(26) NOT NULL,
+  `post_id` CHAR(26) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `title` VARCHAR(64) COLLATE utf8mb4_unicode_ci,
+  `thumbnail_url` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+  `content` LONGTEXT COLLATE utf8mb4_unicode_ci,
+  `permalink` VARCHAR(256) COLLATE utf8mb4_unicode_ci,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX(`post_id`, `created_at`),
+  FOREIGN KEY(`post_id`) REFERENCES  posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
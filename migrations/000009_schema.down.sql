@@ -0,0 +1 @@
+ALTER TABLE posts DROP is_scheduled;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE posts ADD is_scheduled boolean NOT NULL DEFAULT 0 AFTER is_draft;
\ No newline at end of file
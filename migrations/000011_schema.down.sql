@@ -0,0 +1,2 @@
+ALTER TABLE posts DROP deleted_at;
+ALTER TABLE tags DROP deleted_at;
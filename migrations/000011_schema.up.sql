@@ -0,0 +1,2 @@
+ALTER TABLE posts ADD deleted_at DATETIME NULL DEFAULT NULL, ADD INDEX(`deleted_at`);
+ALTER TABLE tags ADD deleted_at DATETIME NULL DEFAULT NULL, ADD INDEX(`deleted_at`);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS preview_tokens;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `preview_tokens` (
+  `id` CHAR(26) NOT NULL,
+  `post_id` CHAR(26) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `expires_at` DATETIME NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX(`post_id`, `expires_at`),
+  FOREIGN KEY(`post_id`) REFERENCES  posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `permalink_redirects` (
+  `id` CHAR(26) NOT NULL,
+  `post_id` CHAR(26) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `permalink` VARCHAR(256) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE(`permalink`),
+  INDEX(`post_id`),
+  FOREIGN KEY(`post_id`) REFERENCES  posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+ALTER TABLE tags DROP FOREIGN KEY `fk_tags_parent_id`;
+ALTER TABLE tags DROP COLUMN parent_id;
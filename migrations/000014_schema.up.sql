@@ -0,0 +1 @@
+ALTER TABLE tags ADD parent_id CHAR(26) COLLATE utf8mb4_unicode_ci NULL DEFAULT NULL, ADD INDEX(`parent_id`), ADD CONSTRAINT `fk_tags_parent_id` FOREIGN KEY(`parent_id`) REFERENCES tags(id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS image_usages;
+DROP TABLE IF EXISTS image_variants;
+DROP TABLE IF EXISTS images;
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS `images` (
+  `id` CHAR(26) NOT NULL,
+  `key` VARCHAR(512) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `content_type` VARCHAR(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `width` INT NOT NULL DEFAULT 0,
+  `height` INT NOT NULL DEFAULT 0,
+  `alt_text` VARCHAR(512) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `user_id` CHAR(26) COLLATE utf8mb4_unicode_ci NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE(`key`),
+  FOREIGN KEY(`user_id`) REFERENCES  users(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `image_variants` (
+  `id` CHAR(26) NOT NULL,
+  `image_id` CHAR(26) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `key` VARCHAR(512) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `content_type` VARCHAR(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `width` INT NOT NULL,
+  `height` INT NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE(`key`),
+  INDEX(`image_id`),
+  FOREIGN KEY(`image_id`) REFERENCES  images(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `image_usages` (
+  `id` CHAR(26) NOT NULL,
+  `image_id` CHAR(26) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `post_id` CHAR(26) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE(`image_id`, `post_id`),
+  INDEX(`post_id`),
+  FOREIGN KEY(`image_id`) REFERENCES  images(id) ON DELETE CASCADE,
+  FOREIGN KEY(`post_id`) REFERENCES  posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1 @@
+ALTER TABLE posts DROP excerpt, DROP char_count, DROP word_count, DROP reading_time;
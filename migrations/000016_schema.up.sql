@@ -0,0 +1 @@
+ALTER TABLE posts ADD excerpt VARCHAR(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' AFTER content, ADD char_count INT NOT NULL DEFAULT 0 AFTER excerpt, ADD word_count INT NOT NULL DEFAULT 0 AFTER char_count, ADD reading_time INT NOT NULL DEFAULT 0 AFTER word_count;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS `series` (
+  `id` CHAR(26) NOT NULL,
+  `title` VARCHAR(256) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `description` TEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `series_posts` (
+  `id` CHAR(26) NOT NULL,
+  `series_id` CHAR(26) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `post_id` CHAR(26) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `position` INT NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE(`post_id`),
+  INDEX(`series_id`, `position`),
+  FOREIGN KEY(`series_id`) REFERENCES  series(id) ON DELETE CASCADE,
+  FOREIGN KEY(`post_id`) REFERENCES  posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
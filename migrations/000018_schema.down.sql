@@ -0,0 +1 @@
+ALTER TABLE posts DROP is_pinned, DROP featured_rank;
@@ -0,0 +1 @@
+ALTER TABLE posts ADD is_pinned boolean NOT NULL DEFAULT 0 AFTER is_scheduled, ADD featured_rank INT NOT NULL DEFAULT 0 AFTER is_pinned;
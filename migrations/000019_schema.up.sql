@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `pages` (
+  `id` CHAR(26) NOT NULL,
+  `title` VARCHAR(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `content` LONGTEXT COLLATE utf8mb4_unicode_ci NOT NULL,
+  `path` VARCHAR(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `is_draft` boolean NOT NULL DEFAULT 1,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE(`path`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
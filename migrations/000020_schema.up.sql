@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `image_page_usages` (
+  `id` CHAR(26) NOT NULL,
+  `image_id` CHAR(26) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `page_id` CHAR(26) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE(`image_id`, `page_id`),
+  INDEX(`page_id`),
+  FOREIGN KEY(`image_id`) REFERENCES  images(id) ON DELETE CASCADE,
+  FOREIGN KEY(`page_id`) REFERENCES  pages(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
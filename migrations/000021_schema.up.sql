@@ -0,0 +1 @@
+ALTER TABLE posts ADD version INT NOT NULL DEFAULT 1 AFTER featured_rank;
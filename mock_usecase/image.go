@@ -5,44 +5,168 @@
 package mock_usecase
 
 import (
-	gomock "github.com/golang/mock/gomock"
+	io "io"
 	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/masibw/blog-server/domain/dto"
 )
 
-// MockImage is a mock of Image interface
+// MockImage is a mock of Image interface.
 type MockImage struct {
 	ctrl     *gomock.Controller
 	recorder *MockImageMockRecorder
 }
 
-// MockImageMockRecorder is the mock recorder for MockImage
+// MockImageMockRecorder is the mock recorder for MockImage.
 type MockImageMockRecorder struct {
 	mock *MockImage
 }
 
-// NewMockImage creates a new mock instance
+// NewMockImage creates a new mock instance.
 func NewMockImage(ctrl *gomock.Controller) *MockImage {
 	mock := &MockImage{ctrl: ctrl}
 	mock.recorder = &MockImageMockRecorder{mock}
 	return mock
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
+// EXPECT returns an object that allows the caller to indicate expected use.
 func (m *MockImage) EXPECT() *MockImageMockRecorder {
 	return m.recorder
 }
 
-// CreatePresignedURL mocks base method
-func (m *MockImage) CreatePresignedURL(fileName, contentType *string) (string, error) {
+// CreatePresignedURL mocks base method.
+func (m *MockImage) CreatePresignedURL(fileName, contentType *string, mailAddress string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePresignedURL", fileName, contentType, mailAddress)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePresignedURL indicates an expected call of CreatePresignedURL.
+func (mr *MockImageMockRecorder) CreatePresignedURL(fileName, contentType, mailAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePresignedURL", reflect.TypeOf((*MockImage)(nil).CreatePresignedURL), fileName, contentType, mailAddress)
+}
+
+// DeleteImage mocks base method.
+func (m *MockImage) DeleteImage(id string, force bool) (*dto.ImageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", id, force)
+	ret0, _ := ret[0].(*dto.ImageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockImageMockRecorder) DeleteImage(id, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockImage)(nil).DeleteImage), id, force)
+}
+
+// DeleteOrphanedImages mocks base method.
+func (m *MockImage) DeleteOrphanedImages(gracePeriod time.Duration, dryRun bool) ([]*dto.StoredImageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphanedImages", gracePeriod, dryRun)
+	ret0, _ := ret[0].([]*dto.StoredImageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOrphanedImages indicates an expected call of DeleteOrphanedImages.
+func (mr *MockImageMockRecorder) DeleteOrphanedImages(gracePeriod, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedImages", reflect.TypeOf((*MockImage)(nil).DeleteOrphanedImages), gracePeriod, dryRun)
+}
+
+// GetImage mocks base method.
+func (m *MockImage) GetImage(id string) (*dto.ImageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImage", id)
+	ret0, _ := ret[0].(*dto.ImageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImage indicates an expected call of GetImage.
+func (mr *MockImageMockRecorder) GetImage(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImage", reflect.TypeOf((*MockImage)(nil).GetImage), id)
+}
+
+// GetImages mocks base method.
+func (m *MockImage) GetImages(offset, pageSize int, query string) ([]*dto.ImageDTO, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImages", offset, pageSize, query)
+	ret0, _ := ret[0].([]*dto.ImageDTO)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetImages indicates an expected call of GetImages.
+func (mr *MockImageMockRecorder) GetImages(offset, pageSize, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImages", reflect.TypeOf((*MockImage)(nil).GetImages), offset, pageSize, query)
+}
+
+// ImageURL mocks base method.
+func (m *MockImage) ImageURL(fileName string) string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePresignedURL", fileName, contentType)
+	ret := m.ctrl.Call(m, "ImageURL", fileName)
 	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ImageURL indicates an expected call of ImageURL.
+func (mr *MockImageMockRecorder) ImageURL(fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImageURL", reflect.TypeOf((*MockImage)(nil).ImageURL), fileName)
+}
+
+// ScanImageUsages mocks base method.
+func (m *MockImage) ScanImageUsages() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanImageUsages")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanImageUsages indicates an expected call of ScanImageUsages.
+func (mr *MockImageMockRecorder) ScanImageUsages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanImageUsages", reflect.TypeOf((*MockImage)(nil).ScanImageUsages))
+}
+
+// UpdateImage mocks base method.
+func (m *MockImage) UpdateImage(id string, imageDTO *dto.ImageDTO) (*dto.ImageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateImage", id, imageDTO)
+	ret0, _ := ret[0].(*dto.ImageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateImage indicates an expected call of UpdateImage.
+func (mr *MockImageMockRecorder) UpdateImage(id, imageDTO interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImage", reflect.TypeOf((*MockImage)(nil).UpdateImage), id, imageDTO)
+}
+
+// UploadImage mocks base method.
+func (m *MockImage) UploadImage(body io.Reader, mailAddress string) (*dto.ImageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", body, mailAddress)
+	ret0, _ := ret[0].(*dto.ImageDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreatePresignedURL indicates an expected call of CreatePresignedURL
-func (mr *MockImageMockRecorder) CreatePresignedURL(fileName, contentType interface{}) *gomock.Call {
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockImageMockRecorder) UploadImage(body, mailAddress interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePresignedURL", reflect.TypeOf((*MockImage)(nil).CreatePresignedURL), fileName, contentType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockImage)(nil).UploadImage), body, mailAddress)
 }
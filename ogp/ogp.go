@@ -0,0 +1,229 @@
+package ogp
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"unicode"
+
+	"github.com/disintegration/imaging"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Width と Height はOGP画像の大きさです https://ogp.me/
+const (
+	Width  = 1200
+	Height = 630
+)
+
+const (
+	// padding はOGP画像の端から文字までの余白です
+	padding = 96
+	// frame はデフォルトのテンプレートの枠の太さです
+	frame = 24
+
+	titleSize       = 64
+	titleLineHeight = 88
+	titleMaxLines   = 4
+	tagSize         = 32
+	tagLineHeight   = 44
+	siteTitleSize   = 28
+)
+
+var (
+	frameColor      = color.RGBA{R: 0x25, G: 0x63, B: 0xeb, A: 0xff}
+	backgroundColor = color.White
+	titleColor      = color.RGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xff}
+	tagColor        = color.RGBA{R: 0x25, G: 0x63, B: 0xeb, A: 0xff}
+	siteTitleColor  = color.RGBA{R: 0x6b, G: 0x72, B: 0x80, A: 0xff}
+)
+
+// Renderer は投稿のタイトルとタグを描いたOGP画像を作ります
+// フォントの face は並行して使えないので，Render のたびに作ります
+type Renderer struct {
+	titleFont *sfnt.Font
+	textFont  *sfnt.Font
+	template  image.Image
+	siteTitle string
+}
+
+// NewRenderer は Renderer を作成します
+// fontData が空の場合は欧文だけを含むGoフォントを使うので，日本語を描くにはTrueTypeかOpenTypeのフォントを指定してください
+// templateData が空の場合は枠だけの背景を使い，指定した場合は画像を1200x630に切り抜いて背景にします
+func NewRenderer(fontData, templateData []byte, siteTitle string) (*Renderer, error) {
+	r := &Renderer{siteTitle: siteTitle}
+
+	var err error
+	if len(fontData) == 0 {
+		if r.titleFont, err = opentype.Parse(gobold.TTF); err != nil {
+			return nil, fmt.Errorf("parse go bold font: %w", err)
+		}
+		if r.textFont, err = opentype.Parse(goregular.TTF); err != nil {
+			return nil, fmt.Errorf("parse go regular font: %w", err)
+		}
+	} else {
+		if r.titleFont, err = parseFont(fontData); err != nil {
+			return nil, err
+		}
+		r.textFont = r.titleFont
+	}
+
+	if len(templateData) == 0 {
+		r.template = defaultTemplate()
+	} else {
+		template, err := imaging.Decode(bytes.NewReader(templateData))
+		if err != nil {
+			return nil, fmt.Errorf("decode ogp template: %w", err)
+		}
+		r.template = imaging.Fill(template, Width, Height, imaging.Center, imaging.Lanczos)
+	}
+	return r, nil
+}
+
+// parseFont はフォントを読み込みます．日本語のフォントに多いフォントコレクションの場合は最初のフォントを使います
+func parseFont(data []byte) (*sfnt.Font, error) {
+	f, err := opentype.Parse(data)
+	if err == nil {
+		return f, nil
+	}
+	collection, collectionErr := opentype.ParseCollection(data)
+	if collectionErr != nil {
+		return nil, fmt.Errorf("parse ogp font: %w", err)
+	}
+	f, err = collection.Font(0)
+	if err != nil {
+		return nil, fmt.Errorf("parse ogp font collection: %w", err)
+	}
+	return f, nil
+}
+
+func defaultTemplate() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(frameColor), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(frame, frame, Width-frame, Height-frame), image.NewUniform(backgroundColor), image.Point{}, draw.Src)
+	return img
+}
+
+// Render はテンプレートにタイトルとタグ，ブログのタイトルを描いたPNG画像を返します
+// タイトルは4行まで折り返し，入り切らない部分とタグは省略します
+func (r *Renderer) Render(title string, tags []string) ([]byte, error) {
+	titleFace, err := opentype.NewFace(r.titleFont, &opentype.FaceOptions{Size: titleSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("render ogp title face: %w", err)
+	}
+	defer titleFace.Close()
+	tagFace, err := opentype.NewFace(r.textFont, &opentype.FaceOptions{Size: tagSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("render ogp tag face: %w", err)
+	}
+	defer tagFace.Close()
+	siteTitleFace, err := opentype.NewFace(r.textFont, &opentype.FaceOptions{Size: siteTitleSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("render ogp site title face: %w", err)
+	}
+	defer siteTitleFace.Close()
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), r.template, image.Point{}, draw.Src)
+
+	maxWidth := fixed.I(Width - 2*padding)
+	lines := wrap(titleFace, title, maxWidth, titleMaxLines)
+	// タイトルはタグとブログのタイトルを描く下端を除いた部分の中央に置く
+	top := fixed.I(Height-padding-tagLineHeight-titleLineHeight*len(lines)) / 2
+	for i, line := range lines {
+		baseline := top + fixed.I(titleLineHeight*i) + titleFace.Metrics().Ascent
+		drawString(img, titleFace, titleColor, line, fixed.I(padding), baseline)
+	}
+
+	tagLine := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagLine = append(tagLine, "#"+tag)
+	}
+	tagBaseline := fixed.I(Height - padding)
+	if len(tagLine) > 0 {
+		drawString(img, tagFace, tagColor, truncate(tagFace, strings.Join(tagLine, "  "), maxWidth), fixed.I(padding), tagBaseline-fixed.I(tagLineHeight))
+	}
+	if r.siteTitle != "" {
+		drawString(img, siteTitleFace, siteTitleColor, truncate(siteTitleFace, r.siteTitle, maxWidth), fixed.I(padding), tagBaseline)
+	}
+
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode ogp png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func drawString(dst draw.Image, face font.Face, c color.Color, s string, x, y fixed.Int26_6) {
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.Point26_6{X: x, Y: y},
+	}
+	d.DrawString(s)
+}
+
+// wrap は s を maxWidth に収まるように折り返し，maxLines 行を超える場合は最後の行を省略します
+// 空白を含む行は英単語を分けないように空白で折り返し，含まない行は日本語のように文字単位で折り返します
+func wrap(face font.Face, s string, maxWidth fixed.Int26_6, maxLines int) []string {
+	var lines []string
+	rest := []rune(strings.Join(strings.Fields(s), " "))
+	for len(rest) > 0 {
+		if len(lines) == maxLines-1 {
+			lines = append(lines, truncate(face, string(rest), maxWidth))
+			break
+		}
+		n := fit(face, rest, maxWidth)
+		if n < len(rest) {
+			if space := lastSpace(rest[:n+1]); space > 0 {
+				n = space
+			}
+		}
+		lines = append(lines, strings.TrimRightFunc(string(rest[:n]), unicode.IsSpace))
+		rest = []rune(strings.TrimLeftFunc(string(rest[n:]), unicode.IsSpace))
+	}
+	return lines
+}
+
+// fit は先頭から maxWidth に収まる文字数を返します．1文字も収まらない場合も1文字は含めます
+func fit(face font.Face, s []rune, maxWidth fixed.Int26_6) int {
+	for i := range s {
+		if font.MeasureString(face, string(s[:i+1])) > maxWidth {
+			if i == 0 {
+				return 1
+			}
+			return i
+		}
+	}
+	return len(s)
+}
+
+func lastSpace(s []rune) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+// truncate は s が maxWidth に収まらない場合は末尾を省略記号に置き換えます
+func truncate(face font.Face, s string, maxWidth fixed.Int26_6) string {
+	if font.MeasureString(face, s) <= maxWidth {
+		return s
+	}
+	const ellipsis = "…"
+	runes := []rune(s)
+	n := fit(face, runes, maxWidth-font.MeasureString(face, ellipsis))
+	return strings.TrimRightFunc(string(runes[:n]), unicode.IsSpace) + ellipsis
+}
@@ -0,0 +1,149 @@
+package ogp
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		tags  []string
+	}{
+		{
+			name:  "タイトルとタグを描いたPNG画像を作れる",
+			title: "Hello World",
+			tags:  []string{"go", "blog"},
+		},
+		{
+			name:  "タグが無くても作れる",
+			title: "Hello World",
+			tags:  nil,
+		},
+		{
+			name:  "長いタイトルでも作れる",
+			title: strings.Repeat("very long title ", 50),
+			tags:  []string{strings.Repeat("tag", 100)},
+		},
+	}
+	r, err := NewRenderer(nil, nil, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Render(tt.title, tt.tags)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			img, err := png.Decode(bytes.NewReader(got))
+			if err != nil {
+				t.Fatalf("Render() returned invalid png: %v", err)
+			}
+			if img.Bounds().Dx() != Width || img.Bounds().Dy() != Height {
+				t.Errorf("Render() size = %v, want = %vx%v", img.Bounds().Size(), Width, Height)
+			}
+		})
+	}
+}
+
+func TestNewRenderer(t *testing.T) {
+	tests := []struct {
+		name         string
+		fontData     []byte
+		templateData []byte
+		wantErr      bool
+	}{
+		{
+			name:         "フォントを指定できる",
+			fontData:     goregular.TTF,
+			templateData: nil,
+			wantErr:      false,
+		},
+		{
+			name:         "フォントではないデータの場合はエラーを返す",
+			fontData:     []byte("not a font"),
+			templateData: nil,
+			wantErr:      true,
+		},
+		{
+			name:         "画像ではないテンプレートの場合はエラーを返す",
+			fontData:     nil,
+			templateData: []byte("not an image"),
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRenderer(tt.fontData, tt.templateData, ""); (err != nil) != tt.wantErr {
+				t.Errorf("NewRenderer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: 32, DPI: 72})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer face.Close()
+	maxWidth := font.MeasureString(face, "aaaaaaaaaa")
+
+	tests := []struct {
+		name     string
+		s        string
+		maxLines int
+		want     []string
+	}{
+		{
+			name:     "収まる場合は折り返さない",
+			s:        "aaa aaa",
+			maxLines: 4,
+			want:     []string{"aaa aaa"},
+		},
+		{
+			name:     "空白で折り返す",
+			s:        "aaaa aaaa aaaa",
+			maxLines: 4,
+			want:     []string{"aaaa aaaa", "aaaa"},
+		},
+		{
+			name:     "空白が無い場合は文字単位で折り返す",
+			s:        strings.Repeat("a", 15),
+			maxLines: 4,
+			want:     []string{strings.Repeat("a", 10), strings.Repeat("a", 5)},
+		},
+		{
+			name:     "行数を超える場合は最後の行を省略する",
+			s:        strings.Repeat("a", 25),
+			maxLines: 2,
+			want:     []string{strings.Repeat("a", 10), strings.Repeat("a", 8) + "…"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrap(face, tt.s, maxWidth, tt.maxLines)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("wrap() = %q, want = %q", got, tt.want)
+			}
+			for _, line := range got {
+				if font.MeasureString(face, line) > maxWidth {
+					t.Errorf("wrap() line %q is wider than %v", line, fixed.Int26_6(maxWidth))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/log"
+)
+
+type postPublisher interface {
+	PublishScheduledPosts() (int, error)
+}
+
+// PublishScheduler は予約投稿を公開日時になったら公開するスケジューラーです
+type PublishScheduler struct {
+	postUC   postPublisher
+	interval time.Duration
+}
+
+func NewPublishScheduler(postUC postPublisher, interval time.Duration) *PublishScheduler {
+	return &PublishScheduler{
+		postUC:   postUC,
+		interval: interval,
+	}
+}
+
+// Run はctxがキャンセルされるまでintervalごとに予約投稿を公開します
+// 起動時に公開日時を過ぎている予約投稿があればすぐに公開します
+func (s *PublishScheduler) Run(ctx context.Context) {
+	ticker := flextime.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.publish()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PublishScheduler) publish() {
+	logger := log.GetLogger()
+	count, err := s.postUC.PublishScheduledPosts()
+	if err != nil {
+		logger.Errorf("publish scheduled posts: %v", err)
+		return
+	}
+	if count > 0 {
+		logger.Infof("published %d scheduled posts", count)
+	}
+}
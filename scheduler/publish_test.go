@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakePublisher struct {
+	called int
+	err    error
+}
+
+func (f *fakePublisher) PublishScheduledPosts() (int, error) {
+	f.called++
+	return 0, f.err
+}
+
+func TestPublishScheduler_Run(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "起動時に予約投稿の公開を行うこと",
+			err:  nil,
+		},
+		{
+			name: "公開に失敗しても停止しないこと",
+			err:  errors.New("dummy error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &fakePublisher{err: tt.err}
+			s := NewPublishScheduler(publisher, time.Hour)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			s.Run(ctx)
+
+			if publisher.called != 1 {
+				t.Errorf("Run() called = %d, want 1", publisher.called)
+			}
+		})
+	}
+}
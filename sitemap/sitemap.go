@@ -0,0 +1,67 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"github.com/masibw/blog-server/domain/dto"
+)
+
+const namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URLSet はURLを列挙するサイトマップです https://www.sitemaps.org/protocol.html
+type URLSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []*URL   `xml:"url"`
+}
+
+type URL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Index は複数のサイトマップを列挙するサイトマップインデックスです
+type Index struct {
+	XMLName  xml.Name   `xml:"sitemapindex"`
+	Xmlns    string     `xml:"xmlns,attr"`
+	Sitemaps []*Sitemap `xml:"sitemap"`
+}
+
+type Sitemap struct {
+	Loc string `xml:"loc"`
+}
+
+// NewURLSet はsiteURLを起点にサイトマップを作成します
+func NewURLSet(siteURL string, entries []*dto.SitemapEntryDTO) *URLSet {
+	urlSet := &URLSet{
+		Xmlns: namespace,
+		URLs:  make([]*URL, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		u := &URL{Loc: siteURL + entry.Path}
+		if !entry.LastModified.IsZero() {
+			u.LastMod = entry.LastModified.Format(time.RFC3339)
+		}
+		urlSet.URLs = append(urlSet.URLs, u)
+	}
+	return urlSet
+}
+
+// NewIndex は count 個に分割したサイトマップのインデックスを作成します
+func NewIndex(siteURL string, count int) *Index {
+	index := &Index{
+		Xmlns:    namespace,
+		Sitemaps: make([]*Sitemap, 0, count),
+	}
+	for page := 1; page <= count; page++ {
+		index.Sitemaps = append(index.Sitemaps, &Sitemap{Loc: PageURL(siteURL, page)})
+	}
+	return index
+}
+
+// PageURL は分割したサイトマップのURLを返します
+func PageURL(siteURL string, page int) string {
+	return siteURL + "/sitemaps/" + strconv.Itoa(page) + ".xml"
+}
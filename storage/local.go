@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+// tempFilePrefix は書き込み途中の一時ファイルの名前の先頭です
+const tempFilePrefix = ".upload-"
+
+// LocalStorage は画像をサーバーのファイルシステムに保存します
+// 署名付きURLはS3と同じくPUTでアップロードできるサーバー自身のURLです
+type LocalStorage struct {
+	dir       string
+	publicURL string
+	secret    []byte
+}
+
+// NewLocalStorage は dir に保存し，publicURL から配信する LocalStorage を作成します
+func NewLocalStorage(dir, publicURL string, secret []byte) *LocalStorage {
+	return &LocalStorage{
+		dir:       dir,
+		publicURL: publicURL,
+		secret:    secret,
+	}
+}
+
+// Dir は画像を保存しているディレクトリを返します
+func (s *LocalStorage) Dir() string {
+	return s.dir
+}
+
+func (s *LocalStorage) CreatePresignedURL(key, contentType string, expires time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", fmt.Errorf("presign local key=%v: %w", key, err)
+	}
+	expiresAt := strconv.FormatInt(flextime.Now().Add(expires).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("contentType", contentType)
+	query.Set("expires", expiresAt)
+	query.Set("signature", s.sign(key, contentType, expiresAt))
+	return s.URL(key) + "?" + query.Encode(), nil
+}
+
+// VerifyPresignedURL は CreatePresignedURL で作ったURLの署名と有効期限を検証します
+func (s *LocalStorage) VerifyPresignedURL(key, contentType, expiresAt, signature string) error {
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, contentType, expiresAt))) {
+		return fmt.Errorf("verify presigned url key=%v: %w", key, entity.ErrUploadSignatureInvalid)
+	}
+	unix, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil {
+		return fmt.Errorf("verify presigned url key=%v: %w", key, entity.ErrUploadSignatureInvalid)
+	}
+	if !flextime.Now().Before(time.Unix(unix, 0)) {
+		return fmt.Errorf("verify presigned url key=%v: %w", key, entity.ErrUploadURLExpired)
+	}
+	return nil
+}
+
+func (s *LocalStorage) sign(key, contentType, expiresAt string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + contentType + "\n" + expiresAt))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Put は一時ファイルに書き込んでから置き換えるので，書き込み途中のファイルが配信されることはありません
+func (s *LocalStorage) Put(key, contentType string, body io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("put local key=%v: %w", key, err)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("put local key=%v: %w", key, err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), tempFilePrefix+"*")
+	if err != nil {
+		return fmt.Errorf("put local key=%v: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("put local key=%v: %w", key, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("put local key=%v: %w", key, err)
+	}
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("put local key=%v: %w", key, err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("put local key=%v: %w", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("open local key=%v: %w", key, err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("open local key=%v: %w", key, entity.ErrImageObjectNotFound)
+		}
+		return nil, fmt.Errorf("open local key=%v: %w", key, err)
+	}
+	return f, nil
+}
+
+func (s *LocalStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("delete local key=%v: %w", key, err)
+	}
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete local key=%v: %w", key, err)
+	}
+	return nil
+}
+
+// List は書き込み途中の一時ファイルを除いて，保存先ディレクトリにあるファイルを返します
+func (s *LocalStorage) List() ([]*entity.StoredImage, error) {
+	var images []*entity.StoredImage
+	err := filepath.Walk(s.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// まだ1枚もアップロードしていなければディレクトリが無い
+			if path == s.dir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), tempFilePrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		images = append(images, &entity.StoredImage{
+			Key:          filepath.ToSlash(rel),
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list local dir=%v: %w", s.dir, err)
+	}
+	return images, nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+// path は保存先ディレクトリの外を指す key を拒否し，ファイルのパスを返します
+func (s *LocalStorage) path(key string) (string, error) {
+	if !entity.IsValidImageKey(key) {
+		return "", entity.ErrImageKeyInvalid
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
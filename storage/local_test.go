@@ -0,0 +1,226 @@
+package storage
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+func TestLocalStorage_CreatePresignedURL(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		name        string
+		key         string
+		contentType string
+		verifyAt    time.Time
+		tamperFn    func(query url.Values)
+		wantErr     error
+	}{
+		{
+			name:        "作成したURLの署名を検証できる",
+			key:         "2021/image.png",
+			contentType: "image/png",
+			verifyAt:    now.Add(30 * time.Second),
+			tamperFn:    func(query url.Values) {},
+			wantErr:     nil,
+		},
+		{
+			name:        "有効期限を過ぎるとErrUploadURLExpiredエラーが返る",
+			key:         "image.png",
+			contentType: "image/png",
+			verifyAt:    now.Add(time.Minute),
+			tamperFn:    func(query url.Values) {},
+			wantErr:     entity.ErrUploadURLExpired,
+		},
+		{
+			name:        "Content-Typeを書き換えるとErrUploadSignatureInvalidエラーが返る",
+			key:         "image.png",
+			contentType: "image/png",
+			verifyAt:    now,
+			tamperFn: func(query url.Values) {
+				query.Set("contentType", "text/html")
+			},
+			wantErr: entity.ErrUploadSignatureInvalid,
+		},
+		{
+			name:        "有効期限を書き換えるとErrUploadSignatureInvalidエラーが返る",
+			key:         "image.png",
+			contentType: "image/png",
+			verifyAt:    now.Add(time.Hour),
+			tamperFn: func(query url.Values) {
+				query.Set("expires", "9999999999")
+			},
+			wantErr: entity.ErrUploadSignatureInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewLocalStorage(t.TempDir(), "/api/v1/uploads", []byte("secret"))
+
+			flextime.Fix(now)
+			signedURL, err := s.CreatePresignedURL(tt.key, tt.contentType, time.Minute)
+			if err != nil {
+				t.Fatalf("CreatePresignedURL() error = %v", err)
+			}
+			u, err := url.Parse(signedURL)
+			if err != nil {
+				t.Fatalf("url.Parse() error = %v", err)
+			}
+			if u.Path != "/api/v1/uploads/"+tt.key {
+				t.Errorf("CreatePresignedURL() path = %v, want = %v", u.Path, "/api/v1/uploads/"+tt.key)
+			}
+			query := u.Query()
+			tt.tamperFn(query)
+
+			flextime.Fix(tt.verifyAt)
+			err = s.VerifyPresignedURL(tt.key, query.Get("contentType"), query.Get("expires"), query.Get("signature"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyPresignedURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLocalStorage_Put(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{
+			name:    "サブディレクトリを作って保存できる",
+			key:     "2021/01/image.png",
+			wantErr: nil,
+		},
+		{
+			name:    "保存先の外を指すkeyの時はErrImageKeyInvalidエラーが返る",
+			key:     "../image.png",
+			wantErr: entity.ErrImageKeyInvalid,
+		},
+		{
+			name:    "絶対パスのkeyの時はErrImageKeyInvalidエラーが返る",
+			key:     "/etc/image.png",
+			wantErr: entity.ErrImageKeyInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := NewLocalStorage(dir, "/api/v1/uploads", []byte("secret"))
+
+			err := s.Put(tt.key, "image/png", strings.NewReader("body"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Put() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			got, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(tt.key)))
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			if string(got) != "body" {
+				t.Errorf("Put() body = %v, want = %v", string(got), "body")
+			}
+
+			f, err := s.Open(tt.key)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			got, err = ioutil.ReadAll(f)
+			f.Close()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != "body" {
+				t.Errorf("Open() body = %v, want = %v", string(got), "body")
+			}
+
+			if err = s.Delete(tt.key); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err = os.Stat(filepath.Join(dir, filepath.FromSlash(tt.key))); !os.IsNotExist(err) {
+				t.Errorf("Delete() file still exists, err = %v", err)
+			}
+			// 存在しない画像の削除はエラーにしない
+			if err = s.Delete(tt.key); err != nil {
+				t.Errorf("Delete() error = %v", err)
+			}
+			if _, err = s.Open(tt.key); !errors.Is(err, entity.ErrImageObjectNotFound) {
+				t.Errorf("Open() error = %v, wantErr %v", err, entity.ErrImageObjectNotFound)
+			}
+		})
+	}
+}
+
+func TestLocalStorage_List(t *testing.T) {
+	tests := []struct {
+		name      string
+		prepareFn func(s *LocalStorage, dir string) error
+		wantKeys  []string
+	}{
+		{
+			name: "サブディレクトリのファイルも含めて返す",
+			prepareFn: func(s *LocalStorage, dir string) error {
+				if err := s.Put("image.png", "image/png", strings.NewReader("body")); err != nil {
+					return err
+				}
+				return s.Put("2021/01/image.png", "image/png", strings.NewReader("body"))
+			},
+			wantKeys: []string{"2021/01/image.png", "image.png"},
+		},
+		{
+			name: "書き込み途中の一時ファイルは返さない",
+			prepareFn: func(s *LocalStorage, dir string) error {
+				if err := s.Put("image.png", "image/png", strings.NewReader("body")); err != nil {
+					return err
+				}
+				return ioutil.WriteFile(filepath.Join(dir, tempFilePrefix+"123"), []byte("body"), 0644)
+			},
+			wantKeys: []string{"image.png"},
+		},
+		{
+			name: "保存先のディレクトリが無い場合は空を返す",
+			prepareFn: func(s *LocalStorage, dir string) error {
+				return os.Remove(dir)
+			},
+			wantKeys: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := NewLocalStorage(dir, "/api/v1/uploads", []byte("secret"))
+			if err := tt.prepareFn(s, dir); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := s.List()
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			var gotKeys []string
+			for _, image := range got {
+				gotKeys = append(gotKeys, image.Key)
+				if image.Size != int64(len("body")) {
+					t.Errorf("List() size = %v, want = %v", image.Size, len("body"))
+				}
+			}
+			if !reflect.DeepEqual(gotKeys, tt.wantKeys) {
+				t.Errorf("List() keys = %v, want = %v", gotKeys, tt.wantKeys)
+			}
+		})
+	}
+}
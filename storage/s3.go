@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/masibw/blog-server/config"
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+// S3Storage は画像をS3またはS3互換ストレージに保存します
+type S3Storage struct {
+	client    *s3.S3
+	uploader  *s3manager.Uploader
+	bucket    string
+	publicURL string
+}
+
+// NewS3Storage は cfg のバケットに保存する S3Storage を作成します
+// publicURL が空の場合はバケットのURLから画像のURLを作ります
+func NewS3Storage(cfg config.S3Config, publicURL string) (*S3Storage, error) {
+	awsConfig := &aws.Config{
+		Credentials: credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, ""),
+		Region:      aws.String(cfg.Region),
+	}
+	// MinIOなどはバケットをサブドメインではなくパスで指定する
+	if cfg.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.Endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("new s3 session: %w", err)
+	}
+
+	if publicURL == "" {
+		if cfg.Endpoint != "" {
+			publicURL = cfg.Endpoint + "/" + cfg.Bucket
+		} else {
+			publicURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", cfg.Bucket, cfg.Region)
+		}
+	}
+
+	return &S3Storage{
+		client:    s3.New(sess),
+		uploader:  s3manager.NewUploader(sess),
+		bucket:    cfg.Bucket,
+		publicURL: publicURL,
+	}, nil
+}
+
+func (s *S3Storage) CreatePresignedURL(key, contentType string, expires time.Duration) (string, error) {
+	req, _ := s.client.PutObjectRequest(&s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ACL:         aws.String("public-read"),
+		ContentType: aws.String(contentType),
+	})
+	url, err := req.Presign(expires)
+	if err != nil {
+		return "", fmt.Errorf("presign s3 key=%v: %w", key, err)
+	}
+	return url, nil
+}
+
+func (s *S3Storage) Put(key, contentType string, body io.Reader) error {
+	_, err := s.uploader.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ACL:         aws.String("public-read"),
+		ContentType: aws.String(contentType),
+		Body:        body,
+	})
+	if err != nil {
+		return fmt.Errorf("put s3 key=%v: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3Storage) Open(key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, fmt.Errorf("open s3 key=%v: %w", key, entity.ErrImageObjectNotFound)
+		}
+		return nil, fmt.Errorf("open s3 key=%v: %w", key, err)
+	}
+	return out.Body, nil
+}
+
+// Delete は存在しないキーを指定してもエラーにしません
+func (s *S3Storage) Delete(key string) error {
+	_, err := s.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("delete s3 key=%v: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3Storage) List() ([]*entity.StoredImage, error) {
+	var images []*entity.StoredImage
+	err := s.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			images = append(images, &entity.StoredImage{
+				Key:          aws.StringValue(object.Key),
+				Size:         aws.Int64Value(object.Size),
+				LastModified: aws.TimeValue(object.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list s3 bucket=%v: %w", s.bucket, err)
+	}
+	return images, nil
+}
+
+func (s *S3Storage) URL(key string) string {
+	return s.publicURL + "/" + key
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"github.com/masibw/blog-server/config"
+	"github.com/masibw/blog-server/domain/repository"
+)
+
+// NewImageStorage は設定に合わせて画像の保存先を作成します
+// ローカルに保存する場合は，アップロードを受け付けて配信するために LocalStorage も返します
+func NewImageStorage() (repository.ImageStorage, *LocalStorage, error) {
+	if config.ImageStorage() == config.ImageStorageLocal {
+		publicURL := config.ImagePublicURL()
+		if publicURL == "" {
+			publicURL = config.LocalImagePath
+		}
+		localStorage := NewLocalStorage(config.ImageStorageDir(), publicURL, config.ImageUploadSecret())
+		return localStorage, localStorage, nil
+	}
+	s3Storage, err := NewS3Storage(config.S3(), config.ImagePublicURL())
+	if err != nil {
+		return nil, nil, err
+	}
+	return s3Storage, nil, nil
+}
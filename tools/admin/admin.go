@@ -23,6 +23,7 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/masibw/blog-server/config"
 	"github.com/masibw/blog-server/database"
+	"github.com/masibw/blog-server/storage"
 
 	_ "github.com/golang-migrate/migrate/v4/database/mysql"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
@@ -31,11 +32,14 @@ import (
 	"gorm.io/driver/mysql"
 )
 
-// 管理者用のユーザーを作成するツール
+// 管理者用のユーザーを作成したり，ゴミ箱を空にしたり，使われていない画像を削除したりするツール
 func main() {
 	time.Local = time.FixedZone("JST", 9*60*60)
 
-	var mode = flag.String("mode", "create", "specify mode (create,delete) default is create")
+	var mode = flag.String("mode", "create", "specify mode (create,delete,purge,gc-images) default is create")
+	var days = flag.Int("days", 30, "purge items moved to the trash more than N days ago (purge mode only)")
+	var grace = flag.Duration("grace", 7*24*time.Hour, "keep unreferenced images uploaded within this period (gc-images mode only)")
+	var dryRun = flag.Bool("dry-run", false, "report unreferenced images without deleting them (gc-images mode only)")
 	flag.Parse()
 	m, err := migrate.New("file://"+os.Getenv("MIGRATION_FILE"), "mysql://"+config.PureDSN())
 	if err != nil {
@@ -48,15 +52,31 @@ func main() {
 
 	}
 
-	fmt.Print("mailAddress: ")
-	var mailAddress string
-	fmt.Scan(&mailAddress)
-
 	db, err := NewDB()
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if *mode == "purge" {
+		trashUC := usecase.NewTrashUseCase(database.NewPostRepository(db), database.NewTagRepository(db))
+		purgeTrash(trashUC, *days)
+		return
+	}
+
+	if *mode == "gc-images" {
+		imageStorage, _, err := storage.NewImageStorage()
+		if err != nil {
+			log.Fatal(err)
+		}
+		imageUC := usecase.NewImageUseCase(imageStorage, database.NewImageRepository(db), database.NewPostRepository(db), database.NewPostRevisionRepository(db), database.NewPageRepository(db), database.NewUserRepository(db))
+		gcImages(imageUC, *grace, *dryRun)
+		return
+	}
+
+	fmt.Print("mailAddress: ")
+	var mailAddress string
+	fmt.Scan(&mailAddress)
+
 	userRepository := database.NewUserRepository(db)
 	userUC := usecase.NewUserUseCase(userRepository)
 
@@ -99,6 +119,37 @@ func deleteAdmin(userUC *usecase.UserUseCase, mailAddress string) {
 	fmt.Println("admin user deleted successfully")
 }
 
+func purgeTrash(trashUC *usecase.TrashUseCase, days int) {
+	if days < 0 {
+		log.Fatalf("days must not be negative: %d", days)
+	}
+	postCount, tagCount, err := trashUC.Purge(days)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("purged %d posts and %d tags successfully\n", postCount, tagCount)
+}
+
+func gcImages(imageUC *usecase.ImageUseCase, grace time.Duration, dryRun bool) {
+	if grace < 0 {
+		log.Fatalf("grace must not be negative: %v", grace)
+	}
+	orphans, err := imageUC.DeleteOrphanedImages(grace, dryRun)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var size int64
+	for _, orphan := range orphans {
+		fmt.Printf("%s\t%d\t%s\n", orphan.Key, orphan.Size, orphan.LastModified.Format(time.RFC3339))
+		size += orphan.Size
+	}
+	if dryRun {
+		fmt.Printf("found %d unreferenced images (%d bytes), nothing deleted (dry run)\n", len(orphans), size)
+		return
+	}
+	fmt.Printf("deleted %d unreferenced images (%d bytes) successfully\n", len(orphans), size)
+}
+
 func NewDB() (db *gorm.DB, err error) {
 
 	db, err = gorm.Open(mysql.Open(config.DSN()), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
@@ -0,0 +1,12 @@
+package usecase
+
+// andCondition は条件にカーソルの条件を AND で加えます．元の params は書き換えません
+func andCondition(condition string, params []interface{}, extra string, extraParams []interface{}) (string, []interface{}) {
+	merged := make([]interface{}, 0, len(params)+len(extraParams))
+	merged = append(merged, params...)
+	merged = append(merged, extraParams...)
+	if condition == "" {
+		return extra, merged
+	}
+	return "(" + condition + ") AND " + extra, merged
+}
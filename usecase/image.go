@@ -1,48 +1,429 @@
 package usecase
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"io/ioutil"
+	"strings"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/constant"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/repository"
+	"github.com/masibw/blog-server/imageproc"
+	"github.com/masibw/blog-server/log"
 )
 
+// presignedURLExpiration は画像をアップロードするための署名付きURLの有効期間です
+const presignedURLExpiration = time.Minute
+
 type Image interface {
-	CreatePresignedURL(fileName, contentType *string) (string, error)
+	CreatePresignedURL(fileName, contentType *string, mailAddress string) (string, error)
+	ImageURL(fileName string) string
+	UploadImage(body io.Reader, mailAddress string) (*dto.ImageDTO, error)
+	GetImages(offset, pageSize int, query string) ([]*dto.ImageDTO, int, error)
+	GetImage(id string) (*dto.ImageDTO, error)
+	UpdateImage(id string, imageDTO *dto.ImageDTO) (*dto.ImageDTO, error)
+	DeleteImage(id string, force bool) (*dto.ImageDTO, error)
+	ScanImageUsages() (int, error)
+	DeleteOrphanedImages(gracePeriod time.Duration, dryRun bool) ([]*dto.StoredImageDTO, error)
 }
 
 type ImageUseCase struct {
+	imageStorage           repository.ImageStorage
+	imageRepository        repository.Image
+	postRepository         repository.Post
+	postRevisionRepository repository.PostRevision
+	pageRepository         repository.Page
+	userRepository         repository.User
+}
+
+func NewImageUseCase(imageStorage repository.ImageStorage, imageRepository repository.Image, postRepository repository.Post, postRevisionRepository repository.PostRevision, pageRepository repository.Page, userRepository repository.User) *ImageUseCase {
+	return &ImageUseCase{
+		imageStorage:           imageStorage,
+		imageRepository:        imageRepository,
+		postRepository:         postRepository,
+		postRevisionRepository: postRevisionRepository,
+		pageRepository:         pageRepository,
+		userRepository:         userRepository,
+	}
+}
+
+// CreatePresignedURL は画像を保存先へ直接アップロードするための署名付きURLを返し，画像をメディアライブラリに登録します
+// 大きさはアップロードが終わるまで分からないので，アップロード後に一覧などで初めて取得した時に保存先のファイルから読みます
+func (i *ImageUseCase) CreatePresignedURL(fileName, contentType *string, mailAddress string) (url string, err error) {
+	if !entity.IsValidImageKey(*fileName) {
+		err = fmt.Errorf("create presigned url file name=%v: %w", *fileName, entity.ErrImageKeyInvalid)
+		return
+	}
+	url, err = i.imageStorage.CreatePresignedURL(*fileName, *contentType, presignedURLExpiration)
+	if err != nil {
+		err = fmt.Errorf("create presigned url file name=%v: %w", *fileName, err)
+		return
+	}
+
+	// 同じキーへのアップロードは画像の上書きなので登録済みの画像をそのまま使う
+	_, err = i.imageRepository.FindByKey(*fileName)
+	if err == nil {
+		return
+	}
+	if !errors.Is(err, entity.ErrImageNotFound) {
+		err = fmt.Errorf("create presigned url file name=%v: %w", *fileName, err)
+		return
+	}
+	userID, err := i.uploaderID(mailAddress)
+	if err != nil {
+		err = fmt.Errorf("create presigned url: %w", err)
+		return
+	}
+	err = i.imageRepository.Create(entity.NewImage(*fileName, *contentType, userID))
+	if err != nil && !errors.Is(err, entity.ErrImageAlreadyExisted) {
+		err = fmt.Errorf("create presigned url file name=%v: %w", *fileName, err)
+		return
+	}
+	return url, nil
+}
+
+// ImageURL はアップロードした画像を公開しているURLを返します
+func (i *ImageUseCase) ImageURL(fileName string) string {
+	return i.imageStorage.URL(fileName)
+}
+
+// UploadImage は画像を縮小してWebPに変換した画像と合わせて保存し，srcsetに使えるURLの一覧を返します
+func (i *ImageUseCase) UploadImage(body io.Reader, mailAddress string) (imageDTO *dto.ImageDTO, err error) {
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		err = fmt.Errorf("upload image read: %w", err)
+		return
+	}
+	variants, err := imageproc.Process(data, constant.ImageWidths, constant.MaxImagePixels)
+	if err != nil {
+		err = fmt.Errorf("upload image: %w", err)
+		return
+	}
+	userID, err := i.uploaderID(mailAddress)
+	if err != nil {
+		err = fmt.Errorf("upload image: %w", err)
+		return
+	}
+
+	image := entity.NewImage("", "", userID)
+	for _, v := range variants {
+		key := image.VariantKey(v.Width, v.Ext)
+		if err = i.imageStorage.Put(key, v.ContentType, bytes.NewReader(v.Body)); err != nil {
+			err = fmt.Errorf("upload image key=%v: %w", key, err)
+			i.deleteObjects(image)
+			return
+		}
+		image.Variants = append(image.Variants, entity.NewImageVariant(image.ID, key, v.ContentType, v.Width, v.Height))
+		// 最も大きいJPEGかPNGの画像を代表にする
+		if v.ContentType != "image/webp" {
+			image.Key, image.ContentType, image.Width, image.Height = key, v.ContentType, v.Width, v.Height
+		}
+	}
+
+	if err = i.imageRepository.Create(image); err != nil {
+		err = fmt.Errorf("upload image: %w", err)
+		i.deleteObjects(image)
+		return
+	}
+	return image.ConvertToDTO(i.imageStorage.URL), nil
+}
+
+// uploaderID はアップロードしたユーザーのIDを返します．ユーザーが削除されている場合は nil を返します
+func (i *ImageUseCase) uploaderID(mailAddress string) (*string, error) {
+	if mailAddress == "" {
+		return nil, nil
+	}
+	user, err := i.userRepository.FindByMailAddress(mailAddress)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find uploader: %w", err)
+	}
+	return &user.ID, nil
 }
 
-func NewImageUseCase() *ImageUseCase {
-	return &ImageUseCase{}
-}
-
-func (i *ImageUseCase) CreatePresignedURL(fileName, contentType *string) (url string, err error) {
-	accessKey := os.Getenv("AWS_ACCESS_KEY")
-	secretKey := os.Getenv("AWS_SECRET_KEY")
-	region := os.Getenv("AWS_REGION")
-	bucketName := os.Getenv("AWS_S3_BUCKET_NAME")
-
-	creds := credentials.NewStaticCredentials(accessKey, secretKey, "")
-	sess := session.Must(session.NewSession(&aws.Config{
-		Credentials: creds,
-		Region:      aws.String(region),
-	}))
-
-	s3Client := s3.New(sess)
-	fmt.Println(*contentType)
-	req, _ := s3Client.PutObjectRequest(&s3.PutObjectInput{
-		Bucket:      aws.String(bucketName),
-		Key:         aws.String(*fileName),
-		ACL:         aws.String("public-read"),
-		ContentType: aws.String(*contentType),
-	})
-	url, err = req.Presign(time.Minute)
-	fmt.Println(url)
+// deleteObjects は保存した画像を削除します．削除に失敗しても元のエラーを返したいのでログに残すだけにします
+func (i *ImageUseCase) deleteObjects(image *entity.Image) {
+	logger := log.GetLogger()
+	for _, v := range image.Variants {
+		if err := i.imageStorage.Delete(v.Key); err != nil {
+			logger.Errorf("delete image variant key=%v: %v", v.Key, err)
+		}
+	}
+}
+
+// GetImages はメディアライブラリの画像を新しい順に返します．query を指定した場合はキーか代替テキストで絞り込みます
+func (i *ImageUseCase) GetImages(offset, pageSize int, query string) (imageDTOs []*dto.ImageDTO, count int, err error) {
+	images, err := i.imageRepository.FindAll(offset, pageSize, query)
+	if err != nil {
+		if errors.Is(err, entity.ErrImageNotFound) {
+			return []*dto.ImageDTO{}, 0, nil
+		}
+		err = fmt.Errorf("get images: %w", err)
+		return
+	}
+	count, err = i.imageRepository.Count(query)
+	if err != nil {
+		err = fmt.Errorf("count images: %w", err)
+		return
+	}
+
+	imageDTOs = make([]*dto.ImageDTO, 0, len(images))
+	for _, image := range images {
+		i.fillDimensions(image)
+		imageDTOs = append(imageDTOs, image.ConvertToDTO(i.imageStorage.URL))
+	}
 	return
 }
+
+func (i *ImageUseCase) GetImage(id string) (*dto.ImageDTO, error) {
+	image, err := i.imageRepository.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("get image id=%v: %w", id, err)
+	}
+	i.fillDimensions(image)
+	return image.ConvertToDTO(i.imageStorage.URL), nil
+}
+
+// fillDimensions は署名付きURLでアップロードして大きさが分からない画像について，保存先のファイルから大きさを読んで記録します
+// アップロードが終わっていない場合や読めなかった場合は大きさを0のままにします．一覧の取得などを失敗させたくないのでログに残すだけにします
+func (i *ImageUseCase) fillDimensions(image *entity.Image) {
+	if len(image.Variants) > 0 || (image.Width > 0 && image.Height > 0) {
+		return
+	}
+	logger := log.GetLogger()
+	body, err := i.imageStorage.Open(image.Key)
+	if err != nil {
+		if !errors.Is(err, entity.ErrImageObjectNotFound) {
+			logger.Errorf("fill image dimensions id=%v: %v", image.ID, err)
+		}
+		return
+	}
+	defer body.Close()
+
+	width, height, err := imageproc.Dimensions(body)
+	if err != nil {
+		logger.Warnf("fill image dimensions id=%v: %v", image.ID, err)
+		return
+	}
+	image.Width, image.Height = width, height
+	if err = i.imageRepository.Update(image); err != nil {
+		logger.Errorf("fill image dimensions id=%v: %v", image.ID, err)
+	}
+}
+
+// UpdateImage は画像の代替テキストと大きさを更新します
+func (i *ImageUseCase) UpdateImage(id string, imageDTO *dto.ImageDTO) (*dto.ImageDTO, error) {
+	image, err := i.imageRepository.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("update image id=%v: %w", id, err)
+	}
+	image.AltText = imageDTO.AltText
+	image.Width = imageDTO.Width
+	image.Height = imageDTO.Height
+	if err = i.imageRepository.Update(image); err != nil {
+		return nil, fmt.Errorf("update image id=%v: %w", id, err)
+	}
+	return image.ConvertToDTO(i.imageStorage.URL), nil
+}
+
+// DeleteImage は画像をメディアライブラリから削除してから保存先のファイルを削除します
+// 削除する前に画像を使っている投稿と固定ページを調べ直し，使われている場合は force でなければ
+// 使っている投稿と固定ページを含めた画像と ErrImageInUse を返します
+func (i *ImageUseCase) DeleteImage(id string, force bool) (*dto.ImageDTO, error) {
+	image, err := i.imageRepository.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("delete image id=%v: %w", id, err)
+	}
+	if err = i.scanUsages(image); err != nil {
+		return nil, fmt.Errorf("delete image id=%v: %w", id, err)
+	}
+	if image.InUse() && !force {
+		return image.ConvertToDTO(i.imageStorage.URL), fmt.Errorf("delete image id=%v: %w", id, entity.ErrImageInUse)
+	}
+
+	// 先にメディアライブラリから削除して，ファイルだけが消えた画像が残らないようにする
+	if err = i.imageRepository.Delete(id); err != nil {
+		return nil, fmt.Errorf("delete image id=%v: %w", id, err)
+	}
+	// 削除できなかったファイルはどこからも使われていないので gc-images で削除できる．画像の削除は済んでいるのでログに残すだけにする
+	logger := log.GetLogger()
+	for _, key := range image.Keys() {
+		if err = i.imageStorage.Delete(key); err != nil {
+			logger.Errorf("delete image key=%v: %v", key, err)
+		}
+	}
+	return nil, nil
+}
+
+// ScanImageUsages はすべての画像について，本文かサムネイルで使っている投稿と本文で使っている固定ページを調べ直して記録し，調べた画像の数を返します
+// 大きさが分からない画像は保存先のファイルから大きさを読みます
+func (i *ImageUseCase) ScanImageUsages() (int, error) {
+	images, err := i.imageRepository.FindAll(0, 0, "")
+	if err != nil {
+		if errors.Is(err, entity.ErrImageNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("scan image usages: %w", err)
+	}
+	for _, image := range images {
+		i.fillDimensions(image)
+		if err = i.scanUsages(image); err != nil {
+			return 0, fmt.Errorf("scan image usages: %w", err)
+		}
+	}
+	return len(images), nil
+}
+
+// scanUsages は画像のいずれかの公開URLを本文かサムネイルに含む投稿と本文に含む固定ページを探し，画像を使っているものとして記録します
+func (i *ImageUseCase) scanUsages(image *entity.Image) error {
+	urls := make([]string, 0, len(image.Variants)+1)
+	for _, key := range image.Keys() {
+		urls = append(urls, i.imageStorage.URL(key))
+	}
+
+	posts, err := i.postRepository.FindReferencing(urls)
+	if err != nil && !errors.Is(err, entity.ErrPostNotFound) {
+		return fmt.Errorf("scan usages image id=%v: %w", image.ID, err)
+	}
+	usages := make([]*entity.ImageUsage, 0, len(posts))
+	for _, post := range posts {
+		usages = append(usages, entity.NewImageUsage(image.ID, post.ID))
+	}
+
+	pages, err := i.pageRepository.FindReferencing(urls)
+	if err != nil && !errors.Is(err, entity.ErrPageNotFound) {
+		return fmt.Errorf("scan usages image id=%v: %w", image.ID, err)
+	}
+	pageUsages := make([]*entity.ImagePageUsage, 0, len(pages))
+	for _, page := range pages {
+		pageUsages = append(pageUsages, entity.NewImagePageUsage(image.ID, page.ID))
+	}
+
+	if err = i.imageRepository.ReplaceUsages(image.ID, usages, pageUsages); err != nil {
+		return fmt.Errorf("scan usages image id=%v: %w", image.ID, err)
+	}
+	image.Usages = usages
+	image.PageUsages = pageUsages
+	return nil
+}
+
+// DeleteOrphanedImages は保存先にあるファイルのうち，どの投稿とそのリビジョンの本文とサムネイルや固定ページの本文からも使われておらず，
+// gracePeriod より前に保存したものを削除して返します．dryRun の場合は削除せずに返すだけです
+// メディアライブラリの画像は幅や形式を変えた画像のどれかが使われていればすべて残し，すべて削除した場合は登録も削除します
+// 投稿では別のドメインの公開URLを使っていることもあるので，URLではなく "/" + キーを含むかで判断します
+func (i *ImageUseCase) DeleteOrphanedImages(gracePeriod time.Duration, dryRun bool) ([]*dto.StoredImageDTO, error) {
+	storedImages, err := i.imageStorage.List()
+	if err != nil {
+		return nil, fmt.Errorf("delete orphaned images: %w", err)
+	}
+	texts, err := i.referencingTexts()
+	if err != nil {
+		return nil, fmt.Errorf("delete orphaned images: %w", err)
+	}
+	images, err := i.imageRepository.FindAll(0, 0, "")
+	if err != nil && !errors.Is(err, entity.ErrImageNotFound) {
+		return nil, fmt.Errorf("delete orphaned images: %w", err)
+	}
+
+	deadline := flextime.Now().Add(-gracePeriod)
+	lastModified := make(map[string]time.Time, len(storedImages))
+	for _, storedImage := range storedImages {
+		lastModified[storedImage.Key] = storedImage.LastModified
+	}
+	inUse := func(key string) bool {
+		if modified, ok := lastModified[key]; ok && !modified.Before(deadline) {
+			return true
+		}
+		for _, text := range texts {
+			if strings.Contains(text, "/"+key) {
+				return true
+			}
+		}
+		return false
+	}
+
+	imageOf := make(map[string]*entity.Image)
+	orphanedImages := make(map[string]bool, len(images))
+	for _, image := range images {
+		orphaned := true
+		for _, key := range image.Keys() {
+			imageOf[key] = image
+			if inUse(key) {
+				orphaned = false
+			}
+		}
+		orphanedImages[image.ID] = orphaned
+	}
+
+	orphans := make([]*dto.StoredImageDTO, 0)
+	for _, storedImage := range storedImages {
+		if image, ok := imageOf[storedImage.Key]; ok {
+			if !orphanedImages[image.ID] {
+				continue
+			}
+		} else if inUse(storedImage.Key) {
+			continue
+		}
+		orphans = append(orphans, storedImage.ConvertToDTO(i.imageStorage.URL))
+	}
+	if dryRun {
+		return orphans, nil
+	}
+
+	for _, orphan := range orphans {
+		if err = i.imageStorage.Delete(orphan.Key); err != nil {
+			return nil, fmt.Errorf("delete orphaned images: %w", err)
+		}
+	}
+	for _, image := range images {
+		if !orphanedImages[image.ID] {
+			continue
+		}
+		if err = i.imageRepository.Delete(image.ID); err != nil && !errors.Is(err, entity.ErrImageNotFound) {
+			return nil, fmt.Errorf("delete orphaned images: %w", err)
+		}
+	}
+	return orphans, nil
+}
+
+// referencingTexts はゴミ箱にあるものも含めたすべての投稿とそのリビジョンの本文とサムネイルのURLと，すべての固定ページの本文を返します
+// リビジョンは元に戻せるので，そこで使っている画像を消すと戻した投稿の画像が表示されなくなります
+func (i *ImageUseCase) referencingTexts() ([]string, error) {
+	posts, err := i.postRepository.FindAll(0, 0, "", nil, nil, "", nil)
+	if err != nil && !errors.Is(err, entity.ErrPostNotFound) {
+		return nil, fmt.Errorf("find posts: %w", err)
+	}
+	deletedPosts, err := i.postRepository.FindDeleted()
+	if err != nil && !errors.Is(err, entity.ErrPostNotFound) {
+		return nil, fmt.Errorf("find deleted posts: %w", err)
+	}
+
+	revisions, err := i.postRevisionRepository.FindAll()
+	if err != nil && !errors.Is(err, entity.ErrPostRevisionNotFound) {
+		return nil, fmt.Errorf("find post revisions: %w", err)
+	}
+	pages, err := i.pageRepository.FindAll("", nil)
+	if err != nil && !errors.Is(err, entity.ErrPageNotFound) {
+		return nil, fmt.Errorf("find pages: %w", err)
+	}
+
+	texts := make([]string, 0, 2*(len(posts)+len(deletedPosts)+len(revisions))+len(pages))
+	for _, post := range append(posts, deletedPosts...) {
+		texts = append(texts, post.Content, post.ThumbnailURL)
+	}
+	for _, revision := range revisions {
+		texts = append(texts, revision.Content, revision.ThumbnailURL)
+	}
+	for _, page := range pages {
+		texts = append(texts, page.Content)
+	}
+	return texts, nil
+}
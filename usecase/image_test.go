@@ -0,0 +1,593 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+	"github.com/masibw/blog-server/util"
+)
+
+func TestImageUseCase_UploadImage(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	errDummy := errors.New("dummy error")
+	imageID := util.Generate(flextime.Now())
+	userID := "abcdefghijklmnopqrstuvwxy1"
+	smallKey := "images/" + imageID + "/320w.png"
+	largeKey := "images/" + imageID + "/400w.png"
+
+	img := image.NewNRGBA(image.Rect(0, 0, 400, 300))
+	for y := 0; y < 300; y++ {
+		for x := 0; x < 400; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		body              []byte
+		mailAddress       string
+		prepareMockRepoFn func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockUsers *mock_repository.MockUser)
+		wantURL           string
+		wantSrcset        string
+		wantErr           error
+	}{
+		{
+			name:        "縮小した画像を保存してsrcsetを返すこと",
+			body:        buf.Bytes(),
+			mailAddress: "test@example.com",
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockUsers *mock_repository.MockUser) {
+				mockUsers.EXPECT().FindByMailAddress("test@example.com").Return(&entity.User{ID: userID}, nil)
+				mockStorage.EXPECT().Put(smallKey, "image/png", gomock.Any()).Return(nil)
+				mockStorage.EXPECT().Put(largeKey, "image/png", gomock.Any()).Return(nil)
+				mockStorage.EXPECT().Put(gomock.Any(), "image/webp", gomock.Any()).Return(nil).AnyTimes()
+				mockStorage.EXPECT().URL(gomock.Any()).DoAndReturn(func(key string) string {
+					return "https://example.com/" + key
+				}).AnyTimes()
+				mockImages.EXPECT().Create(gomock.Any()).DoAndReturn(func(image *entity.Image) error {
+					if image.Key != largeKey || image.Width != 400 || image.Height != 300 || *image.UserID != userID {
+						t.Errorf("Create() image = %+v", image)
+					}
+					return nil
+				})
+			},
+			wantURL:    "https://example.com/" + largeKey,
+			wantSrcset: "https://example.com/" + smallKey + " 320w, https://example.com/" + largeKey + " 400w",
+			wantErr:    nil,
+		},
+		{
+			name: "画像でない場合はErrImageTypeUnsupportedエラーを返すこと",
+			body: []byte("not an image"),
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockUsers *mock_repository.MockUser) {
+			},
+			wantErr: entity.ErrImageTypeUnsupported,
+		},
+		{
+			name: "保存に失敗した場合は保存済みの画像を削除してエラーを返すこと",
+			body: buf.Bytes(),
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockUsers *mock_repository.MockUser) {
+				gomock.InOrder(
+					mockStorage.EXPECT().Put(smallKey, "image/png", gomock.Any()).Return(nil),
+					mockStorage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(errDummy),
+					mockStorage.EXPECT().Delete(smallKey).Return(nil),
+				)
+			},
+			wantErr: errDummy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ms := mock_repository.NewMockImageStorage(ctrl)
+			mi := mock_repository.NewMockImage(ctrl)
+			mu := mock_repository.NewMockUser(ctrl)
+			tt.prepareMockRepoFn(ms, mi, mu)
+			i := &ImageUseCase{
+				imageStorage:    ms,
+				imageRepository: mi,
+				userRepository:  mu,
+			}
+
+			got, err := i.UploadImage(bytes.NewReader(tt.body), tt.mailAddress)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UploadImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.URL != tt.wantURL {
+				t.Errorf("UploadImage() url = %v, want = %v", got.URL, tt.wantURL)
+			}
+			if got.Srcset != tt.wantSrcset {
+				t.Errorf("UploadImage() srcset = %v, want = %v", got.Srcset, tt.wantSrcset)
+			}
+		})
+	}
+}
+
+func TestImageUseCase_CreatePresignedURL(t *testing.T) {
+	errDummy := errors.New("dummy error")
+	userID := "abcdefghijklmnopqrstuvwxy1"
+
+	tests := []struct {
+		name              string
+		fileName          string
+		prepareMockRepoFn func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockUsers *mock_repository.MockUser)
+		wantErr           error
+	}{
+		{
+			name:     "署名付きURLを返して画像を登録すること",
+			fileName: "image.png",
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockUsers *mock_repository.MockUser) {
+				mockStorage.EXPECT().CreatePresignedURL("image.png", "image/png", presignedURLExpiration).Return("https://example.com/signed", nil)
+				mockImages.EXPECT().FindByKey("image.png").Return(nil, entity.ErrImageNotFound)
+				mockUsers.EXPECT().FindByMailAddress("test@example.com").Return(&entity.User{ID: userID}, nil)
+				mockImages.EXPECT().Create(gomock.Any()).DoAndReturn(func(image *entity.Image) error {
+					if image.Key != "image.png" || image.ContentType != "image/png" || *image.UserID != userID {
+						t.Errorf("Create() image = %+v", image)
+					}
+					return nil
+				})
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "登録済みの画像の場合は登録し直さないこと",
+			fileName: "image.png",
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockUsers *mock_repository.MockUser) {
+				mockStorage.EXPECT().CreatePresignedURL("image.png", "image/png", presignedURLExpiration).Return("https://example.com/signed", nil)
+				mockImages.EXPECT().FindByKey("image.png").Return(&entity.Image{ID: "abcdefghijklmnopqrstuvwxy2", Key: "image.png"}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "保存先の外を指すファイル名の場合はErrImageKeyInvalidエラーを返すこと",
+			fileName: "../image.png",
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockUsers *mock_repository.MockUser) {
+			},
+			wantErr: entity.ErrImageKeyInvalid,
+		},
+		{
+			name:     "登録に失敗した場合はエラーを返すこと",
+			fileName: "image.png",
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockUsers *mock_repository.MockUser) {
+				mockStorage.EXPECT().CreatePresignedURL("image.png", "image/png", presignedURLExpiration).Return("https://example.com/signed", nil)
+				mockImages.EXPECT().FindByKey("image.png").Return(nil, entity.ErrImageNotFound)
+				mockUsers.EXPECT().FindByMailAddress("test@example.com").Return(nil, entity.ErrUserNotFound)
+				mockImages.EXPECT().Create(gomock.Any()).Return(errDummy)
+			},
+			wantErr: errDummy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ms := mock_repository.NewMockImageStorage(ctrl)
+			mi := mock_repository.NewMockImage(ctrl)
+			mu := mock_repository.NewMockUser(ctrl)
+			tt.prepareMockRepoFn(ms, mi, mu)
+			i := &ImageUseCase{
+				imageStorage:    ms,
+				imageRepository: mi,
+				userRepository:  mu,
+			}
+
+			fileName, contentType := tt.fileName, "image/png"
+			_, err := i.CreatePresignedURL(&fileName, &contentType, "test@example.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreatePresignedURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImageUseCase_GetImage(t *testing.T) {
+	imageID := "abcdefghijklmnopqrstuvwxy1"
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 400, 300))); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		image             *entity.Image
+		prepareMockRepoFn func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage)
+		wantWidth         int
+		wantHeight        int
+	}{
+		{
+			name:  "大きさが分からない画像は保存先のファイルから大きさを読んで記録すること",
+			image: &entity.Image{ID: imageID, Key: "image.png"},
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage) {
+				mockStorage.EXPECT().Open("image.png").Return(ioutil.NopCloser(bytes.NewReader(buf.Bytes())), nil)
+				mockImages.EXPECT().Update(gomock.Any()).DoAndReturn(func(image *entity.Image) error {
+					if image.Width != 400 || image.Height != 300 {
+						t.Errorf("Update() size = %dx%d, want = 400x300", image.Width, image.Height)
+					}
+					return nil
+				})
+			},
+			wantWidth:  400,
+			wantHeight: 300,
+		},
+		{
+			name:  "アップロードが終わっていない画像は大きさを0のままにすること",
+			image: &entity.Image{ID: imageID, Key: "image.png"},
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage) {
+				mockStorage.EXPECT().Open("image.png").Return(nil, entity.ErrImageObjectNotFound)
+			},
+			wantWidth:  0,
+			wantHeight: 0,
+		},
+		{
+			name:  "大きさが分かっている画像は保存先のファイルを読まないこと",
+			image: &entity.Image{ID: imageID, Key: "image.png", Width: 640, Height: 480},
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage) {
+			},
+			wantWidth:  640,
+			wantHeight: 480,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ms := mock_repository.NewMockImageStorage(ctrl)
+			ms.EXPECT().URL(gomock.Any()).DoAndReturn(func(key string) string {
+				return "https://example.com/" + key
+			}).AnyTimes()
+			mi := mock_repository.NewMockImage(ctrl)
+			mi.EXPECT().FindByID(imageID).Return(tt.image, nil)
+			tt.prepareMockRepoFn(ms, mi)
+			i := &ImageUseCase{
+				imageStorage:    ms,
+				imageRepository: mi,
+			}
+
+			got, err := i.GetImage(imageID)
+			if err != nil {
+				t.Fatalf("GetImage() error = %v", err)
+			}
+			if got.Width != tt.wantWidth || got.Height != tt.wantHeight {
+				t.Errorf("GetImage() size = %dx%d, want = %dx%d", got.Width, got.Height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestImageUseCase_DeleteImage(t *testing.T) {
+	errDummy := errors.New("dummy error")
+	imageID := "abcdefghijklmnopqrstuvwxy1"
+	postID := "abcdefghijklmnopqrstuvwxy2"
+	pageID := "abcdefghijklmnopqrstuvwxy5"
+
+	newImage := func() *entity.Image {
+		return &entity.Image{
+			ID:  imageID,
+			Key: "images/" + imageID + "/640w.jpg",
+			Variants: []*entity.ImageVariant{
+				{ID: "abcdefghijklmnopqrstuvwxy3", ImageID: imageID, Key: "images/" + imageID + "/320w.jpg"},
+				{ID: "abcdefghijklmnopqrstuvwxy4", ImageID: imageID, Key: "images/" + imageID + "/640w.jpg"},
+			},
+		}
+	}
+	urls := []string{
+		"https://example.com/images/" + imageID + "/640w.jpg",
+		"https://example.com/images/" + imageID + "/320w.jpg",
+	}
+
+	tests := []struct {
+		name              string
+		force             bool
+		prepareMockRepoFn func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage)
+		wantUsedBy        []string
+		wantUsedByPages   []string
+		wantErr           error
+	}{
+		{
+			name:  "使われていない画像を保存先ごと削除すること",
+			force: false,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage) {
+				mockImages.EXPECT().FindByID(imageID).Return(newImage(), nil)
+				mockPosts.EXPECT().FindReferencing(urls).Return(nil, entity.ErrPostNotFound)
+				mockPages.EXPECT().FindReferencing(urls).Return(nil, entity.ErrPageNotFound)
+				mockImages.EXPECT().ReplaceUsages(imageID, []*entity.ImageUsage{}, []*entity.ImagePageUsage{}).Return(nil)
+				gomock.InOrder(
+					mockImages.EXPECT().Delete(imageID).Return(nil),
+					mockStorage.EXPECT().Delete("images/"+imageID+"/640w.jpg").Return(nil),
+					mockStorage.EXPECT().Delete("images/"+imageID+"/320w.jpg").Return(nil),
+				)
+			},
+			wantUsedBy: nil,
+			wantErr:    nil,
+		},
+		{
+			name:  "使われている画像の場合は使っている投稿とErrImageInUseエラーを返すこと",
+			force: false,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage) {
+				mockImages.EXPECT().FindByID(imageID).Return(newImage(), nil)
+				mockPosts.EXPECT().FindReferencing(urls).Return([]*entity.Post{{ID: postID}}, nil)
+				mockPages.EXPECT().FindReferencing(urls).Return(nil, entity.ErrPageNotFound)
+				mockImages.EXPECT().ReplaceUsages(imageID, gomock.Len(1), []*entity.ImagePageUsage{}).Return(nil)
+			},
+			wantUsedBy: []string{postID},
+			wantErr:    entity.ErrImageInUse,
+		},
+		{
+			name:  "固定ページだけで使われている画像の場合も使っている固定ページとErrImageInUseエラーを返すこと",
+			force: false,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage) {
+				mockImages.EXPECT().FindByID(imageID).Return(newImage(), nil)
+				mockPosts.EXPECT().FindReferencing(urls).Return(nil, entity.ErrPostNotFound)
+				mockPages.EXPECT().FindReferencing(urls).Return([]*entity.Page{{ID: pageID}}, nil)
+				mockImages.EXPECT().ReplaceUsages(imageID, []*entity.ImageUsage{}, gomock.Len(1)).Return(nil)
+			},
+			wantUsedBy:      nil,
+			wantUsedByPages: []string{pageID},
+			wantErr:         entity.ErrImageInUse,
+		},
+		{
+			name:  "forceの場合は使われている画像も削除すること",
+			force: true,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage) {
+				mockImages.EXPECT().FindByID(imageID).Return(newImage(), nil)
+				mockPosts.EXPECT().FindReferencing(urls).Return([]*entity.Post{{ID: postID}}, nil)
+				mockPages.EXPECT().FindReferencing(urls).Return(nil, entity.ErrPageNotFound)
+				mockImages.EXPECT().ReplaceUsages(imageID, gomock.Len(1), []*entity.ImagePageUsage{}).Return(nil)
+				mockStorage.EXPECT().Delete(gomock.Any()).Return(nil).Times(2)
+				mockImages.EXPECT().Delete(imageID).Return(nil)
+			},
+			wantUsedBy: nil,
+			wantErr:    nil,
+		},
+		{
+			name:  "画像が存在しない場合はErrImageNotFoundエラーを返すこと",
+			force: false,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage) {
+				mockImages.EXPECT().FindByID(imageID).Return(nil, entity.ErrImageNotFound)
+			},
+			wantUsedBy: nil,
+			wantErr:    entity.ErrImageNotFound,
+		},
+		{
+			name:  "メディアライブラリからの削除に失敗した場合は保存先から削除せずにエラーを返すこと",
+			force: false,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage) {
+				mockImages.EXPECT().FindByID(imageID).Return(newImage(), nil)
+				mockPosts.EXPECT().FindReferencing(urls).Return(nil, entity.ErrPostNotFound)
+				mockPages.EXPECT().FindReferencing(urls).Return(nil, entity.ErrPageNotFound)
+				mockImages.EXPECT().ReplaceUsages(imageID, []*entity.ImageUsage{}, []*entity.ImagePageUsage{}).Return(nil)
+				mockImages.EXPECT().Delete(imageID).Return(errDummy)
+			},
+			wantUsedBy: nil,
+			wantErr:    errDummy,
+		},
+		{
+			name:  "メディアライブラリから削除した後は保存先からの削除に失敗してもエラーを返さないこと",
+			force: false,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage) {
+				mockImages.EXPECT().FindByID(imageID).Return(newImage(), nil)
+				mockPosts.EXPECT().FindReferencing(urls).Return(nil, entity.ErrPostNotFound)
+				mockPages.EXPECT().FindReferencing(urls).Return(nil, entity.ErrPageNotFound)
+				mockImages.EXPECT().ReplaceUsages(imageID, []*entity.ImageUsage{}, []*entity.ImagePageUsage{}).Return(nil)
+				mockImages.EXPECT().Delete(imageID).Return(nil)
+				mockStorage.EXPECT().Delete("images/" + imageID + "/640w.jpg").Return(errDummy)
+				mockStorage.EXPECT().Delete("images/" + imageID + "/320w.jpg").Return(nil)
+			},
+			wantUsedBy: nil,
+			wantErr:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ms := mock_repository.NewMockImageStorage(ctrl)
+			ms.EXPECT().URL(gomock.Any()).DoAndReturn(func(key string) string {
+				return "https://example.com/" + key
+			}).AnyTimes()
+			mi := mock_repository.NewMockImage(ctrl)
+			mp := mock_repository.NewMockPost(ctrl)
+			mpg := mock_repository.NewMockPage(ctrl)
+			tt.prepareMockRepoFn(ms, mi, mp, mpg)
+			i := &ImageUseCase{
+				imageStorage:    ms,
+				imageRepository: mi,
+				postRepository:  mp,
+				pageRepository:  mpg,
+			}
+
+			got, err := i.DeleteImage(imageID, tt.force)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var usedBy, usedByPages []string
+			if got != nil {
+				usedBy, usedByPages = got.UsedBy, got.UsedByPages
+			}
+			if diff := cmp.Diff(tt.wantUsedBy, usedBy, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("DeleteImage() usedBy mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantUsedByPages, usedByPages, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("DeleteImage() usedByPages mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImageUseCase_DeleteOrphanedImages(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	errDummy := errors.New("dummy error")
+	old := flextime.Now().Add(-48 * time.Hour)
+	usedImageID := "abcdefghijklmnopqrstuvwxy1"
+	unusedImageID := "abcdefghijklmnopqrstuvwxy2"
+
+	storedImages := []*entity.StoredImage{
+		{Key: "referenced.png", Size: 1, LastModified: old},
+		{Key: "thumbnail.png", Size: 2, LastModified: old},
+		{Key: "recent.png", Size: 3, LastModified: flextime.Now().Add(-time.Hour)},
+		{Key: "orphan.png", Size: 4, LastModified: old},
+		{Key: "images/" + usedImageID + "/320w.jpg", Size: 5, LastModified: old},
+		{Key: "images/" + usedImageID + "/640w.jpg", Size: 6, LastModified: old},
+		{Key: "images/" + unusedImageID + "/320w.jpg", Size: 7, LastModified: old},
+		{Key: "page.png", Size: 8, LastModified: old},
+		{Key: "revision.png", Size: 9, LastModified: old},
+	}
+	images := []*entity.Image{
+		{
+			ID:  usedImageID,
+			Key: "images/" + usedImageID + "/640w.jpg",
+			Variants: []*entity.ImageVariant{
+				{Key: "images/" + usedImageID + "/320w.jpg"},
+				{Key: "images/" + usedImageID + "/640w.jpg"},
+			},
+		},
+		{
+			ID:  unusedImageID,
+			Key: "images/" + unusedImageID + "/320w.jpg",
+		},
+	}
+	posts := []*entity.Post{
+		{Content: "![](https://cdn.example.com/referenced.png)\n![](https://cdn.example.com/images/" + usedImageID + "/320w.jpg)"},
+	}
+	deletedPosts := []*entity.Post{
+		{ThumbnailURL: "https://old.example.com/thumbnail.png"},
+	}
+	revisions := []*entity.PostRevision{
+		{Content: "![](https://cdn.example.com/revision.png)"},
+	}
+	pages := []*entity.Page{
+		{Content: "![](https://cdn.example.com/page.png)"},
+	}
+	wantOrphans := []*dto.StoredImageDTO{
+		{Key: "orphan.png", URL: "https://example.com/orphan.png", Size: 4, LastModified: old},
+		{Key: "images/" + unusedImageID + "/320w.jpg", URL: "https://example.com/images/" + unusedImageID + "/320w.jpg", Size: 7, LastModified: old},
+	}
+
+	tests := []struct {
+		name              string
+		dryRun            bool
+		prepareMockRepoFn func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage, mockRevisions *mock_repository.MockPostRevision)
+		want              []*dto.StoredImageDTO
+		wantErr           bool
+	}{
+		{
+			name:   "dryRunの場合は使われていない古い画像を削除せずに返すこと",
+			dryRun: true,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage, mockRevisions *mock_repository.MockPostRevision) {
+				mockStorage.EXPECT().List().Return(storedImages, nil)
+				mockPosts.EXPECT().FindAll(0, 0, "", nil, nil, "", nil).Return(posts, nil)
+				mockPosts.EXPECT().FindDeleted().Return(deletedPosts, nil)
+				mockRevisions.EXPECT().FindAll().Return(revisions, nil)
+				mockPages.EXPECT().FindAll("", nil).Return(pages, nil)
+				mockImages.EXPECT().FindAll(0, 0, "").Return(images, nil)
+			},
+			want:    wantOrphans,
+			wantErr: false,
+		},
+		{
+			name:   "使われていない古い画像とメディアライブラリの登録を削除すること",
+			dryRun: false,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage, mockRevisions *mock_repository.MockPostRevision) {
+				mockStorage.EXPECT().List().Return(storedImages, nil)
+				mockPosts.EXPECT().FindAll(0, 0, "", nil, nil, "", nil).Return(posts, nil)
+				mockPosts.EXPECT().FindDeleted().Return(deletedPosts, nil)
+				mockRevisions.EXPECT().FindAll().Return(revisions, nil)
+				mockPages.EXPECT().FindAll("", nil).Return(pages, nil)
+				mockImages.EXPECT().FindAll(0, 0, "").Return(images, nil)
+				mockStorage.EXPECT().Delete("orphan.png").Return(nil)
+				mockStorage.EXPECT().Delete("images/" + unusedImageID + "/320w.jpg").Return(nil)
+				mockImages.EXPECT().Delete(unusedImageID).Return(nil)
+			},
+			want:    wantOrphans,
+			wantErr: false,
+		},
+		{
+			name:   "投稿が無い場合は古い画像をすべて削除すること",
+			dryRun: false,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage, mockRevisions *mock_repository.MockPostRevision) {
+				mockStorage.EXPECT().List().Return(storedImages[3:4], nil)
+				mockPosts.EXPECT().FindAll(0, 0, "", nil, nil, "", nil).Return(nil, entity.ErrPostNotFound)
+				mockPosts.EXPECT().FindDeleted().Return(nil, entity.ErrPostNotFound)
+				mockRevisions.EXPECT().FindAll().Return(nil, entity.ErrPostRevisionNotFound)
+				mockPages.EXPECT().FindAll("", nil).Return(nil, entity.ErrPageNotFound)
+				mockImages.EXPECT().FindAll(0, 0, "").Return(nil, entity.ErrImageNotFound)
+				mockStorage.EXPECT().Delete("orphan.png").Return(nil)
+			},
+			want:    wantOrphans[:1],
+			wantErr: false,
+		},
+		{
+			name:   "保存先の一覧の取得に失敗した場合はエラーを返すこと",
+			dryRun: false,
+			prepareMockRepoFn: func(mockStorage *mock_repository.MockImageStorage, mockImages *mock_repository.MockImage, mockPosts *mock_repository.MockPost, mockPages *mock_repository.MockPage, mockRevisions *mock_repository.MockPostRevision) {
+				mockStorage.EXPECT().List().Return(nil, errDummy)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ms := mock_repository.NewMockImageStorage(ctrl)
+			ms.EXPECT().URL(gomock.Any()).DoAndReturn(func(key string) string {
+				return "https://example.com/" + key
+			}).AnyTimes()
+			mi := mock_repository.NewMockImage(ctrl)
+			mp := mock_repository.NewMockPost(ctrl)
+			mpg := mock_repository.NewMockPage(ctrl)
+			mpr := mock_repository.NewMockPostRevision(ctrl)
+			tt.prepareMockRepoFn(ms, mi, mp, mpg, mpr)
+			i := &ImageUseCase{
+				imageStorage:           ms,
+				imageRepository:        mi,
+				postRepository:         mp,
+				postRevisionRepository: mpr,
+				pageRepository:         mpg,
+			}
+
+			got, err := i.DeleteOrphanedImages(24*time.Hour, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteOrphanedImages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DeleteOrphanedImages() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/repository"
+)
+
+type PageUseCase struct {
+	pageRepository repository.Page
+}
+
+func NewPageUseCase(pageRepository repository.Page) *PageUseCase {
+	return &PageUseCase{
+		pageRepository: pageRepository,
+	}
+}
+
+// childPagesCondition は path の固定ページの1つ下の階層の固定ページに絞り込む条件です
+// パスに使える文字には LIKE で特別な意味を持つものがないのでそのまま使えます
+func childPagesCondition(path string) (string, []interface{}) {
+	return "pages.path LIKE ? AND pages.path NOT LIKE ?", []interface{}{path + "/%", path + "/%/%"}
+}
+
+func (p *PageUseCase) StorePage(pageDTO *dto.PageDTO) (*dto.PageDTO, error) {
+	path, err := entity.NormalizePagePath(pageDTO.Path)
+	if err != nil {
+		return nil, fmt.Errorf("store page: %w", err)
+	}
+	page := entity.NewPage(pageDTO.Title, pageDTO.Content, path, *pageDTO.IsDraft)
+	if err = p.validateParent(page); err != nil {
+		return nil, fmt.Errorf("store page: %w", err)
+	}
+	if err = p.pageRepository.Store(page); err != nil {
+		return nil, fmt.Errorf("store page path=%v: %w", path, err)
+	}
+	return page.ConvertToDTO(), nil
+}
+
+// GetPages は固定ページを本文を含めずにパスの順に返します
+// isDraft を指定した場合は下書きかどうかで，parent を指定した場合はその1つ下の階層の固定ページに絞り込みます
+func (p *PageUseCase) GetPages(isDraft *bool, parent string) ([]*dto.PageDTO, error) {
+	var condition string
+	var params []interface{}
+	if isDraft != nil {
+		condition = "pages.is_draft = ?"
+		params = append(params, *isDraft)
+	}
+	if parent != "" {
+		parentPath, err := entity.NormalizePagePath(parent)
+		if err != nil {
+			return nil, fmt.Errorf("get pages: %w", err)
+		}
+		childCondition, childParams := childPagesCondition(parentPath)
+		condition, params = andCondition(condition, params, childCondition, childParams)
+	}
+
+	pages, err := p.pageRepository.FindAll(condition, params)
+	if err != nil {
+		return nil, fmt.Errorf("get pages: %w", err)
+	}
+	return convertPagesToDTO(pages), nil
+}
+
+// GetPage はパスの固定ページと，その1つ下の階層にある公開済みの固定ページを返します
+// isMarkdown が false の場合は本文をHTMLにします
+func (p *PageUseCase) GetPage(path string, isMarkdown bool) (pageDTO *dto.PageDTO, children []*dto.PageDTO, err error) {
+	path, err = entity.NormalizePagePath(path)
+	if err != nil {
+		err = fmt.Errorf("get page: %w", entity.ErrPageNotFound)
+		return
+	}
+	var page *entity.Page
+	page, err = p.pageRepository.FindByPath(path)
+	if err != nil {
+		err = fmt.Errorf("get page: %w", err)
+		return
+	}
+	if !isMarkdown {
+		page.ConvertContentToHTML()
+	}
+
+	condition, params := childPagesCondition(path)
+	condition, params = andCondition(condition, params, "pages.is_draft = ?", []interface{}{false})
+	var childPages []*entity.Page
+	childPages, err = p.pageRepository.FindAll(condition, params)
+	if err != nil && !errors.Is(err, entity.ErrPageNotFound) {
+		err = fmt.Errorf("get page children path=%v: %w", path, err)
+		return
+	}
+	return page.ConvertToDTO(), convertPagesToDTO(childPages), nil
+}
+
+// UpdatePage は固定ページを更新します．子ページがある固定ページのパスは変更できません
+func (p *PageUseCase) UpdatePage(pageDTO *dto.PageDTO) (*dto.PageDTO, error) {
+	page, err := p.pageRepository.FindByID(pageDTO.ID)
+	if err != nil {
+		return nil, fmt.Errorf("update page id=%v: %w", pageDTO.ID, err)
+	}
+	path, err := entity.NormalizePagePath(pageDTO.Path)
+	if err != nil {
+		return nil, fmt.Errorf("update page id=%v: %w", pageDTO.ID, err)
+	}
+
+	if path != page.Path {
+		if err = p.ensureNoChildren(page); err != nil {
+			return nil, fmt.Errorf("update page id=%v: %w", pageDTO.ID, err)
+		}
+	}
+
+	page.Title = pageDTO.Title
+	page.Content = pageDTO.Content
+	page.Path = path
+	page.IsDraft = *pageDTO.IsDraft
+	if err = p.validateParent(page); err != nil {
+		return nil, fmt.Errorf("update page id=%v: %w", pageDTO.ID, err)
+	}
+	if err = p.pageRepository.Update(page); err != nil {
+		return nil, fmt.Errorf("update page id=%v: %w", pageDTO.ID, err)
+	}
+	return page.ConvertToDTO(), nil
+}
+
+// DeletePage は固定ページを削除します．子ページがある固定ページは削除できません
+func (p *PageUseCase) DeletePage(id string) error {
+	page, err := p.pageRepository.FindByID(id)
+	if err != nil {
+		return fmt.Errorf("delete page id=%v: %w", id, err)
+	}
+	if err = p.ensureNoChildren(page); err != nil {
+		return fmt.Errorf("delete page id=%v: %w", id, err)
+	}
+	if err = p.pageRepository.Delete(id); err != nil {
+		return fmt.Errorf("delete page id=%v: %w", id, err)
+	}
+	return nil
+}
+
+// validateParent は親ページが存在することを確認します．一番上の階層の固定ページには親ページは不要です
+func (p *PageUseCase) validateParent(page *entity.Page) error {
+	parentPath := page.ParentPath()
+	if parentPath == "" {
+		return nil
+	}
+	_, err := p.pageRepository.FindByPath(parentPath)
+	if errors.Is(err, entity.ErrPageNotFound) {
+		return fmt.Errorf("validate parent page path=%v: %w", parentPath, entity.ErrPageParentNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("validate parent page path=%v: %w", parentPath, err)
+	}
+	return nil
+}
+
+// ensureNoChildren は子ページがないことを確認します．子ページのパスが親ページのパスを含むため，パスの変更や削除の前に確認します
+func (p *PageUseCase) ensureNoChildren(page *entity.Page) error {
+	_, err := p.pageRepository.FindAll("pages.path LIKE ?", []interface{}{page.Path + "/%"})
+	if err == nil {
+		return fmt.Errorf("ensure no children path=%v: %w", page.Path, entity.ErrPageHasChildren)
+	}
+	if !errors.Is(err, entity.ErrPageNotFound) {
+		return fmt.Errorf("ensure no children path=%v: %w", page.Path, err)
+	}
+	return nil
+}
+
+// convertPagesToDTO は一覧に返す固定ページを本文を含めずにDTOにします
+func convertPagesToDTO(pages []*entity.Page) []*dto.PageDTO {
+	pageDTOs := make([]*dto.PageDTO, 0, len(pages))
+	for _, page := range pages {
+		page.Content = ""
+		pageDTOs = append(pageDTOs, page.ConvertToDTO())
+	}
+	return pageDTOs
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+)
+
+func TestPageUseCase_StorePage(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	isDraft := false
+	tests := []struct {
+		name                  string
+		path                  string
+		prepareMockPageRepoFn func(mock *mock_repository.MockPage)
+		wantPath              string
+		wantErr               error
+	}{
+		{
+			name: "親ページがある場合は子ページとして保存できる",
+			path: "/company/about/",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByPath("company").Return(&entity.Page{Path: "company"}, nil)
+				mock.EXPECT().Store(gomock.Any()).Return(nil)
+			},
+			wantPath: "company/about",
+			wantErr:  nil,
+		},
+		{
+			name: "親ページがない場合はErrPageParentNotFoundを返す",
+			path: "company/about",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByPath("company").Return(nil, entity.ErrPageNotFound)
+			},
+			wantErr: entity.ErrPageParentNotFound,
+		},
+		{
+			name:                  "パスが正しくない場合はErrPagePathInvalidを返す",
+			path:                  "About Us",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {},
+			wantErr:               entity.ErrPagePathInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPage(ctrl)
+			tt.prepareMockPageRepoFn(mr)
+			p := &PageUseCase{
+				pageRepository: mr,
+			}
+
+			got, err := p.StorePage(&dto.PageDTO{Title: "About", Content: "# About", Path: tt.path, IsDraft: &isDraft})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("StorePage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Path != tt.wantPath {
+				t.Errorf("StorePage() path = %v, want %v", got.Path, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestPageUseCase_GetPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr := mock_repository.NewMockPage(ctrl)
+	mr.EXPECT().FindByPath("company").Return(&entity.Page{ID: "abcdefghijklmnopqrstuvwxy1", Title: "Company", Content: "**hello**", Path: "company"}, nil)
+	mr.EXPECT().FindAll("(pages.path LIKE ? AND pages.path NOT LIKE ?) AND pages.is_draft = ?", []interface{}{"company/%", "company/%/%", false}).Return([]*entity.Page{
+		{ID: "abcdefghijklmnopqrstuvwxy2", Title: "About", Content: "# About", Path: "company/about"},
+	}, nil)
+	p := &PageUseCase{
+		pageRepository: mr,
+	}
+
+	got, children, err := p.GetPage("/company", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<p><strong>hello</strong></p>\n"; got.Content != want {
+		t.Errorf("GetPage() content = %q, want %q", got.Content, want)
+	}
+	wantChildren := []*dto.PageDTO{{ID: "abcdefghijklmnopqrstuvwxy2", Title: "About", Path: "company/about", IsDraft: new(bool)}}
+	if diff := cmp.Diff(wantChildren, children); diff != "" {
+		t.Errorf("GetPage() children mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPageUseCase_UpdatePage(t *testing.T) {
+	isDraft := false
+	tests := []struct {
+		name                  string
+		path                  string
+		prepareMockPageRepoFn func(mock *mock_repository.MockPage)
+		wantErr               error
+	}{
+		{
+			name: "パスを変えない場合は子ページがあっても更新できる",
+			path: "company",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.Page{ID: "abcdefghijklmnopqrstuvwxy1", Path: "company"}, nil)
+				mock.EXPECT().Update(gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "子ページがある場合はパスを変えられない",
+			path: "corporate",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.Page{ID: "abcdefghijklmnopqrstuvwxy1", Path: "company"}, nil)
+				mock.EXPECT().FindAll("pages.path LIKE ?", []interface{}{"company/%"}).Return([]*entity.Page{{Path: "company/about"}}, nil)
+			},
+			wantErr: entity.ErrPageHasChildren,
+		},
+		{
+			name: "存在しない場合はErrPageNotFoundを返す",
+			path: "company",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(nil, entity.ErrPageNotFound)
+			},
+			wantErr: entity.ErrPageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPage(ctrl)
+			tt.prepareMockPageRepoFn(mr)
+			p := &PageUseCase{
+				pageRepository: mr,
+			}
+
+			_, err := p.UpdatePage(&dto.PageDTO{ID: "abcdefghijklmnopqrstuvwxy1", Title: "Company", Path: tt.path, IsDraft: &isDraft})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdatePage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPageUseCase_DeletePage(t *testing.T) {
+	tests := []struct {
+		name                  string
+		prepareMockPageRepoFn func(mock *mock_repository.MockPage)
+		wantErr               error
+	}{
+		{
+			name: "子ページがない場合は削除できる",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.Page{ID: "abcdefghijklmnopqrstuvwxy1", Path: "company"}, nil)
+				mock.EXPECT().FindAll("pages.path LIKE ?", []interface{}{"company/%"}).Return(nil, entity.ErrPageNotFound)
+				mock.EXPECT().Delete("abcdefghijklmnopqrstuvwxy1").Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "子ページがある場合はErrPageHasChildrenを返す",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.Page{ID: "abcdefghijklmnopqrstuvwxy1", Path: "company"}, nil)
+				mock.EXPECT().FindAll("pages.path LIKE ?", []interface{}{"company/%"}).Return([]*entity.Page{{Path: "company/about"}}, nil)
+			},
+			wantErr: entity.ErrPageHasChildren,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPage(ctrl)
+			tt.prepareMockPageRepoFn(mr)
+			p := &PageUseCase{
+				pageRepository: mr,
+			}
+
+			if err := p.DeletePage("abcdefghijklmnopqrstuvwxy1"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeletePage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/pmezard/go-difflib/difflib"
+
 	"github.com/Songmu/flextime"
 	"github.com/masibw/blog-server/domain/dto"
 	"github.com/masibw/blog-server/domain/entity"
@@ -11,11 +13,19 @@ import (
 )
 
 type PostUseCase struct {
-	postRepository repository.Post
+	postRepository              repository.Post
+	postRevisionRepository      repository.PostRevision
+	permalinkRedirectRepository repository.PermalinkRedirect
+	// related は投稿が更新されるまで関連する投稿の計算に使い回すキャッシュです
+	related relatedPostCache
 }
 
-func NewPostUseCase(postRepository repository.Post) *PostUseCase {
-	return &PostUseCase{postRepository: postRepository}
+func NewPostUseCase(postRepository repository.Post, postRevisionRepository repository.PostRevision, permalinkRedirectRepository repository.PermalinkRedirect) *PostUseCase {
+	return &PostUseCase{
+		postRepository:              postRepository,
+		postRevisionRepository:      postRevisionRepository,
+		permalinkRedirectRepository: permalinkRedirectRepository,
+	}
 }
 
 func (p *PostUseCase) CreatePost() (*dto.PostDTO, error) {
@@ -31,10 +41,14 @@ func (p *PostUseCase) CreatePost() (*dto.PostDTO, error) {
 	return post.ConvertToDTO(), nil
 }
 
+// UpdatePost は投稿を更新します
+// postDTO.UpdatedAt が保存されている投稿の更新日時と異なる場合は ErrPostVersionConflict と共に現在の投稿を返します
 func (p *PostUseCase) UpdatePost(postDTO *dto.PostDTO) (*dto.PostDTO, error) {
 
-	// 下書きじゃないのにTitleとContent,Permalinkに未入力項目があればエラー
-	if !*postDTO.IsDraft {
+	now := flextime.Now()
+
+	// 下書きじゃない，または予約投稿なのにTitleとContent,Permalinkに未入力項目があればエラー
+	if !*postDTO.IsDraft || postDTO.PublishedAt.After(now) {
 		errMsg := ""
 		if postDTO.Title == "" {
 			errMsg += "title is nil "
@@ -62,6 +76,11 @@ func (p *PostUseCase) UpdatePost(postDTO *dto.PostDTO) (*dto.PostDTO, error) {
 		return nil, fmt.Errorf("update post not found ID=%v: %w", postDTO.ID, entity.ErrPostNotFound)
 	}
 
+	// 編集を始めた後に他のタブなどで更新されていれば上書きせず，マージできるように現在の投稿を返す
+	if post.IsModifiedSince(postDTO.Version, postDTO.UpdatedAt) {
+		return post.ConvertToDTO(), fmt.Errorf("update post ID=%v version=%v updatedAt=%v: %w", postDTO.ID, postDTO.Version, postDTO.UpdatedAt, entity.ErrPostVersionConflict)
+	}
+
 	var permalinkPost *entity.Post
 	// 重複確認の処理をDomainServiceに切り出すべきだけど2箇所なので一旦保留
 	permalinkPost, err = p.postRepository.FindByPermalink(postDTO.Permalink)
@@ -74,54 +93,255 @@ func (p *PostUseCase) UpdatePost(postDTO *dto.PostDTO) (*dto.PostDTO, error) {
 		return nil, fmt.Errorf("update post permalink=%v: %w", postDTO.Permalink, entity.ErrPermalinkAlreadyExisted)
 	}
 
+	// 他の投稿の変更前のパーマリンクはリダイレクトに使われているので使えない
+	var reclaimedRedirect *entity.PermalinkRedirect
+	if postDTO.Permalink != "" && postDTO.Permalink != post.Permalink {
+		reclaimedRedirect, err = p.permalinkRedirectRepository.FindByPermalink(postDTO.Permalink)
+		if err != nil && !errors.Is(err, entity.ErrPermalinkRedirectNotFound) {
+			return nil, fmt.Errorf("update post title=%v: %w", postDTO.Title, err)
+		}
+		if reclaimedRedirect != nil && reclaimedRedirect.PostID != postDTO.ID {
+			return nil, fmt.Errorf("update post permalink=%v is redirected: %w", postDTO.Permalink, entity.ErrPermalinkAlreadyExisted)
+		}
+	}
+
+	// 公開済みの投稿のパーマリンクを変更した場合は古いパーマリンクからリダイレクトする
+	oldPermalink := post.Permalink
+	wasPublished := !post.IsDraft
+
+	// 上書きされる前の内容をリビジョンとして残す
+	revision := entity.NewPostRevision(post)
+
 	post.ConvertFromDTO(postDTO)
+	post.Summarize()
+
+	if revision.HasSameContent(post) {
+		revision = nil
+	}
+
+	// 下書きに未来の公開日時が設定されていれば予約投稿としてスケジューラーが公開する
+	post.IsScheduled = post.IsDraft && post.PublishedAt.After(now)
 
 	// 初めて公開するときのみ投稿時間を設定する
 	if post.PublishedAt.IsZero() && !post.IsDraft {
-		post.PublishedAt = flextime.Now()
+		post.PublishedAt = now
 	}
 
-	err = p.postRepository.Update(post)
+	// 古いパーマリンクからのリダイレクトを作る．以前のパーマリンクに戻した場合の reclaimedRedirect は不要になるので投稿と一緒に削除する
+	var redirect *entity.PermalinkRedirect
+	if wasPublished && oldPermalink != "" && oldPermalink != post.Permalink {
+		redirect = entity.NewPermalinkRedirect(post.ID, oldPermalink)
+	}
 
+	// リビジョンとリダイレクトは投稿を更新できた場合だけ変える．確認してから保存するまでの間に更新された場合もDBで競合が分かるので，同じように現在の投稿を返す
+	err = p.postRepository.UpdateWithHistory(post, revision, redirect, reclaimedRedirect)
+	if errors.Is(err, entity.ErrPostVersionConflict) {
+		current, findErr := p.postRepository.FindByID(postDTO.ID)
+		if findErr != nil {
+			// 現在の投稿を返せないので競合ではなく読み直しに失敗したエラーにする
+			return nil, fmt.Errorf("update post find current post id=%v: %w", postDTO.ID, findErr)
+		}
+		return current.ConvertToDTO(), fmt.Errorf("update post title=%v: %w", postDTO.Title, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("update post title=%v: %w", postDTO.Title, err)
 	}
+	p.related.invalidate()
 
 	return post.ConvertToDTO(), nil
 }
 
-func (p *PostUseCase) GetPosts(offset, pageSize int, condition string, params []interface{}, sortCondition string) (postDTOs []*dto.PostDTO, count int, err error) {
+// GetPosts は条件に一致する投稿を返します
+// fields にカラムを指定した場合はそのカラムだけを取得します
+// withContent が false の場合は本文を含めず，抜粋だけを返します
+func (p *PostUseCase) GetPosts(offset, pageSize int, condition string, params []interface{}, tagFilter *entity.TagFilter, sortCondition string, fields []string, withContent bool) (postDTOs []*dto.PostDTO, count int, err error) {
 	var posts []*entity.Post
-	posts, err = p.postRepository.FindAll(offset, pageSize, condition, params, sortCondition)
+	posts, err = p.postRepository.FindAll(offset, pageSize, condition, params, tagFilter, sortCondition, fields)
 	if err != nil {
 		err = fmt.Errorf("get posts: %w", err)
 		return
 	}
 
-	count, err = p.postRepository.Count(condition, params)
+	count, err = p.postRepository.Count(condition, params, tagFilter)
 	if err != nil {
 		err = fmt.Errorf("count posts: %w", err)
 		return
 	}
 
-	for _, post := range posts {
+	postDTOs = convertPostsToDTO(posts, withContent)
+	return
+}
 
-		// Markdownをhtmlへパースしている
-		post.ConvertContentToHTML()
+// GetPostsByCursor はキーセットページネーションで cursor の位置から pageSize 件の投稿を返します
+// cursor が nil の場合は最初のページを返し，次や前のページがある場合はその位置を表すカーソルも返します
+// 投稿は sortColumn の値とIDで並べ，desc が true の場合は降順にします．sortColumn がカーソルに使えないカラムの場合は ErrCursorInvalid を返します
+// pinnedFirst が true の場合は固定した投稿を先に並べます
+func (p *PostUseCase) GetPostsByCursor(pageSize int, condition string, params []interface{}, tagFilter *entity.TagFilter, sortColumn string, desc, pinnedFirst bool, cursor *entity.Cursor, fields []string, withContent bool) (postDTOs []*dto.PostDTO, count int, nextCursor, prevCursor string, err error) {
+	if !entity.IsCursorColumn(sortColumn) {
+		err = fmt.Errorf("get posts by cursor sort=%v: %w", sortColumn, entity.ErrCursorInvalid)
+		return
+	}
+	pageCondition, pageParams := condition, params
+	before := false
+	if cursor != nil {
+		if cursor.Column != sortColumn {
+			err = fmt.Errorf("get posts by cursor column=%v sort=%v: %w", cursor.Column, sortColumn, entity.ErrCursorInvalid)
+			return
+		}
+		var cursorCondition string
+		var cursorParams []interface{}
+		if pinnedFirst {
+			cursorCondition, cursorParams, err = cursor.PinnedCondition("posts", desc)
+		} else {
+			cursorCondition, cursorParams, err = cursor.Condition("posts", desc)
+		}
+		if err != nil {
+			err = fmt.Errorf("get posts by cursor: %w", err)
+			return
+		}
+		pageCondition, pageParams = andCondition(condition, params, cursorCondition, cursorParams)
+		before = cursor.Before
+	}
+
+	sortCondition := entity.CursorSortCondition("posts", sortColumn, desc, before)
+	if pinnedFirst {
+		sortCondition = entity.PinnedCursorSortCondition("posts", sortColumn, desc, before)
+	}
+
+	// 次のページがあるかを調べるために1件多く取得する
+	var posts []*entity.Post
+	posts, err = p.postRepository.FindAll(0, pageSize+1, pageCondition, pageParams, tagFilter, sortCondition, fields)
+	if err != nil {
+		err = fmt.Errorf("get posts by cursor: %w", err)
+		return
+	}
+	hasMore := len(posts) > pageSize
+	if hasMore {
+		posts = posts[:pageSize]
+	}
+	// 前のページは逆順に取得しているので並べ直す
+	if before {
+		for i, j := 0, len(posts)-1; i < j; i, j = i+1, j-1 {
+			posts[i], posts[j] = posts[j], posts[i]
+		}
+	}
+
+	count, err = p.postRepository.Count(condition, params, tagFilter)
+	if err != nil {
+		err = fmt.Errorf("count posts: %w", err)
+		return
+	}
+
+	hasNext, hasPrev := hasMore, cursor != nil
+	if before {
+		hasNext, hasPrev = true, hasMore
+	}
+	first, last := posts[0], posts[len(posts)-1]
+	if hasNext {
+		next := entity.NewCursor(sortColumn, last.ColumnValue(sortColumn), last.ID, false)
+		next.Pinned = pinnedFirst && last.IsPinned
+		nextCursor = next.Encode()
+	}
+	if hasPrev {
+		prev := entity.NewCursor(sortColumn, first.ColumnValue(sortColumn), first.ID, true)
+		prev.Pinned = pinnedFirst && first.IsPinned
+		prevCursor = prev.Encode()
+	}
+
+	postDTOs = convertPostsToDTO(posts, withContent)
+	return
+}
+
+// convertPostsToDTO は一覧に返す投稿をDTOにします．withContent が false の場合は本文を含めません
+func convertPostsToDTO(posts []*entity.Post, withContent bool) []*dto.PostDTO {
+	postDTOs := make([]*dto.PostDTO, 0, len(posts))
+	for _, post := range posts {
+		post.EnsureSummary()
+		if withContent {
+			// Markdownをhtmlへパースしている
+			post.ConvertContentToHTML()
+		} else {
+			post.Content = ""
+		}
 
 		postDTOs = append(postDTOs, post.ConvertToDTO())
 	}
+	return postDTOs
+}
+
+// SearchPosts は全文検索に一致する投稿を関連度の高い順に返します
+// 各投稿には検索語を強調した本文の抜粋を付けます．withContent が false の場合は本文を含めません
+func (p *PostUseCase) SearchPosts(query string, offset, pageSize int, condition string, params []interface{}, tagFilter *entity.TagFilter, withContent bool) (postDTOs []*dto.PostDTO, count int, err error) {
+	var posts []*entity.Post
+	posts, err = p.postRepository.Search(query, offset, pageSize, condition, params, tagFilter)
+	if err != nil {
+		err = fmt.Errorf("search posts query=%v: %w", query, err)
+		return
+	}
+
+	count, err = p.postRepository.CountSearch(query, condition, params, tagFilter)
+	if err != nil {
+		err = fmt.Errorf("count search posts query=%v: %w", query, err)
+		return
+	}
+
+	for _, post := range posts {
+		// 抜粋はMarkdownのまま作る
+		snippet := post.Snippet(query)
+		post.EnsureSummary()
+
+		if withContent {
+			// Markdownをhtmlへパースしている
+			post.ConvertContentToHTML()
+		} else {
+			post.Content = ""
+		}
+
+		postDTO := post.ConvertToDTO()
+		postDTO.Snippet = snippet
+		postDTOs = append(postDTOs, postDTO)
+	}
 
 	return
 }
 
+// GetArchives は公開済みの投稿の数を年ごとと月ごとに新しい順で返します
+func (p *PostUseCase) GetArchives() ([]*dto.ArchiveDTO, error) {
+	counts, err := p.postRepository.CountByMonth(publishedPostCondition, []interface{}{false, flextime.Now()})
+	if err != nil {
+		return nil, fmt.Errorf("get archives: %w", err)
+	}
+
+	archiveDTOs := make([]*dto.ArchiveDTO, 0)
+	for _, count := range counts {
+		if len(archiveDTOs) == 0 || archiveDTOs[len(archiveDTOs)-1].Year != count.Year {
+			archiveDTOs = append(archiveDTOs, &dto.ArchiveDTO{Year: count.Year, Months: []*dto.MonthlyArchiveDTO{}})
+		}
+		archive := archiveDTOs[len(archiveDTOs)-1]
+		archive.Count += count.Count
+		archive.Months = append(archive.Months, &dto.MonthlyArchiveDTO{Month: count.Month, Count: count.Count})
+	}
+	return archiveDTOs, nil
+}
+
+// GetPost はパーマリンクの投稿を返します
+// 変更前のパーマリンクが指定された場合は ErrPostMoved と共に現在のパーマリンクを持つ投稿を返します
 func (p *PostUseCase) GetPost(permalink string, isMarkdown bool) (postDTO *dto.PostDTO, err error) {
 	var post *entity.Post
 	post, err = p.postRepository.FindByPermalink(permalink)
+	if errors.Is(err, entity.ErrPostNotFound) {
+		return p.getMovedPost(permalink)
+	}
 	if err != nil {
 		err = fmt.Errorf("get post: %w", err)
 		return
 	}
+	// 公開日時が来るまでは存在しないものとして扱う
+	if post.IsBeforePublish(flextime.Now()) {
+		err = fmt.Errorf("get post before publish permalink=%v: %w", permalink, entity.ErrPostNotFound)
+		return
+	}
+	post.EnsureSummary()
 	if !isMarkdown {
 		post.ConvertContentToHTML()
 	}
@@ -129,11 +349,268 @@ func (p *PostUseCase) GetPost(permalink string, isMarkdown bool) (postDTO *dto.P
 	return
 }
 
+// getMovedPost は変更前のパーマリンクからリダイレクト先の投稿を探します
+func (p *PostUseCase) getMovedPost(permalink string) (*dto.PostDTO, error) {
+	redirect, err := p.permalinkRedirectRepository.FindByPermalink(permalink)
+	if err != nil {
+		if errors.Is(err, entity.ErrPermalinkRedirectNotFound) {
+			return nil, fmt.Errorf("get post permalink=%v: %w", permalink, entity.ErrPostNotFound)
+		}
+		return nil, fmt.Errorf("get post: %w", err)
+	}
+
+	var post *entity.Post
+	post, err = p.postRepository.FindByID(redirect.PostID)
+	if err != nil {
+		return nil, fmt.Errorf("get post moved from permalink=%v: %w", permalink, err)
+	}
+	// 移動先が公開されていなければ存在しないものとして扱う
+	if post.Permalink == "" || post.IsBeforePublish(flextime.Now()) {
+		return nil, fmt.Errorf("get post moved from permalink=%v: %w", permalink, entity.ErrPostNotFound)
+	}
+
+	return post.ConvertToDTO(), fmt.Errorf("get post permalink=%v moved to %v: %w", permalink, post.Permalink, entity.ErrPostMoved)
+}
+
+// PublishScheduledPosts は公開日時を過ぎた予約投稿を公開し，公開した投稿数を返します
+func (p *PostUseCase) PublishScheduledPosts() (count int, err error) {
+	var posts []*entity.Post
+	posts, err = p.postRepository.FindAll(0, 0, "posts.is_draft = ? AND posts.is_scheduled = ? AND posts.published_at <= ?", []interface{}{true, true, flextime.Now()}, nil, "posts.published_at asc", nil)
+	if err != nil {
+		if errors.Is(err, entity.ErrPostNotFound) {
+			return 0, nil
+		}
+		err = fmt.Errorf("publish scheduled posts: %w", err)
+		return
+	}
+
+	for _, post := range posts {
+		post.IsDraft = false
+		post.IsScheduled = false
+		err = p.postRepository.Update(post)
+		// 読み込んだ後に編集された投稿は次に確認する時に改めて公開するか判断する
+		if errors.Is(err, entity.ErrPostVersionConflict) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			err = fmt.Errorf("publish scheduled post id=%v: %w", post.ID, err)
+			return
+		}
+		p.related.invalidate()
+		count++
+	}
+	return
+}
+
 func (p *PostUseCase) DeletePost(id string) (err error) {
 	err = p.postRepository.Delete(id)
 	if err != nil {
 		err = fmt.Errorf("delete post: %w", err)
 		return
 	}
+	p.related.invalidate()
 	return nil
 }
+
+// PinPost は投稿を一覧の先頭に固定します．featuredRank が1以上の場合はトップページのおすすめにもその順番で表示します
+func (p *PostUseCase) PinPost(id string, featuredRank int) (*dto.PostDTO, error) {
+	if featuredRank < 0 {
+		return nil, fmt.Errorf("pin post id=%v featuredRank=%v: %w", id, featuredRank, entity.ErrFeaturedRankInvalid)
+	}
+	return p.updatePin(id, true, featuredRank)
+}
+
+// UnpinPost は投稿の固定を外し，おすすめからも外します
+func (p *PostUseCase) UnpinPost(id string) (*dto.PostDTO, error) {
+	return p.updatePin(id, false, 0)
+}
+
+func (p *PostUseCase) updatePin(id string, isPinned bool, featuredRank int) (*dto.PostDTO, error) {
+	post, err := p.postRepository.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("update post pin id=%v: %w", id, err)
+	}
+	if err = p.postRepository.UpdatePin(id, isPinned, featuredRank); err != nil {
+		return nil, fmt.Errorf("update post pin id=%v: %w", id, err)
+	}
+	post.IsPinned = isPinned
+	post.FeaturedRank = featuredRank
+	return post.ConvertToDTO(), nil
+}
+
+// RestorePost はゴミ箱にある投稿を元に戻します
+func (p *PostUseCase) RestorePost(id string) (*dto.PostDTO, error) {
+	post, err := p.postRepository.FindDeletedByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("restore post id=%v: %w", id, err)
+	}
+
+	// ゴミ箱にある間に同じパーマリンクの投稿が公開されていれば元に戻せない
+	if post.Permalink != "" {
+		_, err = p.postRepository.FindByPermalink(post.Permalink)
+		if err == nil {
+			return nil, fmt.Errorf("restore post id=%v permalink=%v: %w", id, post.Permalink, entity.ErrPermalinkAlreadyExisted)
+		}
+		if !errors.Is(err, entity.ErrPostNotFound) {
+			return nil, fmt.Errorf("restore post id=%v: %w", id, err)
+		}
+
+		var redirect *entity.PermalinkRedirect
+		redirect, err = p.permalinkRedirectRepository.FindByPermalink(post.Permalink)
+		if err == nil && redirect.PostID != id {
+			return nil, fmt.Errorf("restore post id=%v permalink=%v is redirected: %w", id, post.Permalink, entity.ErrPermalinkAlreadyExisted)
+		}
+		if err != nil && !errors.Is(err, entity.ErrPermalinkRedirectNotFound) {
+			return nil, fmt.Errorf("restore post id=%v: %w", id, err)
+		}
+	}
+
+	if err = p.postRepository.Restore(id); err != nil {
+		return nil, fmt.Errorf("restore post id=%v: %w", id, err)
+	}
+	p.related.invalidate()
+	post.DeletedAt.Valid = false
+
+	return post.ConvertToDTO(), nil
+}
+
+func (p *PostUseCase) GetPostRevisions(postID string, offset, pageSize int) (revisionDTOs []*dto.PostRevisionDTO, count int, err error) {
+	var revisions []*entity.PostRevision
+	revisions, err = p.postRevisionRepository.FindByPostID(postID, offset, pageSize)
+	if err != nil {
+		err = fmt.Errorf("get post revisions post id=%v: %w", postID, err)
+		return
+	}
+
+	count, err = p.postRevisionRepository.CountByPostID(postID)
+	if err != nil {
+		err = fmt.Errorf("count post revisions post id=%v: %w", postID, err)
+		return
+	}
+
+	for _, revision := range revisions {
+		revisionDTOs = append(revisionDTOs, revision.ConvertToDTO())
+	}
+	return
+}
+
+// GetPostRevisionDiff は2つのリビジョン間のunified diffを返します
+// toIDが空の場合は現在の投稿の内容と比較します
+func (p *PostUseCase) GetPostRevisionDiff(postID, fromID, toID string) (diff string, err error) {
+	var from *entity.PostRevision
+	from, err = p.findPostRevision(postID, fromID)
+	if err != nil {
+		err = fmt.Errorf("get post revision diff: %w", err)
+		return
+	}
+
+	var to *entity.PostRevision
+	toName := "current"
+	if toID == "" {
+		var post *entity.Post
+		post, err = p.postRepository.FindByID(postID)
+		if err != nil {
+			err = fmt.Errorf("get post revision diff: %w", err)
+			return
+		}
+		to = entity.NewPostRevision(post)
+	} else {
+		to, err = p.findPostRevision(postID, toID)
+		if err != nil {
+			err = fmt.Errorf("get post revision diff: %w", err)
+			return
+		}
+		toName = to.ID
+	}
+
+	diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(revisionText(from)),
+		B:        difflib.SplitLines(revisionText(to)),
+		FromFile: from.ID,
+		ToFile:   toName,
+		Context:  3,
+	})
+	if err != nil {
+		err = fmt.Errorf("get post revision diff: %w", err)
+		return
+	}
+	return
+}
+
+// RestorePostRevision はリビジョンの内容で投稿を上書きします
+// 復元前の内容もリビジョンとして残るので復元自体を取り消すこともできます
+func (p *PostUseCase) RestorePostRevision(postID, revisionID string) (*dto.PostDTO, error) {
+	revision, err := p.findPostRevision(postID, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("restore post revision: %w", err)
+	}
+
+	var post *entity.Post
+	post, err = p.postRepository.FindByID(postID)
+	if err != nil {
+		return nil, fmt.Errorf("restore post revision: %w", err)
+	}
+
+	postDTO := post.ConvertToDTO()
+	postDTO.Title = revision.Title
+	postDTO.ThumbnailURL = revision.ThumbnailURL
+	postDTO.Content = revision.Content
+	postDTO.Permalink = revision.Permalink
+
+	postDTO, err = p.UpdatePost(postDTO)
+	if err != nil {
+		return nil, fmt.Errorf("restore post revision id=%v: %w", revisionID, err)
+	}
+	return postDTO, nil
+}
+
+// GetPermalinkRedirects は投稿の変更前のパーマリンクを新しい順に返します
+func (p *PostUseCase) GetPermalinkRedirects(postID string) (redirectDTOs []*dto.PermalinkRedirectDTO, err error) {
+	var redirects []*entity.PermalinkRedirect
+	redirects, err = p.permalinkRedirectRepository.FindByPostID(postID)
+	if err != nil {
+		err = fmt.Errorf("get permalink redirects post id=%v: %w", postID, err)
+		return
+	}
+
+	for _, redirect := range redirects {
+		redirectDTOs = append(redirectDTOs, redirect.ConvertToDTO())
+	}
+	return
+}
+
+// DeletePermalinkRedirect は変更前のパーマリンクからのリダイレクトを削除し，そのパーマリンクを再び使えるようにします
+func (p *PostUseCase) DeletePermalinkRedirect(postID, redirectID string) error {
+	redirect, err := p.permalinkRedirectRepository.FindByID(redirectID)
+	if err != nil {
+		return fmt.Errorf("delete permalink redirect id=%v: %w", redirectID, err)
+	}
+	// 別の投稿のリダイレクトは存在しないものとして扱う
+	if redirect.PostID != postID {
+		return fmt.Errorf("delete permalink redirect id=%v post id=%v: %w", redirectID, postID, entity.ErrPermalinkRedirectNotFound)
+	}
+
+	err = p.permalinkRedirectRepository.Delete(redirectID)
+	if err != nil {
+		return fmt.Errorf("delete permalink redirect id=%v: %w", redirectID, err)
+	}
+	return nil
+}
+
+// findPostRevision は指定した投稿に属するリビジョンのみを返します
+func (p *PostUseCase) findPostRevision(postID, revisionID string) (*entity.PostRevision, error) {
+	revision, err := p.postRevisionRepository.FindByID(revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("find post revision id=%v: %w", revisionID, err)
+	}
+	if revision.PostID != postID {
+		return nil, fmt.Errorf("find post revision id=%v post id=%v: %w", revisionID, postID, entity.ErrPostRevisionNotFound)
+	}
+	return revision, nil
+}
+
+// revisionText はdiffを取るためにリビジョンをテキストに変換します
+func revisionText(revision *entity.PostRevision) string {
+	return fmt.Sprintf("title: %s\npermalink: %s\nthumbnailUrl: %s\n\n%s", revision.Title, revision.Permalink, revision.ThumbnailURL, revision.Content)
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/masibw/blog-server/domain/mock_repository"
+	"gorm.io/gorm"
 
 	"github.com/masibw/blog-server/domain/entity"
 
@@ -79,6 +80,7 @@ func TestPostUseCase_StorePost(t *testing.T) { // nolint:gocognit
 }
 
 func TestPostUseCase_UpdatePost(t *testing.T) { // nolint:gocognit
+	errDummy := errors.New("dummy error")
 
 	loc, err := time.LoadLocation("Asia/Tokyo")
 	if err != nil {
@@ -88,11 +90,80 @@ func TestPostUseCase_UpdatePost(t *testing.T) { // nolint:gocognit
 	defer flextime.Restore()
 
 	tests := []struct {
-		name                  string
-		postDTO               *dto.PostDTO
-		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
-		wantErr               error
+		name                      string
+		postDTO                   *dto.PostDTO
+		prepareMockPostRepoFn     func(mock *mock_repository.MockPost)
+		prepareMockRedirectRepoFn func(mock *mock_repository.MockPermalinkRedirect)
+		wantErr                   error
 	}{
+		{
+			name: "内容が変わる場合は更新前の内容をリビジョンとして保存する",
+			postDTO: &dto.PostDTO{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content2",
+				Permalink:    "new_permalink",
+				IsDraft:      func() *bool { b := true; return &b }(),
+				CreatedAt:    flextime.Now(),
+				UpdatedAt:    flextime.Now(),
+				PublishedAt:  time.Time{},
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "new_permalink",
+					IsDraft:      true,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  time.Time{},
+				}, nil)
+				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(post *entity.Post, revision *entity.PostRevision, _, _ *entity.PermalinkRedirect) error {
+					if revision == nil || revision.PostID != "abcdefghijklmnopqrstuvwxyz" || revision.Content != "new_content" {
+						t.Errorf("UpdateWithHistory() revision = %v, want content of the post before update", revision)
+					}
+					if post.Content != "new_content2" {
+						t.Errorf("UpdateWithHistory() content = %v, want new_content2", post.Content)
+					}
+					return nil
+				})
+			},
+			wantErr: nil,
+		},
+		{
+			name: "リビジョンの保存に失敗した場合はエラーを返す",
+			postDTO: &dto.PostDTO{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content2",
+				Permalink:    "new_permalink",
+				IsDraft:      func() *bool { b := true; return &b }(),
+				CreatedAt:    flextime.Now(),
+				UpdatedAt:    flextime.Now(),
+				PublishedAt:  time.Time{},
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "new_permalink",
+					IsDraft:      true,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  time.Time{},
+				}, nil)
+				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).Return(entity.ErrPostRevisionAlreadyExisted)
+			},
+			wantErr: entity.ErrPostRevisionAlreadyExisted,
+		},
 		{
 			name: "投稿を更新し、その投稿を返す",
 			postDTO: &dto.PostDTO{
@@ -119,7 +190,7 @@ func TestPostUseCase_UpdatePost(t *testing.T) { // nolint:gocognit
 					PublishedAt:  time.Time{},
 				}, nil)
 				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
-				mock.EXPECT().Update(gomock.Any()).Return(nil)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Nil(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: nil,
 		},
@@ -150,6 +221,7 @@ func TestPostUseCase_UpdatePost(t *testing.T) { // nolint:gocognit
 				Content:      "new_content",
 				Permalink:    "new_permalink",
 				IsDraft:      func() *bool { b := true; return &b }(),
+				UpdatedAt:    flextime.Now(),
 			},
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
 				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
@@ -202,7 +274,7 @@ func TestPostUseCase_UpdatePost(t *testing.T) { // nolint:gocognit
 					UpdatedAt:    flextime.Now(),
 					PublishedAt:  time.Time{}}, nil)
 				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
-				mock.EXPECT().Update(gomock.Any()).Return(nil)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Nil(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: nil,
 		},
@@ -232,7 +304,7 @@ func TestPostUseCase_UpdatePost(t *testing.T) { // nolint:gocognit
 					PublishedAt:  time.Time{},
 				}, nil)
 				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
-				mock.EXPECT().Update(gomock.Any()).Return(nil)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Nil(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: nil,
 		},
@@ -262,10 +334,61 @@ func TestPostUseCase_UpdatePost(t *testing.T) { // nolint:gocognit
 					PublishedAt:  time.Time{},
 				}, nil)
 				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
-				mock.EXPECT().Update(gomock.Any()).Return(nil)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Nil(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "IsDraftがtrueでPublishedAtが未来であれば予約投稿として更新されること",
+			postDTO: &dto.PostDTO{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content",
+				Permalink:    "new_permalink",
+				IsDraft:      func() *bool { b := true; return &b }(),
+				CreatedAt:    flextime.Now(),
+				UpdatedAt:    flextime.Now(),
+				PublishedAt:  flextime.Now().Add(time.Hour),
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "new_permalink",
+					IsDraft:      true,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  time.Time{},
+				}, nil)
+				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Nil(), gomock.Any(), gomock.Any()).DoAndReturn(func(post *entity.Post, revision *entity.PostRevision, _, _ *entity.PermalinkRedirect) error {
+					if !post.IsScheduled {
+						t.Errorf("UpdateWithHistory() IsScheduled = false, want true")
+					}
+					return nil
+				})
 			},
 			wantErr: nil,
 		},
+		{
+			name: "予約投稿でTitle,Content,Permalinkが空であれば entity.ErrPostHasEmptyFieldエラーを返す",
+			postDTO: &dto.PostDTO{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "",
+				Permalink:    "",
+				IsDraft:      func() *bool { b := true; return &b }(),
+				CreatedAt:    flextime.Now(),
+				UpdatedAt:    flextime.Now(),
+				PublishedAt:  flextime.Now().Add(time.Hour),
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {},
+			wantErr:               entity.ErrPostHasEmptyField,
+		},
 		{
 			name: "IsDraftがfalseでTitle,Content,Permalinkが空であれば entity.ErrPostHasEmptyFieldエラーを返す",
 			postDTO: &dto.PostDTO{
@@ -282,6 +405,199 @@ func TestPostUseCase_UpdatePost(t *testing.T) { // nolint:gocognit
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {},
 			wantErr:               entity.ErrPostHasEmptyField,
 		},
+		{
+			name: "編集を始めた後に投稿が更新されていた場合はErrPostVersionConflictエラーを返す",
+			postDTO: &dto.PostDTO{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content",
+				Permalink:    "new_permalink",
+				IsDraft:      func() *bool { b := true; return &b }(),
+				UpdatedAt:    flextime.Now(),
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "updated_content",
+					Permalink:    "new_permalink",
+					IsDraft:      true,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now().Add(time.Minute),
+					PublishedAt:  time.Time{},
+				}, nil)
+			},
+			wantErr: entity.ErrPostVersionConflict,
+		},
+		{
+			name: "確認した後に他で更新されて保存に失敗した場合はErrPostVersionConflictエラーを返す",
+			postDTO: &dto.PostDTO{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content",
+				Permalink:    "new_permalink",
+				IsDraft:      func() *bool { b := true; return &b }(),
+				Version:      1,
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:        "abcdefghijklmnopqrstuvwxyz",
+					Title:     "new_post",
+					Content:   "new_content",
+					Permalink: "new_permalink",
+					IsDraft:   true,
+					Version:   1,
+				}, nil)
+				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.ErrPostVersionConflict)
+				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz", Version: 2}, nil)
+			},
+			wantErr: entity.ErrPostVersionConflict,
+		},
+		{
+			name: "保存に失敗した後に現在の投稿の取得にも失敗した場合はErrPostVersionConflictではなく取得のエラーを返す",
+			postDTO: &dto.PostDTO{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content",
+				Permalink:    "new_permalink",
+				IsDraft:      func() *bool { b := true; return &b }(),
+				Version:      1,
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:        "abcdefghijklmnopqrstuvwxyz",
+					Title:     "new_post",
+					Content:   "new_content",
+					Permalink: "new_permalink",
+					IsDraft:   true,
+					Version:   1,
+				}, nil)
+				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.ErrPostVersionConflict)
+				mock.EXPECT().FindByID(gomock.Any()).Return(nil, errDummy)
+			},
+			wantErr: errDummy,
+		},
+		{
+			name: "公開済みの投稿のパーマリンクを変更した場合は古いパーマリンクからのリダイレクトを作成する",
+			postDTO: &dto.PostDTO{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content",
+				Permalink:    "new_permalink",
+				IsDraft:      func() *bool { b := false; return &b }(),
+				CreatedAt:    flextime.Now(),
+				UpdatedAt:    flextime.Now(),
+				PublishedAt:  flextime.Now(),
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "old_permalink",
+					IsDraft:      false,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  flextime.Now(),
+				}, nil)
+				mock.EXPECT().FindByPermalink("new_permalink").Return(nil, entity.ErrPostNotFound)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any(), gomock.Nil()).DoAndReturn(func(_ *entity.Post, _ *entity.PostRevision, redirect, _ *entity.PermalinkRedirect) error {
+					if redirect == nil || redirect.PostID != "abcdefghijklmnopqrstuvwxyz" || redirect.Permalink != "old_permalink" {
+						t.Errorf("UpdateWithHistory() redirect = %v, want redirect from old_permalink", redirect)
+					}
+					return nil
+				})
+			},
+			prepareMockRedirectRepoFn: func(mock *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink("new_permalink").Return(nil, entity.ErrPermalinkRedirectNotFound)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "以前のパーマリンクに戻した場合はそのリダイレクトを削除する",
+			postDTO: &dto.PostDTO{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content",
+				Permalink:    "older_permalink",
+				IsDraft:      func() *bool { b := false; return &b }(),
+				CreatedAt:    flextime.Now(),
+				UpdatedAt:    flextime.Now(),
+				PublishedAt:  flextime.Now(),
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "old_permalink",
+					IsDraft:      false,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  flextime.Now(),
+				}, nil)
+				mock.EXPECT().FindByPermalink("older_permalink").Return(nil, entity.ErrPostNotFound)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Not(gomock.Nil()), &entity.PermalinkRedirect{
+					ID:        "abcdefghijklmnopqrstuvwxy1",
+					PostID:    "abcdefghijklmnopqrstuvwxyz",
+					Permalink: "older_permalink",
+				}).Return(nil)
+			},
+			prepareMockRedirectRepoFn: func(mock *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink("older_permalink").Return(&entity.PermalinkRedirect{
+					ID:        "abcdefghijklmnopqrstuvwxy1",
+					PostID:    "abcdefghijklmnopqrstuvwxyz",
+					Permalink: "older_permalink",
+				}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "他の投稿のリダイレクトに使われているパーマリンクの場合はErrPermalinkAlreadyExistedを返す",
+			postDTO: &dto.PostDTO{
+				ID:           "abcdefghijklmnopqrstuvwxyz",
+				Title:        "new_post",
+				ThumbnailURL: "new_thumbnail_url",
+				Content:      "new_content",
+				Permalink:    "new_permalink",
+				IsDraft:      func() *bool { b := false; return &b }(),
+				CreatedAt:    flextime.Now(),
+				UpdatedAt:    flextime.Now(),
+				PublishedAt:  flextime.Now(),
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "old_permalink",
+					IsDraft:      false,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  flextime.Now(),
+				}, nil)
+				mock.EXPECT().FindByPermalink("new_permalink").Return(nil, entity.ErrPostNotFound)
+			},
+			prepareMockRedirectRepoFn: func(mock *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink("new_permalink").Return(&entity.PermalinkRedirect{
+					ID:        "abcdefghijklmnopqrstuvwxy1",
+					PostID:    "abcdefghijklmnopqrstuvwxy2",
+					Permalink: "new_permalink",
+				}, nil)
+			},
+			wantErr: entity.ErrPermalinkAlreadyExisted,
+		},
 	}
 
 	for _, tt := range tests {
@@ -290,14 +606,23 @@ func TestPostUseCase_UpdatePost(t *testing.T) { // nolint:gocognit
 			defer ctrl.Finish()
 			mr := mock_repository.NewMockPost(ctrl)
 			tt.prepareMockPostRepoFn(mr)
+			mpr := mock_repository.NewMockPermalinkRedirect(ctrl)
+			if tt.prepareMockRedirectRepoFn != nil {
+				tt.prepareMockRedirectRepoFn(mpr)
+			}
 			p := &PostUseCase{
-				postRepository: mr,
+				postRepository:              mr,
+				permalinkRedirectRepository: mpr,
 			}
 
 			got, err := p.UpdatePost(tt.postDTO)
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("UpdatePost() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			// 競合した場合だけ現在の投稿を返す
+			if tt.wantErr != nil && errors.Is(tt.wantErr, entity.ErrPostVersionConflict) != (got != nil) {
+				t.Errorf("UpdatePost() got = %v, error = %v", got, err)
+			}
 
 			if tt.wantErr != nil {
 				return
@@ -348,39 +673,43 @@ func TestPostUseCase_GetPosts(t *testing.T) {
 	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
 	defer flextime.Restore()
 
-	existsPosts := []*entity.Post{{
-		ID:           "abcdefghijklmnopqrstuvwxyz",
-		Title:        "new_post",
-		ThumbnailURL: "new_thumbnail_url",
-		Content:      "new_content",
-		Permalink:    "new_permalink",
-		IsDraft:      false,
-		CreatedAt:    flextime.Now(),
-		UpdatedAt:    flextime.Now(),
-		PublishedAt:  flextime.Now(),
-	}, {
-		ID:           "abcdefghijklmnopqrstuvwxy2",
-		Title:        "new_post",
-		ThumbnailURL: "new_thumbnail_url",
-		Content:      "new_content",
-		Permalink:    "new_permalink2",
-		IsDraft:      false,
-		CreatedAt:    flextime.Now(),
-		UpdatedAt:    flextime.Now(),
-		PublishedAt:  flextime.Now(),
-	}}
+	existsPosts := func() []*entity.Post {
+		return []*entity.Post{{
+			ID:           "abcdefghijklmnopqrstuvwxyz",
+			Title:        "new_post",
+			ThumbnailURL: "new_thumbnail_url",
+			Content:      "new_content",
+			Permalink:    "new_permalink",
+			IsDraft:      false,
+			CreatedAt:    flextime.Now(),
+			UpdatedAt:    flextime.Now(),
+			PublishedAt:  flextime.Now(),
+		}, {
+			ID:           "abcdefghijklmnopqrstuvwxy2",
+			Title:        "new_post",
+			ThumbnailURL: "new_thumbnail_url",
+			Content:      "new_content",
+			Permalink:    "new_permalink2",
+			IsDraft:      false,
+			CreatedAt:    flextime.Now(),
+			UpdatedAt:    flextime.Now(),
+			PublishedAt:  flextime.Now(),
+		}}
+	}
 
 	tests := []struct {
 		name                  string
+		withContent           bool
 		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
 		want                  []*dto.PostDTO
 		wantErr               bool
 	}{
 		{
-			name: "postDTOsを返すこと",
+			name:        "withContentがtrueの場合はhtmlにした本文と抜粋を返すこと",
+			withContent: true,
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts, nil)
-				mock.EXPECT().Count(gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts(), nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(2, nil)
 			},
 			want: []*dto.PostDTO{
 				{
@@ -388,6 +717,10 @@ func TestPostUseCase_GetPosts(t *testing.T) {
 					Title:        "new_post",
 					ThumbnailURL: "new_thumbnail_url",
 					Content:      "<p>new_content</p>\n",
+					Excerpt:      "new_content",
+					CharCount:    11,
+					WordCount:    2,
+					ReadingTime:  1,
 					Permalink:    "new_permalink",
 					IsDraft:      func() *bool { b := false; return &b }(),
 					CreatedAt:    flextime.Now(),
@@ -399,6 +732,10 @@ func TestPostUseCase_GetPosts(t *testing.T) {
 					Title:        "new_post",
 					ThumbnailURL: "new_thumbnail_url",
 					Content:      "<p>new_content</p>\n",
+					Excerpt:      "new_content",
+					CharCount:    11,
+					WordCount:    2,
+					ReadingTime:  1,
 					Permalink:    "new_permalink2",
 					IsDraft:      func() *bool { b := false; return &b }(),
 					CreatedAt:    flextime.Now(),
@@ -409,11 +746,51 @@ func TestPostUseCase_GetPosts(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "FindAllがエラーを返した時はpostDTOsが空であること",
+			name: "withContentがfalseの場合は本文を含めず抜粋を返すこと",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts(), nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(2, nil)
 			},
-			want:    nil,
+			want: []*dto.PostDTO{
+				{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "",
+					Excerpt:      "new_content",
+					CharCount:    11,
+					WordCount:    2,
+					ReadingTime:  1,
+					Permalink:    "new_permalink",
+					IsDraft:      func() *bool { b := false; return &b }(),
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  flextime.Now(),
+				},
+				{
+					ID:           "abcdefghijklmnopqrstuvwxy2",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "",
+					Excerpt:      "new_content",
+					CharCount:    11,
+					WordCount:    2,
+					ReadingTime:  1,
+					Permalink:    "new_permalink2",
+					IsDraft:      func() *bool { b := false; return &b }(),
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  flextime.Now(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "FindAllがエラーを返した時はpostDTOsが空であること",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -429,7 +806,7 @@ func TestPostUseCase_GetPosts(t *testing.T) {
 			}
 
 			// このGetPostsの責務はパラメータを受け取ってpostDTOsを返すだけなのでパラメータの中身はなんでも良い(はず)
-			got, count, err := p.GetPosts(0, 0, "", []interface{}{}, "")
+			got, count, err := p.GetPosts(0, 0, "", []interface{}{}, nil, "", nil, tt.withContent)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetPosts() error = %v, wantErr %v", err, tt.wantErr)
@@ -445,6 +822,248 @@ func TestPostUseCase_GetPosts(t *testing.T) {
 	}
 }
 
+func TestPostUseCase_GetPostsByCursor(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	existsPosts := func() []*entity.Post {
+		return []*entity.Post{{
+			ID:          "abcdefghijklmnopqrstuvwxy2",
+			Title:       "new_post2",
+			Permalink:   "new_permalink2",
+			PublishedAt: flextime.Now(),
+		}, {
+			ID:          "abcdefghijklmnopqrstuvwxy1",
+			Title:       "new_post1",
+			Permalink:   "new_permalink1",
+			PublishedAt: flextime.Now().Add(-time.Hour),
+		}}
+	}
+	nextOfFirst := entity.NewCursor("published_at", flextime.Now(), "abcdefghijklmnopqrstuvwxy2", false)
+
+	tests := []struct {
+		name                  string
+		cursor                *entity.Cursor
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		wantIDs               []string
+		wantNextCursor        string
+		wantPrevCursor        string
+		wantErr               error
+	}{
+		{
+			name:   "最初のページと次のページのカーソルを返すこと",
+			cursor: nil,
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(0, 2, "posts.is_draft = ?", []interface{}{false}, nil, "posts.published_at desc, posts.id desc", nil).Return(existsPosts(), nil)
+				mock.EXPECT().Count("posts.is_draft = ?", []interface{}{false}, nil).Return(2, nil)
+			},
+			wantIDs:        []string{"abcdefghijklmnopqrstuvwxy2"},
+			wantNextCursor: nextOfFirst.Encode(),
+			wantPrevCursor: "",
+			wantErr:        nil,
+		},
+		{
+			name:   "次のページでは前のページのカーソルを返し，最後のページでは次のページのカーソルを返さないこと",
+			cursor: nextOfFirst,
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(0, 2, "(posts.is_draft = ?) AND (posts.published_at < ? OR (posts.published_at = ? AND posts.id < ?))", gomock.Any(), nil, "posts.published_at desc, posts.id desc", nil).Return(existsPosts()[1:], nil)
+				mock.EXPECT().Count("posts.is_draft = ?", []interface{}{false}, nil).Return(2, nil)
+			},
+			wantIDs:        []string{"abcdefghijklmnopqrstuvwxy1"},
+			wantNextCursor: "",
+			wantPrevCursor: entity.NewCursor("published_at", flextime.Now().Add(-time.Hour), "abcdefghijklmnopqrstuvwxy1", true).Encode(),
+			wantErr:        nil,
+		},
+		{
+			name:   "前のページはカーソルに近い投稿から逆順に取得して並べ直すこと",
+			cursor: entity.NewCursor("published_at", flextime.Now().Add(-2*time.Hour), "abcdefghijklmnopqrstuvwxy0", true),
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				posts := existsPosts()
+				mock.EXPECT().FindAll(0, 2, gomock.Any(), gomock.Any(), nil, "posts.published_at asc, posts.id asc", nil).Return([]*entity.Post{posts[1], posts[0]}, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(3, nil)
+			},
+			wantIDs:        []string{"abcdefghijklmnopqrstuvwxy1"},
+			wantNextCursor: entity.NewCursor("published_at", flextime.Now().Add(-time.Hour), "abcdefghijklmnopqrstuvwxy1", false).Encode(),
+			wantPrevCursor: entity.NewCursor("published_at", flextime.Now().Add(-time.Hour), "abcdefghijklmnopqrstuvwxy1", true).Encode(),
+			wantErr:        nil,
+		},
+		{
+			name:                  "並べ替えるカラムとカーソルのカラムが異なる場合はErrCursorInvalidを返すこと",
+			cursor:                entity.NewCursor("title", "new_post", "abcdefghijklmnopqrstuvwxy1", false),
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {},
+			wantIDs:               nil,
+			wantErr:               entity.ErrCursorInvalid,
+		},
+		{
+			name:   "投稿がない場合はErrPostNotFoundを返すこと",
+			cursor: nextOfFirst,
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, entity.ErrPostNotFound)
+			},
+			wantIDs: nil,
+			wantErr: entity.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			p := &PostUseCase{
+				postRepository: mr,
+			}
+
+			got, _, nextCursor, prevCursor, err := p.GetPostsByCursor(1, "posts.is_draft = ?", []interface{}{false}, nil, "published_at", true, false, tt.cursor, nil, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetPostsByCursor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ids []string
+			for _, post := range got {
+				ids = append(ids, post.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, ids); diff != "" {
+				t.Errorf("GetPostsByCursor() mismatch (-want +got):\n%s", diff)
+			}
+			if nextCursor != tt.wantNextCursor {
+				t.Errorf("GetPostsByCursor() nextCursor = %v, want %v", nextCursor, tt.wantNextCursor)
+			}
+			if prevCursor != tt.wantPrevCursor {
+				t.Errorf("GetPostsByCursor() prevCursor = %v, want %v", prevCursor, tt.wantPrevCursor)
+			}
+		})
+	}
+}
+
+func TestPostUseCase_GetPostsByCursor_sortColumn(t *testing.T) {
+	tests := []struct {
+		name       string
+		sortColumn string
+	}{
+		{
+			name:       "本文で並べ替える場合はErrCursorInvalidを返すこと",
+			sortColumn: "content",
+		},
+		{
+			name:       "サムネイルのURLで並べ替える場合はErrCursorInvalidを返すこと",
+			sortColumn: "thumbnail_url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			p := &PostUseCase{
+				postRepository: mr,
+			}
+
+			if _, _, _, _, err := p.GetPostsByCursor(1, "", nil, nil, tt.sortColumn, false, true, nil, nil, false); !errors.Is(err, entity.ErrCursorInvalid) {
+				t.Errorf("GetPostsByCursor() error = %v, wantErr %v", err, entity.ErrCursorInvalid)
+			}
+		})
+	}
+}
+
+func TestPostUseCase_SearchPosts(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	existsPosts := func() []*entity.Post {
+		return []*entity.Post{{
+			ID:           "abcdefghijklmnopqrstuvwxyz",
+			Title:        "new_post",
+			ThumbnailURL: "new_thumbnail_url",
+			Content:      "**new_content**",
+			Permalink:    "new_permalink",
+			IsDraft:      false,
+			CreatedAt:    flextime.Now(),
+			UpdatedAt:    flextime.Now(),
+			PublishedAt:  flextime.Now(),
+		}}
+	}
+
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		want                  []*dto.PostDTO
+		wantCount             int
+		wantErr               error
+	}{
+		{
+			name: "検索語を強調した抜粋付きのpostDTOsを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().Search("content", 0, 10, "", []interface{}{}, nil).Return(existsPosts(), nil)
+				mock.EXPECT().CountSearch("content", "", []interface{}{}, nil).Return(1, nil)
+			},
+			want: []*dto.PostDTO{
+				{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "<p><strong>new_content</strong></p>\n",
+					Excerpt:      "new_content",
+					CharCount:    11,
+					WordCount:    2,
+					ReadingTime:  1,
+					Permalink:    "new_permalink",
+					IsDraft:      func() *bool { b := false; return &b }(),
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  flextime.Now(),
+					Snippet:      "**new_<mark>content</mark>**",
+				},
+			},
+			wantCount: 1,
+			wantErr:   nil,
+		},
+		{
+			name: "一致する投稿がない場合はErrPostNotFoundを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, entity.ErrPostNotFound)
+			},
+			want:      nil,
+			wantCount: 0,
+			wantErr:   entity.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			p := &PostUseCase{
+				postRepository: mr,
+			}
+
+			got, count, err := p.SearchPosts("content", 0, 10, "", []interface{}{}, nil, true)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SearchPosts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if count != tt.wantCount {
+				t.Errorf("SearchPosts() count = %d, want %d", count, tt.wantCount)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SearchPosts() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestPostUseCase_GetPost(t *testing.T) {
 
 	loc, err := time.LoadLocation("Asia/Tokyo")
@@ -468,15 +1087,15 @@ func TestPostUseCase_GetPost(t *testing.T) {
 
 	tests := []struct {
 		name                  string
-		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect)
 		permalink             string
 		isMarkdown            bool
 		want                  *dto.PostDTO
-		wantErr               bool
+		wantErr               error
 	}{
 		{
 			name: "isMarkdownがtrueの時はmarkdownのままpostDTOを返すこと",
-			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
 				mock.EXPECT().FindByPermalink(gomock.Any()).Return(existsPost, nil)
 			},
 			want: &dto.PostDTO{
@@ -484,6 +1103,10 @@ func TestPostUseCase_GetPost(t *testing.T) {
 				Title:        "new_post",
 				ThumbnailURL: "new_thumbnail_url",
 				Content:      "new_content",
+				Excerpt:      "new_content",
+				CharCount:    11,
+				WordCount:    2,
+				ReadingTime:  1,
 				Permalink:    "new_permalink",
 				IsDraft:      func() *bool { b := false; return &b }(),
 				CreatedAt:    flextime.Now(),
@@ -492,10 +1115,10 @@ func TestPostUseCase_GetPost(t *testing.T) {
 			},
 			permalink:  "new_permalink",
 			isMarkdown: true,
-			wantErr:    false,
+			wantErr:    nil,
 		}, {
 			name: "postDTOを返すこと",
-			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
 				mock.EXPECT().FindByPermalink(gomock.Any()).Return(existsPost, nil)
 			},
 			want: &dto.PostDTO{
@@ -503,6 +1126,10 @@ func TestPostUseCase_GetPost(t *testing.T) {
 				Title:        "new_post",
 				ThumbnailURL: "new_thumbnail_url",
 				Content:      "<p>new_content</p>\n",
+				Excerpt:      "new_content",
+				CharCount:    11,
+				WordCount:    2,
+				ReadingTime:  1,
 				Permalink:    "new_permalink",
 				IsDraft:      func() *bool { b := false; return &b }(),
 				CreatedAt:    flextime.Now(),
@@ -511,18 +1138,86 @@ func TestPostUseCase_GetPost(t *testing.T) {
 			},
 			permalink:  "new_permalink",
 			isMarkdown: false,
-			wantErr:    false,
+			wantErr:    nil,
 		},
 
+		{
+			name: "公開日時が未来の投稿はErrPostNotFoundを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink(gomock.Any()).Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "new_permalink",
+					IsDraft:      false,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  flextime.Now().Add(time.Hour),
+				}, nil)
+			},
+			permalink:  "new_permalink",
+			isMarkdown: false,
+			want:       nil,
+			wantErr:    entity.ErrPostNotFound,
+		},
 		{
 			name: "FindByPermalinkがエラーを返した時はpostDTOが空であること",
-			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
 				mock.EXPECT().FindByPermalink("not_found").Return(nil, entity.ErrPostNotFound)
+				mockRedirects.EXPECT().FindByPermalink("not_found").Return(nil, entity.ErrPermalinkRedirectNotFound)
 			},
 			permalink:  "not_found",
 			isMarkdown: false,
 			want:       nil,
-			wantErr:    true,
+			wantErr:    entity.ErrPostNotFound,
+		},
+		{
+			name: "変更前のパーマリンクの場合はErrPostMovedと共に現在の投稿を返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink("old_permalink").Return(nil, entity.ErrPostNotFound)
+				mockRedirects.EXPECT().FindByPermalink("old_permalink").Return(&entity.PermalinkRedirect{
+					ID:        "abcdefghijklmnopqrstuvwxy1",
+					PostID:    "abcdefghijklmnopqrstuvwxyz",
+					Permalink: "old_permalink",
+				}, nil)
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{
+					ID:          "abcdefghijklmnopqrstuvwxyz",
+					Permalink:   "new_permalink",
+					IsDraft:     false,
+					PublishedAt: flextime.Now(),
+				}, nil)
+			},
+			permalink:  "old_permalink",
+			isMarkdown: false,
+			want: &dto.PostDTO{
+				ID:          "abcdefghijklmnopqrstuvwxyz",
+				Permalink:   "new_permalink",
+				IsDraft:     func() *bool { b := false; return &b }(),
+				PublishedAt: flextime.Now(),
+			},
+			wantErr: entity.ErrPostMoved,
+		},
+		{
+			name: "リダイレクト先が公開日時前の場合はErrPostNotFoundを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink("old_permalink").Return(nil, entity.ErrPostNotFound)
+				mockRedirects.EXPECT().FindByPermalink("old_permalink").Return(&entity.PermalinkRedirect{
+					ID:        "abcdefghijklmnopqrstuvwxy1",
+					PostID:    "abcdefghijklmnopqrstuvwxyz",
+					Permalink: "old_permalink",
+				}, nil)
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{
+					ID:          "abcdefghijklmnopqrstuvwxyz",
+					Permalink:   "new_permalink",
+					IsDraft:     false,
+					PublishedAt: flextime.Now().Add(time.Hour),
+				}, nil)
+			},
+			permalink:  "old_permalink",
+			isMarkdown: false,
+			want:       nil,
+			wantErr:    entity.ErrPostNotFound,
 		},
 	}
 
@@ -531,14 +1226,16 @@ func TestPostUseCase_GetPost(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mr := mock_repository.NewMockPost(ctrl)
-			tt.prepareMockPostRepoFn(mr)
+			mpr := mock_repository.NewMockPermalinkRedirect(ctrl)
+			tt.prepareMockPostRepoFn(mr, mpr)
 			p := &PostUseCase{
-				postRepository: mr,
+				postRepository:              mr,
+				permalinkRedirectRepository: mpr,
 			}
 
 			got, err := p.GetPost(tt.permalink, tt.isMarkdown)
 
-			if (err != nil) != tt.wantErr {
+			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("GetPost() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -549,8 +1246,7 @@ func TestPostUseCase_GetPost(t *testing.T) {
 	}
 }
 
-func TestPostUseCase_DeletePost(t *testing.T) {
-
+func TestPostUseCase_GetArchives(t *testing.T) {
 	loc, err := time.LoadLocation("Asia/Tokyo")
 	if err != nil {
 		t.Fatal(err)
@@ -561,23 +1257,38 @@ func TestPostUseCase_DeletePost(t *testing.T) {
 	tests := []struct {
 		name                  string
 		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
-		ID                    string
+		want                  []*dto.ArchiveDTO
 		wantErr               bool
 	}{
 		{
-			name: "削除に成功した場合はエラーを返さないこと",
+			name: "年ごとに月ごとの投稿数をまとめて返すこと",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().Delete(gomock.Any()).Return(nil)
+				mock.EXPECT().CountByMonth(gomock.Any(), []interface{}{false, flextime.Now()}).Return([]*entity.MonthlyPostCount{
+					{Year: 2021, Month: 1, Count: 2},
+					{Year: 2020, Month: 12, Count: 3},
+					{Year: 2020, Month: 3, Count: 1},
+				}, nil)
+			},
+			want: []*dto.ArchiveDTO{
+				{Year: 2021, Count: 2, Months: []*dto.MonthlyArchiveDTO{{Month: 1, Count: 2}}},
+				{Year: 2020, Count: 4, Months: []*dto.MonthlyArchiveDTO{{Month: 12, Count: 3}, {Month: 3, Count: 1}}},
 			},
-			ID:      "abcdefghijklmnopqrstuvwxyz",
 			wantErr: false,
 		},
 		{
-			name: "Deleteがエラーを返した時はエラーを返すこと",
+			name: "公開済みの投稿がない場合は空のスライスを返すこと",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().Delete("not_found").Return(entity.ErrPostNotFound)
+				mock.EXPECT().CountByMonth(gomock.Any(), gomock.Any()).Return(nil, nil)
 			},
-			ID:      "not_found",
+			want:    []*dto.ArchiveDTO{},
+			wantErr: false,
+		},
+		{
+			name: "取得に失敗した場合はエラーを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().CountByMonth(gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -592,10 +1303,634 @@ func TestPostUseCase_DeletePost(t *testing.T) {
 				postRepository: mr,
 			}
 
-			err := p.DeletePost(tt.ID)
-
+			got, err := p.GetArchives()
 			if (err != nil) != tt.wantErr {
-				t.Errorf("GetPost() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetArchives() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetArchives() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostUseCase_PublishScheduledPosts(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		want                  int
+		wantErr               bool
+	}{
+		{
+			name: "公開日時を過ぎた予約投稿を公開すること",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(0, 0, gomock.Any(), []interface{}{true, true, flextime.Now()}, nil, gomock.Any(), nil).Return([]*entity.Post{
+					{
+						ID:          "abcdefghijklmnopqrstuvwxyz",
+						Title:       "new_post",
+						Content:     "new_content",
+						Permalink:   "new_permalink",
+						IsDraft:     true,
+						IsScheduled: true,
+						PublishedAt: flextime.Now().Add(-time.Minute),
+					},
+				}, nil)
+				mock.EXPECT().Update(gomock.Any()).DoAndReturn(func(post *entity.Post) error {
+					if post.IsDraft || post.IsScheduled {
+						t.Errorf("Update() IsDraft = %v, IsScheduled = %v, want false", post.IsDraft, post.IsScheduled)
+					}
+					if !post.PublishedAt.Equal(flextime.Now().Add(-time.Minute)) {
+						t.Errorf("Update() PublishedAt = %v, want scheduled time", post.PublishedAt)
+					}
+					return nil
+				})
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "公開する予約投稿がない場合は何もしないこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), nil, gomock.Any(), nil).Return(nil, entity.ErrPostNotFound)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "更新に失敗した場合はエラーを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), nil, gomock.Any(), nil).Return([]*entity.Post{
+					{ID: "abcdefghijklmnopqrstuvwxyz", IsDraft: true, IsScheduled: true},
+				}, nil)
+				mock.EXPECT().Update(gomock.Any()).Return(errors.New("dummy error"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			p := &PostUseCase{
+				postRepository: mr,
+			}
+
+			got, err := p.PublishScheduledPosts()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PublishScheduledPosts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PublishScheduledPosts() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostUseCase_DeletePost(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		ID                    string
+		wantErr               bool
+	}{
+		{
+			name: "削除に成功した場合はエラーを返さないこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().Delete(gomock.Any()).Return(nil)
+			},
+			ID:      "abcdefghijklmnopqrstuvwxyz",
+			wantErr: false,
+		},
+		{
+			name: "Deleteがエラーを返した時はエラーを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().Delete("not_found").Return(entity.ErrPostNotFound)
+			},
+			ID:      "not_found",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			p := &PostUseCase{
+				postRepository: mr,
+			}
+
+			err := p.DeletePost(tt.ID)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPostUseCase_RestorePost(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	deletedPost := func() *entity.Post {
+		return &entity.Post{
+			ID:          "abcdefghijklmnopqrstuvwxyz",
+			Title:       "new_post",
+			Content:     "new_content",
+			Permalink:   "new_permalink",
+			IsDraft:     false,
+			CreatedAt:   flextime.Now(),
+			UpdatedAt:   flextime.Now(),
+			PublishedAt: flextime.Now(),
+			DeletedAt:   gorm.DeletedAt{Time: flextime.Now(), Valid: true},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect)
+		want                  *dto.PostDTO
+		wantErr               error
+	}{
+		{
+			name: "ゴミ箱にある投稿を元に戻して返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindDeletedByID("abcdefghijklmnopqrstuvwxyz").Return(deletedPost(), nil)
+				mock.EXPECT().FindByPermalink("new_permalink").Return(nil, entity.ErrPostNotFound)
+				mockRedirects.EXPECT().FindByPermalink("new_permalink").Return(nil, entity.ErrPermalinkRedirectNotFound)
+				mock.EXPECT().Restore("abcdefghijklmnopqrstuvwxyz").Return(nil)
+			},
+			want: &dto.PostDTO{
+				ID:          "abcdefghijklmnopqrstuvwxyz",
+				Title:       "new_post",
+				Content:     "new_content",
+				Permalink:   "new_permalink",
+				IsDraft:     func() *bool { b := false; return &b }(),
+				CreatedAt:   flextime.Now(),
+				UpdatedAt:   flextime.Now(),
+				PublishedAt: flextime.Now(),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "同じパーマリンクの投稿が存在する場合はErrPermalinkAlreadyExistedを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindDeletedByID("abcdefghijklmnopqrstuvwxyz").Return(deletedPost(), nil)
+				mock.EXPECT().FindByPermalink("new_permalink").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxy2"}, nil)
+			},
+			want:    nil,
+			wantErr: entity.ErrPermalinkAlreadyExisted,
+		},
+		{
+			name: "パーマリンクが他の投稿のリダイレクトに使われている場合はErrPermalinkAlreadyExistedを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindDeletedByID("abcdefghijklmnopqrstuvwxyz").Return(deletedPost(), nil)
+				mock.EXPECT().FindByPermalink("new_permalink").Return(nil, entity.ErrPostNotFound)
+				mockRedirects.EXPECT().FindByPermalink("new_permalink").Return(&entity.PermalinkRedirect{
+					ID:        "abcdefghijklmnopqrstuvwxy1",
+					PostID:    "abcdefghijklmnopqrstuvwxy2",
+					Permalink: "new_permalink",
+				}, nil)
+			},
+			want:    nil,
+			wantErr: entity.ErrPermalinkAlreadyExisted,
+		},
+		{
+			name: "ゴミ箱に投稿がない場合はErrPostNotFoundを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindDeletedByID("abcdefghijklmnopqrstuvwxyz").Return(nil, entity.ErrPostNotFound)
+			},
+			want:    nil,
+			wantErr: entity.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			mpr := mock_repository.NewMockPermalinkRedirect(ctrl)
+			tt.prepareMockPostRepoFn(mr, mpr)
+			p := &PostUseCase{
+				postRepository:              mr,
+				permalinkRedirectRepository: mpr,
+			}
+
+			got, err := p.RestorePost("abcdefghijklmnopqrstuvwxyz")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RestorePost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("RestorePost() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostUseCase_GetPostRevisions(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	existsRevisions := []*entity.PostRevision{
+		{
+			ID:           "abcdefghijklmnopqrstuvwxy2",
+			PostID:       "abcdefghijklmnopqrstuvwxyz",
+			Title:        "new_post",
+			ThumbnailURL: "new_thumbnail_url",
+			Content:      "new_content2",
+			Permalink:    "new_permalink",
+			CreatedAt:    flextime.Now(),
+			UpdatedAt:    flextime.Now(),
+		},
+		{
+			ID:           "abcdefghijklmnopqrstuvwxy1",
+			PostID:       "abcdefghijklmnopqrstuvwxyz",
+			Title:        "new_post",
+			ThumbnailURL: "new_thumbnail_url",
+			Content:      "new_content",
+			Permalink:    "new_permalink",
+			CreatedAt:    flextime.Now(),
+			UpdatedAt:    flextime.Now(),
+		},
+	}
+
+	tests := []struct {
+		name                          string
+		prepareMockPostRevisionRepoFn func(mock *mock_repository.MockPostRevision)
+		postID                        string
+		want                          []*dto.PostRevisionDTO
+		wantCount                     int
+		wantErr                       error
+	}{
+		{
+			name: "投稿のリビジョン一覧を返すこと",
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByPostID("abcdefghijklmnopqrstuvwxyz", 0, 0).Return(existsRevisions, nil)
+				mock.EXPECT().CountByPostID("abcdefghijklmnopqrstuvwxyz").Return(2, nil)
+			},
+			postID: "abcdefghijklmnopqrstuvwxyz",
+			want: []*dto.PostRevisionDTO{
+				existsRevisions[0].ConvertToDTO(),
+				existsRevisions[1].ConvertToDTO(),
+			},
+			wantCount: 2,
+			wantErr:   nil,
+		},
+		{
+			name: "リビジョンが存在しない場合はErrPostRevisionNotFoundを返すこと",
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByPostID("not_found", 0, 0).Return(nil, entity.ErrPostRevisionNotFound)
+			},
+			postID:    "not_found",
+			want:      nil,
+			wantCount: 0,
+			wantErr:   entity.ErrPostRevisionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mrr := mock_repository.NewMockPostRevision(ctrl)
+			tt.prepareMockPostRevisionRepoFn(mrr)
+			p := &PostUseCase{
+				postRevisionRepository: mrr,
+			}
+
+			got, count, err := p.GetPostRevisions(tt.postID, 0, 0)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetPostRevisions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if count != tt.wantCount {
+				t.Errorf("GetPostRevisions() count = %d, want %d", count, tt.wantCount)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetPostRevisions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostUseCase_GetPostRevisionDiff(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	fromRevision := &entity.PostRevision{
+		ID:           "abcdefghijklmnopqrstuvwxy1",
+		PostID:       "abcdefghijklmnopqrstuvwxyz",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+	}
+	toRevision := &entity.PostRevision{
+		ID:           "abcdefghijklmnopqrstuvwxy2",
+		PostID:       "abcdefghijklmnopqrstuvwxyz",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content2",
+		Permalink:    "new_permalink",
+	}
+
+	tests := []struct {
+		name                          string
+		prepareMockPostRepoFn         func(mock *mock_repository.MockPost)
+		prepareMockPostRevisionRepoFn func(mock *mock_repository.MockPostRevision)
+		fromID                        string
+		toID                          string
+		want                          string
+		wantErr                       error
+	}{
+		{
+			name:                  "2つのリビジョン間のunified diffを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {},
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(fromRevision, nil)
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy2").Return(toRevision, nil)
+			},
+			fromID: "abcdefghijklmnopqrstuvwxy1",
+			toID:   "abcdefghijklmnopqrstuvwxy2",
+			want: "--- abcdefghijklmnopqrstuvwxy1\n" +
+				"+++ abcdefghijklmnopqrstuvwxy2\n" +
+				"@@ -2,4 +2,4 @@\n" +
+				" permalink: new_permalink\n" +
+				" thumbnailUrl: new_thumbnail_url\n" +
+				" \n" +
+				"-new_content\n" +
+				"+new_content2\n",
+			wantErr: nil,
+		},
+		{
+			name: "toIDが空の場合は現在の投稿とのdiffを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post2",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "new_permalink",
+				}, nil)
+			},
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(fromRevision, nil)
+			},
+			fromID: "abcdefghijklmnopqrstuvwxy1",
+			toID:   "",
+			want: "--- abcdefghijklmnopqrstuvwxy1\n" +
+				"+++ current\n" +
+				"@@ -1,4 +1,4 @@\n" +
+				"-title: new_post\n" +
+				"+title: new_post2\n" +
+				" permalink: new_permalink\n" +
+				" thumbnailUrl: new_thumbnail_url\n" +
+				" \n",
+			wantErr: nil,
+		},
+		{
+			name:                  "別の投稿のリビジョンを指定した場合はErrPostRevisionNotFoundを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {},
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy3").Return(&entity.PostRevision{
+					ID:     "abcdefghijklmnopqrstuvwxy3",
+					PostID: "other_post",
+				}, nil)
+			},
+			fromID:  "abcdefghijklmnopqrstuvwxy3",
+			toID:    "",
+			want:    "",
+			wantErr: entity.ErrPostRevisionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			mrr := mock_repository.NewMockPostRevision(ctrl)
+			tt.prepareMockPostRevisionRepoFn(mrr)
+			p := &PostUseCase{
+				postRepository:         mr,
+				postRevisionRepository: mrr,
+			}
+
+			got, err := p.GetPostRevisionDiff("abcdefghijklmnopqrstuvwxyz", tt.fromID, tt.toID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetPostRevisionDiff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetPostRevisionDiff() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostUseCase_RestorePostRevision(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	existsPost := func() *entity.Post {
+		return &entity.Post{
+			ID:           "abcdefghijklmnopqrstuvwxyz",
+			Title:        "new_post",
+			ThumbnailURL: "new_thumbnail_url",
+			Content:      "new_content2",
+			Permalink:    "new_permalink",
+			IsDraft:      false,
+			CreatedAt:    flextime.Now(),
+			UpdatedAt:    flextime.Now(),
+			PublishedAt:  flextime.Now(),
+		}
+	}
+
+	tests := []struct {
+		name                          string
+		prepareMockPostRepoFn         func(mock *mock_repository.MockPost)
+		prepareMockPostRevisionRepoFn func(mock *mock_repository.MockPostRevision)
+		revisionID                    string
+		wantContent                   string
+		wantErr                       error
+	}{
+		{
+			name: "リビジョンの内容で投稿を更新し、復元前の内容をリビジョンとして残すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(existsPost(), nil).Times(2)
+				mock.EXPECT().FindByPermalink("new_permalink").Return(existsPost(), nil)
+				mock.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.PostRevision{
+					ID:           "abcdefghijklmnopqrstuvwxy1",
+					PostID:       "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "new_permalink",
+				}, nil)
+			},
+			revisionID:  "abcdefghijklmnopqrstuvwxy1",
+			wantContent: "new_content",
+			wantErr:     nil,
+		},
+		{
+			name:                  "リビジョンが存在しない場合はErrPostRevisionNotFoundを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {},
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByID("not_found").Return(nil, entity.ErrPostRevisionNotFound)
+			},
+			revisionID: "not_found",
+			wantErr:    entity.ErrPostRevisionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			mrr := mock_repository.NewMockPostRevision(ctrl)
+			tt.prepareMockPostRevisionRepoFn(mrr)
+			p := &PostUseCase{
+				postRepository:         mr,
+				postRevisionRepository: mrr,
+			}
+
+			got, err := p.RestorePostRevision("abcdefghijklmnopqrstuvwxyz", tt.revisionID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RestorePostRevision() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Content != tt.wantContent {
+				t.Errorf("RestorePostRevision() Content = %v, want %v", got.Content, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestPostUseCase_GetPostsByCursor_pinnedFirst(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr := mock_repository.NewMockPost(ctrl)
+	mr.EXPECT().FindAll(0, 2, "posts.is_draft = ?", []interface{}{false}, nil, "posts.is_pinned desc, posts.id asc", nil).Return([]*entity.Post{
+		{ID: "abcdefghijklmnopqrstuvwxy2", IsPinned: true},
+		{ID: "abcdefghijklmnopqrstuvwxy1"},
+	}, nil)
+	mr.EXPECT().Count("posts.is_draft = ?", []interface{}{false}, nil).Return(2, nil)
+	p := &PostUseCase{
+		postRepository: mr,
+	}
+
+	_, _, nextCursor, _, err := p.GetPostsByCursor(1, "posts.is_draft = ?", []interface{}{false}, nil, "id", false, true, nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 固定した投稿の次のページは固定していない投稿から始める
+	want := &entity.Cursor{Column: "id", Value: "abcdefghijklmnopqrstuvwxy2", ID: "abcdefghijklmnopqrstuvwxy2", Pinned: true}
+	if nextCursor != want.Encode() {
+		t.Errorf("GetPostsByCursor() nextCursor = %v, want %v", nextCursor, want.Encode())
+	}
+}
+
+func TestPostUseCase_PinPost(t *testing.T) {
+	tests := []struct {
+		name                  string
+		featuredRank          int
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		wantErr               error
+	}{
+		{
+			name:         "投稿を固定しておすすめの順番を設定できる",
+			featuredRank: 2,
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mock.EXPECT().UpdatePin("abcdefghijklmnopqrstuvwxyz", true, 2).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:                  "おすすめの順番が負の数の場合はErrFeaturedRankInvalidを返す",
+			featuredRank:          -1,
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {},
+			wantErr:               entity.ErrFeaturedRankInvalid,
+		},
+		{
+			name:         "投稿が存在しない場合はErrPostNotFoundを返す",
+			featuredRank: 0,
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(nil, entity.ErrPostNotFound)
+			},
+			wantErr: entity.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			p := &PostUseCase{
+				postRepository: mr,
+			}
+
+			got, err := p.PinPost("abcdefghijklmnopqrstuvwxyz", tt.featuredRank)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PinPost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !got.IsPinned || got.FeaturedRank != tt.featuredRank {
+				t.Errorf("PinPost() isPinned = %v, featuredRank = %v, want true, %v", got.IsPinned, got.FeaturedRank, tt.featuredRank)
 			}
 		})
 	}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/repository"
+)
+
+type PreviewUseCase struct {
+	postRepository         repository.Post
+	previewTokenRepository repository.PreviewToken
+	secret                 []byte
+}
+
+func NewPreviewUseCase(postRepository repository.Post, previewTokenRepository repository.PreviewToken, secret []byte) *PreviewUseCase {
+	return &PreviewUseCase{
+		postRepository:         postRepository,
+		previewTokenRepository: previewTokenRepository,
+		secret:                 secret,
+	}
+}
+
+// CreatePreviewToken は投稿のプレビューリンクを ttl の間だけ有効なトークンとして発行します
+func (p *PreviewUseCase) CreatePreviewToken(postID string, ttl time.Duration) (*dto.PreviewTokenDTO, error) {
+	// 実際に投稿が存在するかのチェックであり結果は使わない
+	_, err := p.postRepository.FindByID(postID)
+	if err != nil {
+		return nil, fmt.Errorf("create preview token post id=%v: %w", postID, err)
+	}
+
+	// DBは秒単位で保存するため，トークンに含める有効期限と揃える
+	previewToken := entity.NewPreviewToken(postID, flextime.Now().Add(ttl).Truncate(time.Second))
+	err = p.previewTokenRepository.Create(previewToken)
+	if err != nil {
+		return nil, fmt.Errorf("create preview token post id=%v: %w", postID, err)
+	}
+
+	return previewToken.ConvertToDTO(p.secret), nil
+}
+
+// GetPreviewTokens は投稿のプレビューリンクを新しい順に返します
+func (p *PreviewUseCase) GetPreviewTokens(postID string) (previewTokenDTOs []*dto.PreviewTokenDTO, err error) {
+	var previewTokens []*entity.PreviewToken
+	previewTokens, err = p.previewTokenRepository.FindByPostID(postID)
+	if err != nil {
+		err = fmt.Errorf("get preview tokens post id=%v: %w", postID, err)
+		return
+	}
+
+	for _, previewToken := range previewTokens {
+		previewTokenDTOs = append(previewTokenDTOs, previewToken.ConvertToDTO(p.secret))
+	}
+	return
+}
+
+// RevokePreviewToken はプレビューリンクを無効にします
+func (p *PreviewUseCase) RevokePreviewToken(postID, previewTokenID string) error {
+	previewToken, err := p.previewTokenRepository.FindByID(previewTokenID)
+	if err != nil {
+		return fmt.Errorf("revoke preview token id=%v: %w", previewTokenID, err)
+	}
+	// 別の投稿のプレビューリンクは存在しないものとして扱う
+	if previewToken.PostID != postID {
+		return fmt.Errorf("revoke preview token id=%v post id=%v: %w", previewTokenID, postID, entity.ErrPreviewTokenNotFound)
+	}
+
+	err = p.previewTokenRepository.Delete(previewTokenID)
+	if err != nil {
+		return fmt.Errorf("revoke preview token id=%v: %w", previewTokenID, err)
+	}
+	return nil
+}
+
+// GetPreview はプレビューリンクのトークンを検証し，下書きを含む投稿をhtmlに変換して返します
+func (p *PreviewUseCase) GetPreview(token string) (*dto.PostDTO, error) {
+	id, expiresAt, err := entity.ParsePreviewToken(token, p.secret)
+	if err != nil {
+		return nil, fmt.Errorf("get preview: %w", err)
+	}
+	now := flextime.Now()
+	if !now.Before(expiresAt) {
+		return nil, fmt.Errorf("get preview id=%v: %w", id, entity.ErrPreviewTokenExpired)
+	}
+
+	// 無効にされたプレビューリンクは行が削除されている
+	var previewToken *entity.PreviewToken
+	previewToken, err = p.previewTokenRepository.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("get preview id=%v: %w", id, err)
+	}
+	if previewToken.IsExpired(now) {
+		return nil, fmt.Errorf("get preview id=%v: %w", id, entity.ErrPreviewTokenExpired)
+	}
+
+	var post *entity.Post
+	post, err = p.postRepository.FindByID(previewToken.PostID)
+	if err != nil {
+		return nil, fmt.Errorf("get preview id=%v: %w", id, err)
+	}
+
+	post.ConvertContentToHTML()
+	return post.ConvertToDTO(), nil
+}
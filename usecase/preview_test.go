@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+)
+
+var previewSecret = []byte("secret")
+
+func TestPreviewUseCase_CreatePreviewToken(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	tests := []struct {
+		name              string
+		postID            string
+		ttl               time.Duration
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken)
+		wantExpiresAt     time.Time
+		wantErr           error
+	}{
+		{
+			name:   "ttlの間だけ有効なプレビューリンクを発行すること",
+			postID: "abcdefghijklmnopqrstuvwxyz",
+			ttl:    72 * time.Hour,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mockPreviewTokens.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+			wantExpiresAt: time.Date(2021, 1, 25, 0, 0, 0, 0, loc),
+			wantErr:       nil,
+		},
+		{
+			name:   "投稿が存在しない場合はErrPostNotFoundを返すこと",
+			postID: "abcdefghijklmnopqrstuvwxyz",
+			ttl:    72 * time.Hour,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(nil, entity.ErrPostNotFound)
+			},
+			wantErr: entity.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mpt := mock_repository.NewMockPreviewToken(ctrl)
+			tt.prepareMockRepoFn(mp, mpt)
+			p := NewPreviewUseCase(mp, mpt, previewSecret)
+
+			got, err := p.CreatePreviewToken(tt.postID, tt.ttl)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreatePreviewToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.PostID != tt.postID || !got.ExpiresAt.Equal(tt.wantExpiresAt) {
+				t.Errorf("CreatePreviewToken() got = %v, want postID = %v expiresAt = %v", got, tt.postID, tt.wantExpiresAt)
+			}
+			id, expiresAt, err := entity.ParsePreviewToken(got.Token, previewSecret)
+			if err != nil || id != got.ID || !expiresAt.Equal(tt.wantExpiresAt) {
+				t.Errorf("CreatePreviewToken() token = %v is not signed correctly: %v", got.Token, err)
+			}
+		})
+	}
+}
+
+func TestPreviewUseCase_RevokePreviewToken(t *testing.T) {
+	tests := []struct {
+		name              string
+		postID            string
+		previewTokenID    string
+		prepareMockRepoFn func(mockPreviewTokens *mock_repository.MockPreviewToken)
+		wantErr           error
+	}{
+		{
+			name:           "プレビューリンクを無効にすること",
+			postID:         "abcdefghijklmnopqrstuvwxyz",
+			previewTokenID: "abcdefghijklmnopqrstuvwxy1",
+			prepareMockRepoFn: func(mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPreviewTokens.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.PreviewToken{ID: "abcdefghijklmnopqrstuvwxy1", PostID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mockPreviewTokens.EXPECT().Delete("abcdefghijklmnopqrstuvwxy1").Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:           "別の投稿のプレビューリンクの場合はErrPreviewTokenNotFoundを返すこと",
+			postID:         "abcdefghijklmnopqrstuvwxyz",
+			previewTokenID: "abcdefghijklmnopqrstuvwxy1",
+			prepareMockRepoFn: func(mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPreviewTokens.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.PreviewToken{ID: "abcdefghijklmnopqrstuvwxy1", PostID: "abcdefghijklmnopqrstuvwxy2"}, nil)
+			},
+			wantErr: entity.ErrPreviewTokenNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mpt := mock_repository.NewMockPreviewToken(ctrl)
+			tt.prepareMockRepoFn(mpt)
+			p := NewPreviewUseCase(mp, mpt, previewSecret)
+
+			err := p.RevokePreviewToken(tt.postID, tt.previewTokenID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RevokePreviewToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPreviewUseCase_GetPreview(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	validToken := &entity.PreviewToken{
+		ID:        "abcdefghijklmnopqrstuvwxy1",
+		PostID:    "abcdefghijklmnopqrstuvwxyz",
+		ExpiresAt: time.Date(2021, 1, 25, 0, 0, 0, 0, loc),
+	}
+	expiredToken := &entity.PreviewToken{
+		ID:        "abcdefghijklmnopqrstuvwxy2",
+		PostID:    "abcdefghijklmnopqrstuvwxyz",
+		ExpiresAt: time.Date(2021, 1, 21, 0, 0, 0, 0, loc),
+	}
+
+	tests := []struct {
+		name              string
+		token             string
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken)
+		want              *dto.PostDTO
+		wantErr           error
+	}{
+		{
+			name:  "有効なトークンの場合は下書きをhtmlに変換して返すこと",
+			token: validToken.Sign(previewSecret),
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPreviewTokens.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(validToken, nil)
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{
+					ID:        "abcdefghijklmnopqrstuvwxyz",
+					Title:     "draft",
+					Content:   "**draft**",
+					Permalink: "draft",
+					IsDraft:   true,
+				}, nil)
+			},
+			want: &dto.PostDTO{
+				ID:        "abcdefghijklmnopqrstuvwxyz",
+				Title:     "draft",
+				Content:   "<p><strong>draft</strong></p>\n",
+				Permalink: "draft",
+				IsDraft:   func() *bool { b := true; return &b }(),
+			},
+			wantErr: nil,
+		},
+		{
+			name:              "有効期限が切れている場合はErrPreviewTokenExpiredを返すこと",
+			token:             expiredToken.Sign(previewSecret),
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {},
+			want:              nil,
+			wantErr:           entity.ErrPreviewTokenExpired,
+		},
+		{
+			name:              "署名が正しくない場合はErrPreviewTokenInvalidを返すこと",
+			token:             validToken.Sign([]byte("another secret")),
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {},
+			want:              nil,
+			wantErr:           entity.ErrPreviewTokenInvalid,
+		},
+		{
+			name:  "無効にされたプレビューリンクの場合はErrPreviewTokenNotFoundを返すこと",
+			token: validToken.Sign(previewSecret),
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPreviewTokens.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(nil, entity.ErrPreviewTokenNotFound)
+			},
+			want:    nil,
+			wantErr: entity.ErrPreviewTokenNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mpt := mock_repository.NewMockPreviewToken(ctrl)
+			tt.prepareMockRepoFn(mp, mpt)
+			p := NewPreviewUseCase(mp, mpt, previewSecret)
+
+			got, err := p.GetPreview(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetPreview() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetPreview() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/constant"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+// relatedPostCandidates は関連度の高い順に公開済みかを確かめる投稿の数です
+const relatedPostCandidates = 50
+
+// relatedPostCache は関連する投稿を探すための公開済みの投稿ごとの TF-IDF のベクトルを保持します
+// 全ての投稿の本文を読んで計算するので，投稿が更新されるまで使い回します
+type relatedPostCache struct {
+	mu         sync.Mutex
+	vectors    map[string]entity.TermVector
+	generation int
+}
+
+// invalidate は投稿が更新されたときに呼び，次に使うときにベクトルを計算し直させます
+func (c *relatedPostCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.vectors = nil
+	c.generation++
+}
+
+// relatedPostVectors は投稿ごとの TF-IDF のベクトルを返します．キャッシュがなければ計算します
+func (p *PostUseCase) relatedPostVectors() (map[string]entity.TermVector, error) {
+	p.related.mu.Lock()
+	vectors, generation := p.related.vectors, p.related.generation
+	p.related.mu.Unlock()
+	if vectors != nil {
+		return vectors, nil
+	}
+
+	posts, err := p.postRepository.FindAll(0, 0, "posts.is_draft = ?", []interface{}{false}, nil, "posts.id asc", []string{"posts.id", "posts.title", "posts.content"})
+	if err != nil && !errors.Is(err, entity.ErrPostNotFound) {
+		return nil, fmt.Errorf("find posts: %w", err)
+	}
+
+	documents := make([][]string, 0, len(posts))
+	for _, post := range posts {
+		// タイトルは本文より内容をよく表すので2回数える
+		documents = append(documents, entity.Tokenize(strings.Repeat(post.Title+" ", 2)+entity.PlainText(post.Content)))
+	}
+	vectors = make(map[string]entity.TermVector, len(posts))
+	for i, vector := range entity.NewTFIDFVectors(documents) {
+		vectors[posts[i].ID] = vector
+	}
+
+	// 計算している間に投稿が更新された場合は古い内容なので保存しない
+	p.related.mu.Lock()
+	if p.related.generation == generation {
+		p.related.vectors = vectors
+	}
+	p.related.mu.Unlock()
+	return vectors, nil
+}
+
+// GetRelatedPosts は permalink の投稿に関連する公開済みの投稿を関連度の高い順に返します
+// 関連度は共通するタグの数と，タイトルと本文の TF-IDF のコサイン類似度から計算します
+func (p *PostUseCase) GetRelatedPosts(permalink string) ([]*dto.PostDTO, error) {
+	post, err := p.postRepository.FindByPermalink(permalink)
+	if err != nil {
+		return nil, fmt.Errorf("get related posts permalink=%v: %w", permalink, err)
+	}
+	if post.IsDraft || post.IsBeforePublish(flextime.Now()) {
+		return nil, fmt.Errorf("get related posts not published permalink=%v: %w", permalink, entity.ErrPostNotFound)
+	}
+
+	vectors, err := p.relatedPostVectors()
+	if err != nil {
+		return nil, fmt.Errorf("get related posts permalink=%v: %w", permalink, err)
+	}
+	sharedTags, err := p.postRepository.CountSharedTags(post.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get related posts permalink=%v: %w", permalink, err)
+	}
+
+	scores := make(map[string]float64)
+	for id, count := range sharedTags {
+		scores[id] += float64(count) * constant.RelatedTagWeight
+	}
+	if vector, ok := vectors[post.ID]; ok {
+		for id, other := range vectors {
+			if similarity := vector.Cosine(other); similarity > 0 {
+				scores[id] += similarity
+			}
+		}
+	}
+	delete(scores, post.ID)
+
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > relatedPostCandidates {
+		ids = ids[:relatedPostCandidates]
+	}
+	if len(ids) == 0 {
+		return []*dto.PostDTO{}, nil
+	}
+
+	// ゴミ箱に移動した投稿や公開日時がまだ来ていない投稿を除く
+	posts, err := p.postRepository.FindAll(0, 0, "posts.id IN ? AND "+publishedPostCondition, []interface{}{ids, false, flextime.Now()}, nil, "posts.id asc", nil)
+	if err != nil && !errors.Is(err, entity.ErrPostNotFound) {
+		return nil, fmt.Errorf("get related posts permalink=%v: %w", permalink, err)
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		if scores[posts[i].ID] != scores[posts[j].ID] {
+			return scores[posts[i].ID] > scores[posts[j].ID]
+		}
+		return posts[i].ID < posts[j].ID
+	})
+	if len(posts) > constant.RelatedPostsSize {
+		posts = posts[:constant.RelatedPostsSize]
+	}
+	return convertPostsToDTO(posts, false), nil
+}
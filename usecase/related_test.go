@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+)
+
+func TestPostUseCase_GetRelatedPosts(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	target := &entity.Post{ID: "abcdefghijklmnopqrstuvwxy0", Title: "GoでMySQLを使う", Content: "database/sqlとgormの使い方", Permalink: "go-mysql", PublishedAt: flextime.Now().Add(-time.Hour)}
+	published := []*entity.Post{
+		target,
+		{ID: "abcdefghijklmnopqrstuvwxy1", Title: "gormの使い方", Content: "GoでMySQLを使う"},
+		{ID: "abcdefghijklmnopqrstuvwxy2", Title: "Rustの所有権", Content: "借用について"},
+		{ID: "abcdefghijklmnopqrstuvwxy3", Title: "ラーメン", Content: "おいしい"},
+	}
+	findPublished := func(mock *mock_repository.MockPost, ids []string) {
+		mock.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), nil, gomock.Any(), nil).DoAndReturn(func(_, _ int, _ string, params []interface{}, _ *entity.TagFilter, _ string, _ []string) ([]*entity.Post, error) {
+			if diff := cmp.Diff(ids, params[0]); diff != "" {
+				t.Errorf("FindAll() ids mismatch (-want +got):\n%s", diff)
+			}
+			var posts []*entity.Post
+			for _, post := range published {
+				for _, id := range ids {
+					if post.ID == id {
+						posts = append(posts, &entity.Post{ID: post.ID, Title: post.Title})
+					}
+				}
+			}
+			return posts, nil
+		})
+	}
+
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		wantIDs               []string
+		wantErr               bool
+	}{
+		{
+			name: "共通するタグと本文の類似度が高い順に返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByPermalink("go-mysql").Return(target, nil)
+				mock.EXPECT().FindAll(0, 0, "posts.is_draft = ?", []interface{}{false}, nil, gomock.Any(), gomock.Any()).Return(published, nil)
+				mock.EXPECT().CountSharedTags(target.ID).Return(map[string]int{"abcdefghijklmnopqrstuvwxy2": 3}, nil)
+				findPublished(mock, []string{"abcdefghijklmnopqrstuvwxy2", "abcdefghijklmnopqrstuvwxy1"})
+			},
+			wantIDs: []string{"abcdefghijklmnopqrstuvwxy2", "abcdefghijklmnopqrstuvwxy1"},
+		},
+		{
+			name: "下書きの場合はErrPostNotFoundを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByPermalink("go-mysql").Return(&entity.Post{ID: target.ID, IsDraft: true}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "関連する投稿がない場合は空のスライスを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByPermalink("go-mysql").Return(target, nil)
+				mock.EXPECT().FindAll(0, 0, "posts.is_draft = ?", []interface{}{false}, nil, gomock.Any(), gomock.Any()).Return([]*entity.Post{target}, nil)
+				mock.EXPECT().CountSharedTags(target.ID).Return(map[string]int{}, nil)
+			},
+			wantIDs: []string{},
+		},
+		{
+			name: "共通するタグの取得に失敗した場合はエラーを返すこと",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByPermalink("go-mysql").Return(target, nil)
+				mock.EXPECT().FindAll(0, 0, "posts.is_draft = ?", []interface{}{false}, nil, gomock.Any(), gomock.Any()).Return(published, nil)
+				mock.EXPECT().CountSharedTags(target.ID).Return(nil, errors.New("dummy error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			p := &PostUseCase{
+				postRepository: mr,
+			}
+
+			got, err := p.GetRelatedPosts("go-mysql")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetRelatedPosts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			gotIDs := make([]string, 0, len(got))
+			for _, post := range got {
+				gotIDs = append(gotIDs, post.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("GetRelatedPosts() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostUseCase_GetRelatedPosts_cache(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr := mock_repository.NewMockPost(ctrl)
+	target := &entity.Post{ID: "abcdefghijklmnopqrstuvwxy0", Title: "Go", Permalink: "go"}
+	mr.EXPECT().FindByPermalink("go").Return(target, nil).Times(3)
+	mr.EXPECT().CountSharedTags(target.ID).Return(map[string]int{}, nil).Times(3)
+	mr.EXPECT().Delete(target.ID).Return(nil)
+	// 投稿が更新されるまではベクトルを計算し直さない
+	mr.EXPECT().FindAll(0, 0, "posts.is_draft = ?", gomock.Any(), nil, gomock.Any(), gomock.Any()).Return([]*entity.Post{target}, nil).Times(2)
+	p := &PostUseCase{
+		postRepository: mr,
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := p.GetRelatedPosts("go"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.DeletePost(target.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.GetRelatedPosts("go"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/repository"
+)
+
+type SeriesUseCase struct {
+	seriesRepository     repository.Series
+	seriesPostRepository repository.SeriesPost
+	postRepository       repository.Post
+}
+
+func NewSeriesUseCase(seriesRepository repository.Series, seriesPostRepository repository.SeriesPost, postRepository repository.Post) *SeriesUseCase {
+	return &SeriesUseCase{
+		seriesRepository:     seriesRepository,
+		seriesPostRepository: seriesPostRepository,
+		postRepository:       postRepository,
+	}
+}
+
+func (s *SeriesUseCase) StoreSeries(seriesDTO *dto.SeriesDTO) (*dto.SeriesDTO, error) {
+	series := entity.NewSeries(seriesDTO.Title, seriesDTO.Description)
+	if err := s.seriesRepository.Store(series); err != nil {
+		return nil, fmt.Errorf("store series title=%v: %w", seriesDTO.Title, err)
+	}
+	return series.ConvertToDTO(), nil
+}
+
+// GetSeriesList は連載を新しい順に返します
+func (s *SeriesUseCase) GetSeriesList() (seriesDTOs []*dto.SeriesDTO, err error) {
+	var seriesList []*entity.Series
+	seriesList, err = s.seriesRepository.FindAll()
+	if err != nil {
+		err = fmt.Errorf("get series list: %w", err)
+		return
+	}
+	for _, series := range seriesList {
+		seriesDTOs = append(seriesDTOs, series.ConvertToDTO())
+	}
+	return
+}
+
+// GetSeries は連載と，連載に含まれる公開済みの投稿を順番に返します
+func (s *SeriesUseCase) GetSeries(id string) (*dto.SeriesDTO, error) {
+	series, err := s.seriesRepository.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("get series id=%v: %w", id, err)
+	}
+
+	posts, err := s.publishedSeriesPosts(series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get series id=%v: %w", id, err)
+	}
+
+	seriesDTO := series.ConvertToDTO()
+	seriesDTO.Posts = convertPostsToDTO(posts, false)
+	return seriesDTO, nil
+}
+
+// UpdateSeries は連載のタイトルと説明を更新します
+func (s *SeriesUseCase) UpdateSeries(seriesDTO *dto.SeriesDTO) (*dto.SeriesDTO, error) {
+	series, err := s.seriesRepository.FindByID(seriesDTO.ID)
+	if err != nil {
+		return nil, fmt.Errorf("update series id=%v: %w", seriesDTO.ID, err)
+	}
+
+	series.Title = seriesDTO.Title
+	series.Description = seriesDTO.Description
+	if err = s.seriesRepository.Update(series); err != nil {
+		return nil, fmt.Errorf("update series id=%v: %w", seriesDTO.ID, err)
+	}
+	return series.ConvertToDTO(), nil
+}
+
+// DeleteSeries は連載を削除します．連載に含まれていた投稿は削除しません
+func (s *SeriesUseCase) DeleteSeries(id string) error {
+	if err := s.seriesRepository.Delete(id); err != nil {
+		return fmt.Errorf("delete series id=%v: %w", id, err)
+	}
+	return nil
+}
+
+// GetPostSeries は投稿が含まれる連載の何回目かと前後の投稿を返します
+// 投稿が連載に含まれていないか，まだ公開されていない場合は nil を返します
+func (s *SeriesUseCase) GetPostSeries(postID string) (*dto.PostSeriesDTO, error) {
+	seriesPost, err := s.seriesPostRepository.FindByPostID(postID)
+	if err != nil {
+		if errors.Is(err, entity.ErrSeriesPostNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get post series post id=%v: %w", postID, err)
+	}
+
+	series, err := s.seriesRepository.FindByID(seriesPost.SeriesID)
+	if err != nil {
+		return nil, fmt.Errorf("get post series post id=%v: %w", postID, err)
+	}
+
+	posts, err := s.publishedSeriesPosts(series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get post series post id=%v: %w", postID, err)
+	}
+	return entity.NewPostSeries(series, &entity.Post{ID: postID}, posts), nil
+}
+
+// publishedSeriesPosts は連載に含まれる投稿のうち公開済みのものを順番に返します
+// 下書きに戻した投稿や予約投稿は連載に含めたまま飛ばすので，何回目かは公開済みの投稿だけで数えます
+func (s *SeriesUseCase) publishedSeriesPosts(seriesID string) ([]*entity.Post, error) {
+	seriesPosts, err := s.seriesPostRepository.FindBySeriesID(seriesID)
+	if err != nil {
+		if errors.Is(err, entity.ErrSeriesPostNotFound) {
+			return []*entity.Post{}, nil
+		}
+		return nil, fmt.Errorf("find series posts: %w", err)
+	}
+
+	postIDs := make([]string, 0, len(seriesPosts))
+	for _, seriesPost := range seriesPosts {
+		postIDs = append(postIDs, seriesPost.PostID)
+	}
+	found, err := s.postRepository.FindAll(0, 0, "posts.id IN ? AND "+publishedPostCondition, []interface{}{postIDs, false, flextime.Now()}, nil, "posts.id asc", nil)
+	if err != nil && !errors.Is(err, entity.ErrPostNotFound) {
+		return nil, fmt.Errorf("find series posts: %w", err)
+	}
+
+	postsByID := make(map[string]*entity.Post, len(found))
+	for _, post := range found {
+		postsByID[post.ID] = post
+	}
+	posts := make([]*entity.Post, 0, len(found))
+	for _, seriesPost := range seriesPosts {
+		if post, ok := postsByID[seriesPost.PostID]; ok {
+			posts = append(posts, post)
+		}
+	}
+	return posts, nil
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+)
+
+func TestSeriesUseCase_GetSeries(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	seriesID := "abcdefghijklmnopqrstuvwxy0"
+	tests := []struct {
+		name          string
+		prepareMockFn func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost)
+		wantIDs       []string
+		wantErr       error
+	}{
+		{
+			name: "連載に含まれる公開済みの投稿を順番に返す",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID, Title: "Go入門"}, nil)
+				mockSP.EXPECT().FindBySeriesID(seriesID).Return([]*entity.SeriesPost{
+					{SeriesID: seriesID, PostID: "abcdefghijklmnopqrstuvwxy3", Position: 1},
+					{SeriesID: seriesID, PostID: "abcdefghijklmnopqrstuvwxy1", Position: 2},
+					{SeriesID: seriesID, PostID: "abcdefghijklmnopqrstuvwxy2", Position: 3},
+				}, nil)
+				// abcdefghijklmnopqrstuvwxy1 は下書きなので返ってこない
+				mockPosts.EXPECT().FindAll(0, 0, "posts.id IN ? AND "+publishedPostCondition, []interface{}{[]string{"abcdefghijklmnopqrstuvwxy3", "abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy2"}, false, flextime.Now()}, nil, gomock.Any(), nil).Return([]*entity.Post{
+					{ID: "abcdefghijklmnopqrstuvwxy2"},
+					{ID: "abcdefghijklmnopqrstuvwxy3"},
+				}, nil)
+			},
+			wantIDs: []string{"abcdefghijklmnopqrstuvwxy3", "abcdefghijklmnopqrstuvwxy2"},
+			wantErr: nil,
+		},
+		{
+			name: "投稿を含まない連載の場合は空のスライスを返す",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID, Title: "Go入門"}, nil)
+				mockSP.EXPECT().FindBySeriesID(seriesID).Return(nil, entity.ErrSeriesPostNotFound)
+			},
+			wantIDs: []string{},
+			wantErr: nil,
+		},
+		{
+			name: "存在しない連載の場合はErrSeriesNotFoundを返す",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(nil, entity.ErrSeriesNotFound)
+			},
+			wantErr: entity.ErrSeriesNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mS := mock_repository.NewMockSeries(ctrl)
+			mSP := mock_repository.NewMockSeriesPost(ctrl)
+			mP := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockFn(mS, mSP, mP)
+			s := &SeriesUseCase{
+				seriesRepository:     mS,
+				seriesPostRepository: mSP,
+				postRepository:       mP,
+			}
+
+			got, err := s.GetSeries(seriesID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetSeries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			gotIDs := make([]string, 0, len(got.Posts))
+			for _, post := range got.Posts {
+				gotIDs = append(gotIDs, post.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("GetSeries() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSeriesUseCase_GetPostSeries(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	seriesID := "abcdefghijklmnopqrstuvwxy0"
+	postID := "abcdefghijklmnopqrstuvwxy2"
+	tests := []struct {
+		name          string
+		prepareMockFn func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost)
+		want          *dto.PostSeriesDTO
+		wantErr       error
+	}{
+		{
+			name: "何回目かと前後の投稿を返す",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost) {
+				mockSP.EXPECT().FindByPostID(postID).Return(&entity.SeriesPost{SeriesID: seriesID, PostID: postID, Position: 2}, nil)
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID, Title: "Go入門"}, nil)
+				mockSP.EXPECT().FindBySeriesID(seriesID).Return([]*entity.SeriesPost{
+					{SeriesID: seriesID, PostID: "abcdefghijklmnopqrstuvwxy1", Position: 1},
+					{SeriesID: seriesID, PostID: postID, Position: 2},
+					{SeriesID: seriesID, PostID: "abcdefghijklmnopqrstuvwxy3", Position: 3},
+				}, nil)
+				mockPosts.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), nil, gomock.Any(), nil).Return([]*entity.Post{
+					{ID: "abcdefghijklmnopqrstuvwxy1", Title: "第1回", Permalink: "go-1"},
+					{ID: postID, Title: "第2回", Permalink: "go-2"},
+					{ID: "abcdefghijklmnopqrstuvwxy3", Title: "第3回", Permalink: "go-3"},
+				}, nil)
+			},
+			want: &dto.PostSeriesDTO{
+				ID:    seriesID,
+				Title: "Go入門",
+				Part:  2,
+				Total: 3,
+				Prev:  &dto.SeriesPartDTO{Title: "第1回", Permalink: "go-1"},
+				Next:  &dto.SeriesPartDTO{Title: "第3回", Permalink: "go-3"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "連載に含まれていない場合はnilを返す",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost) {
+				mockSP.EXPECT().FindByPostID(postID).Return(nil, entity.ErrSeriesPostNotFound)
+			},
+			want:    nil,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mS := mock_repository.NewMockSeries(ctrl)
+			mSP := mock_repository.NewMockSeriesPost(ctrl)
+			mP := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockFn(mS, mSP, mP)
+			s := &SeriesUseCase{
+				seriesRepository:     mS,
+				seriesPostRepository: mSP,
+				postRepository:       mP,
+			}
+
+			got, err := s.GetPostSeries(postID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetPostSeries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetPostSeries() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/constant"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/repository"
+)
+
+type SitemapUseCase struct {
+	postRepository repository.Post
+	tagRepository  repository.Tag
+}
+
+func NewSitemapUseCase(postRepository repository.Post, tagRepository repository.Tag) *SitemapUseCase {
+	return &SitemapUseCase{
+		postRepository: postRepository,
+		tagRepository:  tagRepository,
+	}
+}
+
+const publishedPostCondition = "posts.is_draft = ? AND posts.published_at <= ?"
+
+// CountSitemaps は全てのURLを載せるのに必要なサイトマップの数を返します
+func (s *SitemapUseCase) CountSitemaps() (int, error) {
+	postCount, err := s.postRepository.Count(publishedPostCondition, []interface{}{false, flextime.Now()}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("count sitemaps: %w", err)
+	}
+
+	var tags []*entity.Tag
+	tags, err = s.findPublishedTags()
+	if err != nil {
+		return 0, fmt.Errorf("count sitemaps: %w", err)
+	}
+
+	total := 1 + postCount + len(tags)
+	return (total + constant.SitemapMaxURLs - 1) / constant.SitemapMaxURLs, nil
+}
+
+// GetSitemapEntries は page 番目(1始まり)のサイトマップに載せるURLを返します
+// URLはトップページ，公開済みの投稿，公開済みの投稿が付いたタグの順に並べて分割します
+func (s *SitemapUseCase) GetSitemapEntries(page int) ([]*dto.SitemapEntryDTO, error) {
+	if page < 1 {
+		return nil, fmt.Errorf("get sitemap entries page=%d: %w", page, entity.ErrSitemapNotFound)
+	}
+	now := flextime.Now()
+	start := (page - 1) * constant.SitemapMaxURLs
+	end := start + constant.SitemapMaxURLs
+
+	entries := make([]*dto.SitemapEntryDTO, 0)
+	if start == 0 {
+		entries = append(entries, &dto.SitemapEntryDTO{Path: "/"})
+	}
+
+	// トップページの分だけずらして投稿の範囲を求める
+	postStart := start - 1
+	if postStart < 0 {
+		postStart = 0
+	}
+	postEnd := end - 1
+
+	postCount, err := s.postRepository.Count(publishedPostCondition, []interface{}{false, now}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get sitemap entries: %w", err)
+	}
+
+	if postStart < postCount {
+		var posts []*entity.Post
+		posts, err = s.postRepository.FindAll(postStart, postEnd-postStart, publishedPostCondition, []interface{}{false, now}, nil, "posts.id asc", nil)
+		if err != nil && !errors.Is(err, entity.ErrPostNotFound) {
+			return nil, fmt.Errorf("get sitemap entries: %w", err)
+		}
+		for _, post := range posts {
+			entries = append(entries, &dto.SitemapEntryDTO{
+				Path:         "/posts/" + url.PathEscape(post.Permalink),
+				LastModified: post.UpdatedAt,
+			})
+		}
+	}
+
+	var tags []*entity.Tag
+	tags, err = s.findPublishedTags()
+	if err != nil {
+		return nil, fmt.Errorf("get sitemap entries: %w", err)
+	}
+	tagStart := postStart - postCount
+	if tagStart < 0 {
+		tagStart = 0
+	}
+	tagEnd := postEnd - postCount
+	if tagEnd > len(tags) {
+		tagEnd = len(tags)
+	}
+	for i := tagStart; i < tagEnd; i++ {
+		entries = append(entries, &dto.SitemapEntryDTO{
+			Path:         "/tags/" + url.PathEscape(tags[i].Name),
+			LastModified: tags[i].UpdatedAt,
+		})
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("get sitemap entries page=%d: %w", page, entity.ErrSitemapNotFound)
+	}
+	return entries, nil
+}
+
+// findPublishedTags は公開済みの投稿が付いたタグを取得します
+func (s *SitemapUseCase) findPublishedTags() ([]*entity.Tag, error) {
+	tags, err := s.tagRepository.FindAll(0, 0, publishedPostCondition, []interface{}{false, flextime.Now()}, "")
+	if err != nil && !errors.Is(err, entity.ErrTagNotFound) {
+		return nil, fmt.Errorf("find published tags: %w", err)
+	}
+	// ページをまたいでも順番が変わらないようにIDで並べる
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].ID < tags[j].ID
+	})
+	return tags, nil
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/constant"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+)
+
+func TestSitemapUseCase_CountSitemaps(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	defaultMaxURLs := constant.SitemapMaxURLs
+	constant.SitemapMaxURLs = 2
+	defer func() { constant.SitemapMaxURLs = defaultMaxURLs }()
+
+	tests := []struct {
+		name              string
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag)
+		want              int
+		wantErr           bool
+	}{
+		{
+			name: "トップページ,投稿,タグの数から必要なサイトマップの数を返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), []interface{}{false, flextime.Now()}, nil).Return(3, nil)
+				mockTags.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), "").Return([]*entity.Tag{{ID: "abcdefghijklmnopqrstuvwxy1"}}, nil)
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "投稿もタグもない場合はトップページのみのサイトマップになる",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(0, nil)
+				mockTags.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), "").Return(nil, entity.ErrTagNotFound)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "投稿数の取得に失敗した場合はエラーを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(0, errors.New("dummy error"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mt := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockRepoFn(mp, mt)
+			s := NewSitemapUseCase(mp, mt)
+
+			got, err := s.CountSitemaps()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CountSitemaps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CountSitemaps() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSitemapUseCase_GetSitemapEntries(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	defaultMaxURLs := constant.SitemapMaxURLs
+	constant.SitemapMaxURLs = 2
+	defer func() { constant.SitemapMaxURLs = defaultMaxURLs }()
+
+	posts := []*entity.Post{
+		{ID: "abcdefghijklmnopqrstuvwxy1", Permalink: "post1", UpdatedAt: flextime.Now()},
+		{ID: "abcdefghijklmnopqrstuvwxy2", Permalink: "post2", UpdatedAt: flextime.Now()},
+	}
+	tags := []*entity.Tag{
+		{ID: "abcdefghijklmnopqrstuvwxy4", Name: "tag2", UpdatedAt: flextime.Now()},
+		{ID: "abcdefghijklmnopqrstuvwxy3", Name: "tag 1", UpdatedAt: flextime.Now()},
+	}
+
+	tests := []struct {
+		name              string
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag)
+		page              int
+		want              []*dto.SitemapEntryDTO
+		wantErr           error
+	}{
+		{
+			name: "1ページ目はトップページと投稿を返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(2, nil)
+				mockPosts.EXPECT().FindAll(0, 1, gomock.Any(), gomock.Any(), nil, "posts.id asc", nil).Return(posts[:1], nil)
+				mockTags.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), "").Return(tags, nil)
+			},
+			page: 1,
+			want: []*dto.SitemapEntryDTO{
+				{Path: "/"},
+				{Path: "/posts/post1", LastModified: flextime.Now()},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "2ページ目は投稿の続きとタグをID順に返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(2, nil)
+				mockPosts.EXPECT().FindAll(1, 2, gomock.Any(), gomock.Any(), nil, "posts.id asc", nil).Return(posts[1:], nil)
+				mockTags.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), "").Return(tags, nil)
+			},
+			page: 2,
+			want: []*dto.SitemapEntryDTO{
+				{Path: "/posts/post2", LastModified: flextime.Now()},
+				{Path: "/tags/tag%201", LastModified: flextime.Now()},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "3ページ目は残りのタグを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(2, nil)
+				mockTags.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), "").Return(tags, nil)
+			},
+			page: 3,
+			want: []*dto.SitemapEntryDTO{
+				{Path: "/tags/tag2", LastModified: flextime.Now()},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "URLが存在しないページはErrSitemapNotFoundを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(2, nil)
+				mockTags.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), "").Return(tags, nil)
+			},
+			page:    4,
+			want:    nil,
+			wantErr: entity.ErrSitemapNotFound,
+		},
+		{
+			name:              "0ページ目はErrSitemapNotFoundを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {},
+			page:              0,
+			want:              nil,
+			wantErr:           entity.ErrSitemapNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mt := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockRepoFn(mp, mt)
+			s := NewSitemapUseCase(mp, mt)
+
+			got, err := s.GetSitemapEntries(tt.page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetSitemapEntries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetSitemapEntries() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -29,7 +29,33 @@ func (p *TagUseCase) StoreTag(tagDTO *dto.TagDTO) (*dto.TagDTO, error) {
 		return nil, fmt.Errorf("store tag name=%v: %w", tagDTO.Name, entity.ErrTagNameAlreadyExisted)
 	}
 
+	// ゴミ箱にある同じ名前のタグは一意制約により作り直せないため元に戻して使う
+	tag, err = p.tagRepository.FindDeletedByName(tagDTO.Name)
+	if err != nil && !errors.Is(err, entity.ErrTagNotFound) {
+		return nil, fmt.Errorf("store tag name=%v: %w", tagDTO.Name, err)
+	}
+	if tag != nil {
+		// ゴミ箱にあるタグにも子のタグが残っていることがあるので，元に戻すタグの子孫を親にしていないか確かめる
+		if err = p.validateParent(tag.ID, tagDTO.ParentID); err != nil {
+			return nil, fmt.Errorf("store tag name=%v: %w", tagDTO.Name, err)
+		}
+		if err = p.tagRepository.Restore(tag.ID); err != nil {
+			return nil, fmt.Errorf("store tag name=%v: %w", tagDTO.Name, err)
+		}
+		tag.DeletedAt.Valid = false
+		tag.ParentID = tagDTO.ParentID
+		if err = p.tagRepository.Update(tag); err != nil {
+			return nil, fmt.Errorf("store tag name=%v: %w", tagDTO.Name, err)
+		}
+		return tag.ConvertToDTO(), nil
+	}
+
+	if err = p.validateParent("", tagDTO.ParentID); err != nil {
+		return nil, fmt.Errorf("store tag name=%v: %w", tagDTO.Name, err)
+	}
+
 	tag = entity.NewTag(tagDTO.Name)
+	tag.ParentID = tagDTO.ParentID
 
 	err = p.tagRepository.Store(tag)
 	if err != nil {
@@ -41,7 +67,7 @@ func (p *TagUseCase) StoreTag(tagDTO *dto.TagDTO) (*dto.TagDTO, error) {
 
 func (p *TagUseCase) GetTags(offset, pageSize int, condition string, params []interface{}) (tagDTOs []*dto.TagDTO, count int, err error) {
 	var tags []*entity.Tag
-	tags, err = p.tagRepository.FindAll(offset, pageSize, condition, params)
+	tags, err = p.tagRepository.FindAll(offset, pageSize, condition, params, "")
 	if err != nil {
 		err = fmt.Errorf("get tags: %w", err)
 		return
@@ -58,6 +84,101 @@ func (p *TagUseCase) GetTags(offset, pageSize int, condition string, params []in
 	return
 }
 
+// GetTagsByCursor はキーセットページネーションで cursor の位置から pageSize 件のタグをIDの順に返します
+// cursor が nil の場合は最初のページを返し，次や前のページがある場合はその位置を表すカーソルも返します
+func (p *TagUseCase) GetTagsByCursor(pageSize int, condition string, params []interface{}, cursor *entity.Cursor) (tagDTOs []*dto.TagDTO, count int, nextCursor, prevCursor string, err error) {
+	pageCondition, pageParams := condition, params
+	before := false
+	if cursor != nil {
+		if cursor.Column != "id" {
+			err = fmt.Errorf("get tags by cursor column=%v: %w", cursor.Column, entity.ErrCursorInvalid)
+			return
+		}
+		var cursorCondition string
+		var cursorParams []interface{}
+		cursorCondition, cursorParams, err = cursor.Condition("tags", false)
+		if err != nil {
+			err = fmt.Errorf("get tags by cursor: %w", err)
+			return
+		}
+		pageCondition, pageParams = andCondition(condition, params, cursorCondition, cursorParams)
+		before = cursor.Before
+	}
+
+	// 次のページがあるかを調べるために1件多く取得する
+	var tags []*entity.Tag
+	tags, err = p.tagRepository.FindAll(0, pageSize+1, pageCondition, pageParams, entity.CursorSortCondition("tags", "id", false, before))
+	if err != nil {
+		err = fmt.Errorf("get tags by cursor: %w", err)
+		return
+	}
+	hasMore := len(tags) > pageSize
+	if hasMore {
+		tags = tags[:pageSize]
+	}
+	// 前のページは逆順に取得しているので並べ直す
+	if before {
+		for i, j := 0, len(tags)-1; i < j; i, j = i+1, j-1 {
+			tags[i], tags[j] = tags[j], tags[i]
+		}
+	}
+
+	count, err = p.tagRepository.Count()
+	if err != nil {
+		err = fmt.Errorf("count tags: %w", err)
+		return
+	}
+
+	hasNext, hasPrev := hasMore, cursor != nil
+	if before {
+		hasNext, hasPrev = true, hasMore
+	}
+	first, last := tags[0], tags[len(tags)-1]
+	if hasNext {
+		nextCursor = entity.NewCursor("id", last.ID, last.ID, false).Encode()
+	}
+	if hasPrev {
+		prevCursor = entity.NewCursor("id", first.ID, first.ID, true).Encode()
+	}
+
+	for _, tag := range tags {
+		tagDTOs = append(tagDTOs, tag.ConvertToDTO())
+	}
+	return
+}
+
+// GetPostTags は投稿に付いているタグを返します．タグが付いていなければ空のスライスを返します
+func (p *TagUseCase) GetPostTags(postID string) ([]*dto.TagDTO, error) {
+	tags, err := p.tagRepository.FindAll(0, 0, "posts_tags.post_id = ?", []interface{}{postID}, "")
+	if err != nil {
+		if errors.Is(err, entity.ErrTagNotFound) {
+			return []*dto.TagDTO{}, nil
+		}
+		return nil, fmt.Errorf("get post tags post id=%v: %w", postID, err)
+	}
+	tagDTOs := make([]*dto.TagDTO, 0, len(tags))
+	for _, tag := range tags {
+		tagDTOs = append(tagDTOs, tag.ConvertToDTO())
+	}
+	return tagDTOs, nil
+}
+
+// GetPostsTags は投稿ごとに付いているタグをまとめて返します．タグが付いていない投稿は空のスライスにします
+func (p *TagUseCase) GetPostsTags(postIDs []string) (map[string][]*dto.TagDTO, error) {
+	tags, err := p.tagRepository.FindByPostIDs(postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get posts tags: %w", err)
+	}
+	tagDTOs := make(map[string][]*dto.TagDTO, len(postIDs))
+	for _, postID := range postIDs {
+		tagDTOs[postID] = make([]*dto.TagDTO, 0, len(tags[postID]))
+		for _, tag := range tags[postID] {
+			tagDTOs[postID] = append(tagDTOs[postID], tag.ConvertToDTO())
+		}
+	}
+	return tagDTOs, nil
+}
+
 func (p *TagUseCase) GetTag(id string) (tagDTO *dto.TagDTO, err error) {
 	var tag *entity.Tag
 	tag, err = p.tagRepository.FindByID(id)
@@ -69,6 +190,18 @@ func (p *TagUseCase) GetTag(id string) (tagDTO *dto.TagDTO, err error) {
 	return
 }
 
+// GetTagByName は name のタグを返します
+func (p *TagUseCase) GetTagByName(name string) (tagDTO *dto.TagDTO, err error) {
+	var tag *entity.Tag
+	tag, err = p.tagRepository.FindByName(name)
+	if err != nil {
+		err = fmt.Errorf("get tag name=%v: %w", name, err)
+		return
+	}
+	tagDTO = tag.ConvertToDTO()
+	return
+}
+
 func (p *TagUseCase) DeleteTag(id string) (err error) {
 	err = p.tagRepository.Delete(id)
 	if err != nil {
@@ -77,3 +210,133 @@ func (p *TagUseCase) DeleteTag(id string) (err error) {
 	}
 	return nil
 }
+
+// RestoreTag はゴミ箱にあるタグを元に戻します
+func (p *TagUseCase) RestoreTag(id string) (tagDTO *dto.TagDTO, err error) {
+	err = p.tagRepository.Restore(id)
+	if err != nil {
+		err = fmt.Errorf("restore tag id=%v: %w", id, err)
+		return
+	}
+	var tag *entity.Tag
+	tag, err = p.tagRepository.FindByID(id)
+	if err != nil {
+		err = fmt.Errorf("restore tag id=%v: %w", id, err)
+		return
+	}
+	tagDTO = tag.ConvertToDTO()
+	return
+}
+
+// UpdateTag はタグの名前と親タグを変更します
+func (p *TagUseCase) UpdateTag(tagDTO *dto.TagDTO) (*dto.TagDTO, error) {
+	tag, err := p.tagRepository.FindByID(tagDTO.ID)
+	if err != nil {
+		return nil, fmt.Errorf("update tag id=%v: %w", tagDTO.ID, err)
+	}
+
+	var sameNameTag *entity.Tag
+	sameNameTag, err = p.tagRepository.FindByName(tagDTO.Name)
+	if err != nil && !errors.Is(err, entity.ErrTagNotFound) {
+		return nil, fmt.Errorf("update tag id=%v: %w", tagDTO.ID, err)
+	}
+	if sameNameTag != nil && sameNameTag.ID != tag.ID {
+		return nil, fmt.Errorf("update tag id=%v name=%v: %w", tagDTO.ID, tagDTO.Name, entity.ErrTagNameAlreadyExisted)
+	}
+
+	if err = p.validateParent(tag.ID, tagDTO.ParentID); err != nil {
+		return nil, fmt.Errorf("update tag id=%v: %w", tagDTO.ID, err)
+	}
+
+	tag.Name = tagDTO.Name
+	tag.ParentID = tagDTO.ParentID
+	err = p.tagRepository.Update(tag)
+	if err != nil {
+		return nil, fmt.Errorf("update tag id=%v: %w", tagDTO.ID, err)
+	}
+	return tag.ConvertToDTO(), nil
+}
+
+// MergeTag はタグを targetID のタグに統合し，統合先のタグを返します
+// 投稿と子タグは統合先に付け替えられ，統合元のタグは完全に削除されます
+func (p *TagUseCase) MergeTag(id, targetID string) (*dto.TagDTO, error) {
+	tag, err := p.tagRepository.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("merge tag id=%v: %w", id, err)
+	}
+
+	var target *entity.Tag
+	target, err = p.tagRepository.FindByID(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("merge tag id=%v into id=%v: %w", id, targetID, err)
+	}
+
+	// 子孫に統合すると統合先の祖先に統合先自身が付け替えられて循環してしまう
+	var isDescendant bool
+	isDescendant, err = p.isDescendantOrSelf(target, tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("merge tag id=%v into id=%v: %w", id, targetID, err)
+	}
+	if isDescendant {
+		return nil, fmt.Errorf("merge tag id=%v into id=%v: %w", id, targetID, entity.ErrTagMergeTargetInvalid)
+	}
+
+	err = p.tagRepository.Merge(tag.ID, target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("merge tag id=%v into id=%v: %w", id, targetID, err)
+	}
+	return target.ConvertToDTO(), nil
+}
+
+// validateParent は親タグが存在し，id のタグ自身やその子孫ではないことを確認します
+func (p *TagUseCase) validateParent(id string, parentID *string) error {
+	if parentID == nil {
+		return nil
+	}
+
+	parent, err := p.tagRepository.FindByID(*parentID)
+	if err != nil {
+		if errors.Is(err, entity.ErrTagNotFound) {
+			return fmt.Errorf("validate parent tag id=%v: %w", *parentID, entity.ErrTagParentNotFound)
+		}
+		return fmt.Errorf("validate parent tag id=%v: %w", *parentID, err)
+	}
+
+	var isDescendant bool
+	isDescendant, err = p.isDescendantOrSelf(parent, id)
+	if err != nil {
+		return fmt.Errorf("validate parent tag id=%v: %w", *parentID, err)
+	}
+	if isDescendant {
+		return fmt.Errorf("validate parent tag id=%v: %w", *parentID, entity.ErrTagParentCycle)
+	}
+	return nil
+}
+
+// isDescendantOrSelf は親タグをたどり，tag が ancestorID のタグ自身かその子孫であるかを返します
+// ゴミ箱にある祖先より上はたどりません
+func (p *TagUseCase) isDescendantOrSelf(tag *entity.Tag, ancestorID string) (bool, error) {
+	visited := make(map[string]bool)
+	for !visited[tag.ID] {
+		if tag.ID == ancestorID {
+			return true, nil
+		}
+		if tag.ParentID == nil {
+			return false, nil
+		}
+		if *tag.ParentID == ancestorID {
+			return true, nil
+		}
+		visited[tag.ID] = true
+
+		var err error
+		tag, err = p.tagRepository.FindByID(*tag.ParentID)
+		if errors.Is(err, entity.ErrTagNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("find ancestor tag: %w", err)
+		}
+	}
+	return false, nil
+}
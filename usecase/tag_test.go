@@ -26,6 +26,8 @@ func TestTagUseCase_StoreTag(t *testing.T) { // nolint:gocognit
 	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
 	defer flextime.Restore()
 
+	parentID := "parentidabcdefghijklmnopq"
+	deletedID := "abcdefghijklmnopqrstuvwxyz"
 	tests := []struct {
 		name                 string
 		tagDTO               *dto.TagDTO
@@ -39,10 +41,52 @@ func TestTagUseCase_StoreTag(t *testing.T) { // nolint:gocognit
 			},
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
 				mock.EXPECT().FindByName(gomock.Any()).Return(nil, entity.ErrTagNotFound)
+				mock.EXPECT().FindDeletedByName(gomock.Any()).Return(nil, entity.ErrTagNotFound)
 				mock.EXPECT().Store(gomock.Any()).Return(nil)
 			},
 			wantErr: nil,
 		},
+		{
+			name: "ゴミ箱に同名のタグがある場合は元に戻してそのタグを返す",
+			tagDTO: &dto.TagDTO{
+				Name: "new_tag",
+			},
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByName("new_tag").Return(nil, entity.ErrTagNotFound)
+				mock.EXPECT().FindDeletedByName("new_tag").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "new_tag"}, nil)
+				mock.EXPECT().Restore("abcdefghijklmnopqrstuvwxyz").Return(nil)
+				mock.EXPECT().Update(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "new_tag"}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ゴミ箱に同名のタグがある場合は元に戻して指定した親タグを設定する",
+			tagDTO: &dto.TagDTO{
+				Name:     "new_tag",
+				ParentID: &parentID,
+			},
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByName("new_tag").Return(nil, entity.ErrTagNotFound)
+				mock.EXPECT().FindDeletedByName("new_tag").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "new_tag"}, nil)
+				mock.EXPECT().FindByID(parentID).Return(&entity.Tag{ID: parentID, Name: "web"}, nil)
+				mock.EXPECT().Restore("abcdefghijklmnopqrstuvwxyz").Return(nil)
+				mock.EXPECT().Update(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "new_tag", ParentID: &parentID}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ゴミ箱にある同名のタグの子孫を親タグにする場合はErrTagParentCycleを返す",
+			tagDTO: &dto.TagDTO{
+				Name:     "new_tag",
+				ParentID: &parentID,
+			},
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByName("new_tag").Return(nil, entity.ErrTagNotFound)
+				mock.EXPECT().FindDeletedByName("new_tag").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "new_tag"}, nil)
+				mock.EXPECT().FindByID(parentID).Return(&entity.Tag{ID: parentID, Name: "web", ParentID: &deletedID}, nil)
+			},
+			wantErr: entity.ErrTagParentCycle,
+		},
 		{
 			name: "名前が登録済みの場合ErrNameAlreadyExistedエラーを返す",
 			tagDTO: &dto.TagDTO{
@@ -71,7 +115,7 @@ func TestTagUseCase_StoreTag(t *testing.T) { // nolint:gocognit
 				t.Errorf("StoreTag() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
-			if errors.Is(err, entity.ErrTagNameAlreadyExisted) {
+			if tt.wantErr != nil {
 				if got == nil {
 					return
 				}
@@ -124,7 +168,7 @@ func TestTagUseCase_GetTags(t *testing.T) {
 		{
 			name: "tagDTOsを返すこと",
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsTags, nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsTags, nil)
 				mock.EXPECT().Count().Return(len(existsTags), nil)
 			},
 			want: []*dto.TagDTO{
@@ -146,7 +190,7 @@ func TestTagUseCase_GetTags(t *testing.T) {
 		{
 			name: "conditionsとparamsを適用して取得できる",
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entity.Tag{
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entity.Tag{
 					{
 						ID:        "abcdefghijklmnopqrstuvwxyz",
 						Name:      "new_tag",
@@ -169,7 +213,7 @@ func TestTagUseCase_GetTags(t *testing.T) {
 		{
 			name: "FindAllがエラーを返した時はtagDTOsが空であること",
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
 			},
 			conditions: "",
 			params:     []interface{}{},
@@ -206,6 +250,164 @@ func TestTagUseCase_GetTags(t *testing.T) {
 	}
 }
 
+func TestTagUseCase_GetTagsByCursor(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	existsTags := func() []*entity.Tag {
+		return []*entity.Tag{
+			{ID: "abcdefghijklmnopqrstuvwxy1", Name: "new_tag1"},
+			{ID: "abcdefghijklmnopqrstuvwxy2", Name: "new_tag2"},
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		cursor               *entity.Cursor
+		prepareMockTagRepoFn func(mock *mock_repository.MockTag)
+		wantIDs              []string
+		wantNextCursor       string
+		wantPrevCursor       string
+		wantErr              error
+	}{
+		{
+			name:   "最初のページと次のページのカーソルを返すこと",
+			cursor: nil,
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindAll(0, 2, "", []interface{}{}, "tags.id asc").Return(existsTags(), nil)
+				mock.EXPECT().Count().Return(2, nil)
+			},
+			wantIDs:        []string{"abcdefghijklmnopqrstuvwxy1"},
+			wantNextCursor: entity.NewCursor("id", "abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy1", false).Encode(),
+			wantPrevCursor: "",
+			wantErr:        nil,
+		},
+		{
+			name:   "次のページでは前のページのカーソルを返すこと",
+			cursor: entity.NewCursor("id", "abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy1", false),
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindAll(0, 2, "tags.id > ?", []interface{}{"abcdefghijklmnopqrstuvwxy1"}, "tags.id asc").Return(existsTags()[1:], nil)
+				mock.EXPECT().Count().Return(2, nil)
+			},
+			wantIDs:        []string{"abcdefghijklmnopqrstuvwxy2"},
+			wantNextCursor: "",
+			wantPrevCursor: entity.NewCursor("id", "abcdefghijklmnopqrstuvwxy2", "abcdefghijklmnopqrstuvwxy2", true).Encode(),
+			wantErr:        nil,
+		},
+		{
+			name:                 "IDでないカーソルの場合はErrCursorInvalidを返すこと",
+			cursor:               entity.NewCursor("name", "new_tag1", "abcdefghijklmnopqrstuvwxy1", false),
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {},
+			wantIDs:              nil,
+			wantErr:              entity.ErrCursorInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockTagRepoFn(mr)
+			p := &TagUseCase{
+				tagRepository: mr,
+			}
+
+			got, _, nextCursor, prevCursor, err := p.GetTagsByCursor(1, "", []interface{}{}, tt.cursor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetTagsByCursor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ids []string
+			for _, tag := range got {
+				ids = append(ids, tag.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, ids); diff != "" {
+				t.Errorf("GetTagsByCursor() mismatch (-want +got):\n%s", diff)
+			}
+			if nextCursor != tt.wantNextCursor {
+				t.Errorf("GetTagsByCursor() nextCursor = %v, want %v", nextCursor, tt.wantNextCursor)
+			}
+			if prevCursor != tt.wantPrevCursor {
+				t.Errorf("GetTagsByCursor() prevCursor = %v, want %v", prevCursor, tt.wantPrevCursor)
+			}
+		})
+	}
+}
+
+func TestTagUseCase_GetPostsTags(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	tests := []struct {
+		name                 string
+		prepareMockTagRepoFn func(mock *mock_repository.MockTag)
+		want                 map[string][]*dto.TagDTO
+		wantErr              bool
+	}{
+		{
+			name: "投稿ごとのtagDTOsを返し，タグが付いていない投稿は空のスライスにすること",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByPostIDs([]string{"abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy2"}).Return(map[string][]*entity.Tag{
+					"abcdefghijklmnopqrstuvwxy1": {{
+						ID:        "abcdefghijklmnopqrstuvwxyz",
+						Name:      "new_tag",
+						CreatedAt: flextime.Now(),
+						UpdatedAt: flextime.Now(),
+					}},
+				}, nil)
+			},
+			want: map[string][]*dto.TagDTO{
+				"abcdefghijklmnopqrstuvwxy1": {{
+					ID:        "abcdefghijklmnopqrstuvwxyz",
+					Name:      "new_tag",
+					CreatedAt: flextime.Now(),
+					UpdatedAt: flextime.Now(),
+				}},
+				"abcdefghijklmnopqrstuvwxy2": {},
+			},
+			wantErr: false,
+		},
+		{
+			name: "FindByPostIDsがエラーを返した時はエラーを返すこと",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByPostIDs(gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockTagRepoFn(mr)
+			p := &TagUseCase{
+				tagRepository: mr,
+			}
+
+			got, err := p.GetPostsTags([]string{"abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy2"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPostsTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetPostsTags() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestTagUseCase_GetTag(t *testing.T) {
 
 	loc, err := time.LoadLocation("Asia/Tokyo")
@@ -277,6 +479,77 @@ func TestTagUseCase_GetTag(t *testing.T) {
 	}
 }
 
+func TestTagUseCase_GetTagByName(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	existsTag := &entity.Tag{
+		ID:        "abcdefghijklmnopqrstuvwxyz",
+		Name:      "new_tag",
+		CreatedAt: flextime.Now(),
+		UpdatedAt: flextime.Now(),
+	}
+
+	tests := []struct {
+		name                 string
+		prepareMockTagRepoFn func(mock *mock_repository.MockTag)
+		tagName              string
+		want                 *dto.TagDTO
+		wantErr              bool
+	}{
+		{
+			name: "tagDTOを返すこと",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByName("new_tag").Return(existsTag, nil)
+			},
+			want: &dto.TagDTO{
+				ID:        "abcdefghijklmnopqrstuvwxyz",
+				Name:      "new_tag",
+				CreatedAt: flextime.Now(),
+				UpdatedAt: flextime.Now(),
+			},
+			tagName: "new_tag",
+			wantErr: false,
+		},
+		{
+			name: "FindByNameがエラーを返した時はtagDTOが空であること",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByName("not_found").Return(nil, entity.ErrTagNotFound)
+			},
+			tagName: "not_found",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockTagRepoFn(mr)
+			p := &TagUseCase{
+				tagRepository: mr,
+			}
+
+			got, err := p.GetTagByName(tt.tagName)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTagByName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetTagByName() mismatch (-want +got):\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
 func TestTagUseCase_DeleteTag(t *testing.T) {
 
 	loc, err := time.LoadLocation("Asia/Tokyo")
@@ -328,3 +601,166 @@ func TestTagUseCase_DeleteTag(t *testing.T) {
 		})
 	}
 }
+
+func TestTagUseCase_UpdateTag(t *testing.T) {
+	parentID := "abcdefghijklmnopqrstuvwxy1"
+	childID := "abcdefghijklmnopqrstuvwxy2"
+
+	tests := []struct {
+		name                 string
+		tagDTO               *dto.TagDTO
+		prepareMockTagRepoFn func(mock *mock_repository.MockTag)
+		want                 *dto.TagDTO
+		wantErr              error
+	}{
+		{
+			name: "タグの名前と親タグを変更し，そのタグを返す",
+			tagDTO: &dto.TagDTO{
+				ID:       "abcdefghijklmnopqrstuvwxyz",
+				Name:     "Go",
+				ParentID: &parentID,
+			},
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "golang"}, nil)
+				mock.EXPECT().FindByName("Go").Return(nil, entity.ErrTagNotFound)
+				mock.EXPECT().FindByID(parentID).Return(&entity.Tag{ID: parentID, Name: "programming"}, nil)
+				mock.EXPECT().Update(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "Go", ParentID: &parentID}).Return(nil)
+			},
+			want: &dto.TagDTO{
+				ID:       "abcdefghijklmnopqrstuvwxyz",
+				Name:     "Go",
+				ParentID: &parentID,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "他のタグと同じ名前の場合はErrTagNameAlreadyExistedを返す",
+			tagDTO: &dto.TagDTO{
+				ID:   "abcdefghijklmnopqrstuvwxyz",
+				Name: "Go",
+			},
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "golang"}, nil)
+				mock.EXPECT().FindByName("Go").Return(&entity.Tag{ID: parentID, Name: "Go"}, nil)
+			},
+			want:    nil,
+			wantErr: entity.ErrTagNameAlreadyExisted,
+		},
+		{
+			name: "親タグが存在しない場合はErrTagParentNotFoundを返す",
+			tagDTO: &dto.TagDTO{
+				ID:       "abcdefghijklmnopqrstuvwxyz",
+				Name:     "golang",
+				ParentID: &parentID,
+			},
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "golang"}, nil)
+				mock.EXPECT().FindByName("golang").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "golang"}, nil)
+				mock.EXPECT().FindByID(parentID).Return(nil, entity.ErrTagNotFound)
+			},
+			want:    nil,
+			wantErr: entity.ErrTagParentNotFound,
+		},
+		{
+			name: "子孫のタグを親タグにする場合はErrTagParentCycleを返す",
+			tagDTO: &dto.TagDTO{
+				ID:       "abcdefghijklmnopqrstuvwxyz",
+				Name:     "golang",
+				ParentID: &childID,
+			},
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				rootID := "abcdefghijklmnopqrstuvwxyz"
+				mock.EXPECT().FindByID(rootID).Return(&entity.Tag{ID: rootID, Name: "golang"}, nil)
+				mock.EXPECT().FindByName("golang").Return(&entity.Tag{ID: rootID, Name: "golang"}, nil)
+				mock.EXPECT().FindByID(childID).Return(&entity.Tag{ID: childID, Name: "gin", ParentID: &parentID}, nil)
+				mock.EXPECT().FindByID(parentID).Return(&entity.Tag{ID: parentID, Name: "web", ParentID: &rootID}, nil)
+			},
+			want:    nil,
+			wantErr: entity.ErrTagParentCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockTagRepoFn(mr)
+			p := &TagUseCase{
+				tagRepository: mr,
+			}
+
+			got, err := p.UpdateTag(tt.tagDTO)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UpdateTag() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTagUseCase_MergeTag(t *testing.T) {
+	sourceID := "abcdefghijklmnopqrstuvwxy1"
+	targetID := "abcdefghijklmnopqrstuvwxy2"
+
+	tests := []struct {
+		name                 string
+		prepareMockTagRepoFn func(mock *mock_repository.MockTag)
+		want                 *dto.TagDTO
+		wantErr              error
+	}{
+		{
+			name: "タグを統合し，統合先のタグを返す",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID(sourceID).Return(&entity.Tag{ID: sourceID, Name: "golang"}, nil)
+				mock.EXPECT().FindByID(targetID).Return(&entity.Tag{ID: targetID, Name: "Go"}, nil)
+				mock.EXPECT().Merge(sourceID, targetID).Return(nil)
+			},
+			want: &dto.TagDTO{
+				ID:   targetID,
+				Name: "Go",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "統合先が子孫のタグの場合はErrTagMergeTargetInvalidを返す",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID(sourceID).Return(&entity.Tag{ID: sourceID, Name: "golang"}, nil)
+				mock.EXPECT().FindByID(targetID).Return(&entity.Tag{ID: targetID, Name: "Go", ParentID: &sourceID}, nil)
+			},
+			want:    nil,
+			wantErr: entity.ErrTagMergeTargetInvalid,
+		},
+		{
+			name: "統合先のタグが存在しない場合はErrTagNotFoundを返す",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID(sourceID).Return(&entity.Tag{ID: sourceID, Name: "golang"}, nil)
+				mock.EXPECT().FindByID(targetID).Return(nil, entity.ErrTagNotFound)
+			},
+			want:    nil,
+			wantErr: entity.ErrTagNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockTagRepoFn(mr)
+			p := &TagUseCase{
+				tagRepository: mr,
+			}
+
+			got, err := p.MergeTag(sourceID, targetID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("MergeTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MergeTag() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/repository"
+)
+
+// thumbnailRenderer は投稿のタイトルとタグからOGP画像を作ります
+type thumbnailRenderer interface {
+	Render(title string, tags []string) ([]byte, error)
+}
+
+type ThumbnailUseCase struct {
+	postRepository repository.Post
+	tagRepository  repository.Tag
+	imageStorage   repository.ImageStorage
+	renderer       thumbnailRenderer
+}
+
+func NewThumbnailUseCase(postRepository repository.Post, tagRepository repository.Tag, imageStorage repository.ImageStorage, renderer thumbnailRenderer) *ThumbnailUseCase {
+	return &ThumbnailUseCase{
+		postRepository: postRepository,
+		tagRepository:  tagRepository,
+		imageStorage:   imageStorage,
+		renderer:       renderer,
+	}
+}
+
+// GenerateThumbnail は公開済みか予約投稿でサムネイルが指定されていない投稿について，タイトルとタグを描いたOGP画像を保存してサムネイルにします
+// 自動で作ったサムネイルはタイトルやタグを変えた時だけ別のキーで作り直します．作らなかった場合は postDTO をそのまま返します
+func (t *ThumbnailUseCase) GenerateThumbnail(postDTO *dto.PostDTO) (*dto.PostDTO, error) {
+	if *postDTO.IsDraft && !postDTO.IsScheduled {
+		return postDTO, nil
+	}
+	if postDTO.ThumbnailURL != "" && !strings.HasPrefix(postDTO.ThumbnailURL, t.imageStorage.URL(entity.OGPImageKeyPrefix(postDTO.ID))) {
+		return postDTO, nil
+	}
+
+	tags, err := t.tagRepository.FindAll(0, 0, "posts_tags.post_id = ?", []interface{}{postDTO.ID}, "")
+	if err != nil && !errors.Is(err, entity.ErrTagNotFound) {
+		return nil, fmt.Errorf("generate thumbnail post id=%v: %w", postDTO.ID, err)
+	}
+	tagNames := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	sort.Strings(tagNames)
+
+	key := entity.OGPImageKey(postDTO.ID, postDTO.Title, tagNames)
+	url := t.imageStorage.URL(key)
+	if postDTO.ThumbnailURL == url {
+		return postDTO, nil
+	}
+
+	body, err := t.renderer.Render(postDTO.Title, tagNames)
+	if err != nil {
+		return nil, fmt.Errorf("generate thumbnail post id=%v: %w", postDTO.ID, err)
+	}
+	if err = t.imageStorage.Put(key, "image/png", bytes.NewReader(body)); err != nil {
+		return nil, fmt.Errorf("generate thumbnail post id=%v: %w", postDTO.ID, err)
+	}
+
+	post, err := t.postRepository.FindByID(postDTO.ID)
+	if err != nil {
+		return nil, fmt.Errorf("generate thumbnail post id=%v: %w", postDTO.ID, err)
+	}
+	post.ThumbnailURL = url
+	if err = t.postRepository.Update(post); err != nil {
+		return nil, fmt.Errorf("generate thumbnail post id=%v: %w", postDTO.ID, err)
+	}
+	return post.ConvertToDTO(), nil
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+)
+
+// fakeRenderer は描いたタイトルとタグを記録する thumbnailRenderer です
+type fakeRenderer struct {
+	title string
+	tags  []string
+	err   error
+}
+
+func (f *fakeRenderer) Render(title string, tags []string) ([]byte, error) {
+	f.title = title
+	f.tags = tags
+	return []byte("png"), f.err
+}
+
+func TestThumbnailUseCase_GenerateThumbnail(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	errDummy := errors.New("dummy error")
+	postID := "abcdefghijklmnopqrstuvwxy1"
+	ogpKey := entity.OGPImageKey(postID, "new_post", []string{"blog", "go"})
+	ogpURL := "https://example.com/" + ogpKey
+	noTagsOGPKey := entity.OGPImageKey(postID, "new_post", []string{})
+	noTagsOGPURL := "https://example.com/" + noTagsOGPKey
+	newPostDTO := func(isDraft, isScheduled bool, thumbnailURL string) *dto.PostDTO {
+		return &dto.PostDTO{
+			ID:           postID,
+			Title:        "new_post",
+			ThumbnailURL: thumbnailURL,
+			Permalink:    "new_permalink",
+			IsDraft:      &isDraft,
+			IsScheduled:  isScheduled,
+			CreatedAt:    flextime.Now(),
+			UpdatedAt:    flextime.Now(),
+		}
+	}
+
+	tests := []struct {
+		name              string
+		postDTO           *dto.PostDTO
+		renderErr         error
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag, mockStorage *mock_repository.MockImageStorage)
+		wantThumbnailURL  string
+		wantTags          []string
+		wantErr           error
+	}{
+		{
+			name:      "サムネイルが無い公開済みの投稿はタイトルとタグを描いた画像をサムネイルにすること",
+			postDTO:   newPostDTO(false, false, ""),
+			renderErr: nil,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag, mockStorage *mock_repository.MockImageStorage) {
+				mockTags.EXPECT().FindAll(0, 0, "posts_tags.post_id = ?", []interface{}{postID}, "").Return([]*entity.Tag{{Name: "go"}, {Name: "blog"}}, nil)
+				mockStorage.EXPECT().Put(ogpKey, "image/png", gomock.Any()).Return(nil)
+				mockPosts.EXPECT().FindByID(postID).Return(&entity.Post{ID: postID, Title: "new_post", Permalink: "new_permalink"}, nil)
+				mockPosts.EXPECT().Update(gomock.Any()).DoAndReturn(func(post *entity.Post) error {
+					if post.ThumbnailURL != ogpURL {
+						t.Errorf("Update() thumbnailURL = %v, want = %v", post.ThumbnailURL, ogpURL)
+					}
+					return nil
+				})
+			},
+			wantThumbnailURL: ogpURL,
+			wantTags:         []string{"blog", "go"},
+			wantErr:          nil,
+		},
+		{
+			name:      "自動で作ったサムネイルはタイトルとタグが変わっていなければ作り直さないこと",
+			postDTO:   newPostDTO(false, false, ogpURL),
+			renderErr: nil,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag, mockStorage *mock_repository.MockImageStorage) {
+				mockTags.EXPECT().FindAll(0, 0, "posts_tags.post_id = ?", []interface{}{postID}, "").Return([]*entity.Tag{{Name: "blog"}, {Name: "go"}}, nil)
+			},
+			wantThumbnailURL: ogpURL,
+			wantTags:         nil,
+			wantErr:          nil,
+		},
+		{
+			name:      "自動で作ったサムネイルはタグが変わったら別のキーで作り直すこと",
+			postDTO:   newPostDTO(false, false, ogpURL),
+			renderErr: nil,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag, mockStorage *mock_repository.MockImageStorage) {
+				mockTags.EXPECT().FindAll(0, 0, "posts_tags.post_id = ?", []interface{}{postID}, "").Return(nil, entity.ErrTagNotFound)
+				mockStorage.EXPECT().Put(noTagsOGPKey, "image/png", gomock.Any()).Return(nil)
+				mockPosts.EXPECT().FindByID(postID).Return(&entity.Post{ID: postID, Title: "new_post", ThumbnailURL: ogpURL}, nil)
+				mockPosts.EXPECT().Update(gomock.Any()).Return(nil)
+			},
+			wantThumbnailURL: noTagsOGPURL,
+			wantTags:         []string{},
+			wantErr:          nil,
+		},
+		{
+			name:      "ハッシュを含まない以前のキーのサムネイルも自動で作ったものとして作り直すこと",
+			postDTO:   newPostDTO(false, false, "https://example.com/ogp/"+postID+".png"),
+			renderErr: nil,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag, mockStorage *mock_repository.MockImageStorage) {
+				mockTags.EXPECT().FindAll(0, 0, "posts_tags.post_id = ?", []interface{}{postID}, "").Return(nil, entity.ErrTagNotFound)
+				mockStorage.EXPECT().Put(noTagsOGPKey, "image/png", gomock.Any()).Return(nil)
+				mockPosts.EXPECT().FindByID(postID).Return(&entity.Post{ID: postID, Title: "new_post"}, nil)
+				mockPosts.EXPECT().Update(gomock.Any()).Return(nil)
+			},
+			wantThumbnailURL: noTagsOGPURL,
+			wantTags:         []string{},
+			wantErr:          nil,
+		},
+		{
+			name:      "予約投稿にもサムネイルを作ること",
+			postDTO:   newPostDTO(true, true, ""),
+			renderErr: nil,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag, mockStorage *mock_repository.MockImageStorage) {
+				mockTags.EXPECT().FindAll(0, 0, "posts_tags.post_id = ?", []interface{}{postID}, "").Return(nil, entity.ErrTagNotFound)
+				mockStorage.EXPECT().Put(noTagsOGPKey, "image/png", gomock.Any()).Return(nil)
+				mockPosts.EXPECT().FindByID(postID).Return(&entity.Post{ID: postID, Title: "new_post"}, nil)
+				mockPosts.EXPECT().Update(gomock.Any()).Return(nil)
+			},
+			wantThumbnailURL: noTagsOGPURL,
+			wantTags:         []string{},
+			wantErr:          nil,
+		},
+		{
+			name:      "下書きにはサムネイルを作らないこと",
+			postDTO:   newPostDTO(true, false, ""),
+			renderErr: nil,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag, mockStorage *mock_repository.MockImageStorage) {
+			},
+			wantThumbnailURL: "",
+			wantTags:         nil,
+			wantErr:          nil,
+		},
+		{
+			name:      "サムネイルを指定した投稿にはサムネイルを作らないこと",
+			postDTO:   newPostDTO(false, false, "https://example.com/thumbnail.png"),
+			renderErr: nil,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag, mockStorage *mock_repository.MockImageStorage) {
+			},
+			wantThumbnailURL: "https://example.com/thumbnail.png",
+			wantTags:         nil,
+			wantErr:          nil,
+		},
+		{
+			name:      "画像を作れなかった場合はエラーを返すこと",
+			postDTO:   newPostDTO(false, false, ""),
+			renderErr: errDummy,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag, mockStorage *mock_repository.MockImageStorage) {
+				mockTags.EXPECT().FindAll(0, 0, "posts_tags.post_id = ?", []interface{}{postID}, "").Return(nil, entity.ErrTagNotFound)
+			},
+			wantThumbnailURL: "",
+			wantTags:         []string{},
+			wantErr:          errDummy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mt := mock_repository.NewMockTag(ctrl)
+			ms := mock_repository.NewMockImageStorage(ctrl)
+			ms.EXPECT().URL(gomock.Any()).DoAndReturn(func(key string) string {
+				return "https://example.com/" + key
+			}).AnyTimes()
+			tt.prepareMockRepoFn(mp, mt, ms)
+			renderer := &fakeRenderer{err: tt.renderErr}
+			tu := NewThumbnailUseCase(mp, mt, ms, renderer)
+
+			got, err := tu.GenerateThumbnail(tt.postDTO)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GenerateThumbnail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantTags, renderer.tags); diff != "" {
+				t.Errorf("GenerateThumbnail() tags mismatch (-want +got):\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if got.ThumbnailURL != tt.wantThumbnailURL {
+				t.Errorf("GenerateThumbnail() thumbnailURL = %v, want = %v", got.ThumbnailURL, tt.wantThumbnailURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Songmu/flextime"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/repository"
+)
+
+type TrashUseCase struct {
+	postRepository repository.Post
+	tagRepository  repository.Tag
+}
+
+func NewTrashUseCase(postRepository repository.Post, tagRepository repository.Tag) *TrashUseCase {
+	return &TrashUseCase{
+		postRepository: postRepository,
+		tagRepository:  tagRepository,
+	}
+}
+
+// GetTrash はゴミ箱にある投稿とタグを返します
+func (t *TrashUseCase) GetTrash() (postDTOs []*dto.PostDTO, tagDTOs []*dto.TagDTO, err error) {
+	var posts []*entity.Post
+	posts, err = t.postRepository.FindDeleted()
+	if err != nil && !errors.Is(err, entity.ErrPostNotFound) {
+		err = fmt.Errorf("get trash posts: %w", err)
+		return
+	}
+
+	var tags []*entity.Tag
+	tags, err = t.tagRepository.FindDeleted()
+	if err != nil && !errors.Is(err, entity.ErrTagNotFound) {
+		err = fmt.Errorf("get trash tags: %w", err)
+		return
+	}
+	err = nil
+
+	postDTOs = make([]*dto.PostDTO, 0, len(posts))
+	for _, post := range posts {
+		postDTOs = append(postDTOs, post.ConvertToDTO())
+	}
+	tagDTOs = make([]*dto.TagDTO, 0, len(tags))
+	for _, tag := range tags {
+		tagDTOs = append(tagDTOs, tag.ConvertToDTO())
+	}
+	return
+}
+
+// Purge は days 日より前にゴミ箱に移動した投稿とタグを完全に削除し，削除した数を返します
+func (t *TrashUseCase) Purge(days int) (postCount, tagCount int, err error) {
+	before := flextime.Now().AddDate(0, 0, -days)
+
+	postCount, err = t.postRepository.Purge(before)
+	if err != nil {
+		err = fmt.Errorf("purge trash days=%d: %w", days, err)
+		return
+	}
+
+	tagCount, err = t.tagRepository.Purge(before)
+	if err != nil {
+		err = fmt.Errorf("purge trash days=%d: %w", days, err)
+		return
+	}
+	return
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+	"gorm.io/gorm"
+)
+
+func TestTrashUseCase_GetTrash(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	deletedAt := flextime.Now()
+
+	tests := []struct {
+		name              string
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag)
+		wantPosts         []*dto.PostDTO
+		wantTags          []*dto.TagDTO
+		wantErr           bool
+	}{
+		{
+			name: "ゴミ箱にある投稿とタグを返すこと",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindDeleted().Return([]*entity.Post{{
+					ID:        "abcdefghijklmnopqrstuvwxy1",
+					Title:     "new_post",
+					DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true},
+				}}, nil)
+				mockTags.EXPECT().FindDeleted().Return([]*entity.Tag{{
+					ID:        "abcdefghijklmnopqrstuvwxy2",
+					Name:      "new_tag",
+					DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true},
+				}}, nil)
+			},
+			wantPosts: []*dto.PostDTO{{
+				ID:        "abcdefghijklmnopqrstuvwxy1",
+				Title:     "new_post",
+				IsDraft:   func() *bool { b := false; return &b }(),
+				DeletedAt: &deletedAt,
+			}},
+			wantTags: []*dto.TagDTO{{
+				ID:        "abcdefghijklmnopqrstuvwxy2",
+				Name:      "new_tag",
+				DeletedAt: &deletedAt,
+			}},
+			wantErr: false,
+		},
+		{
+			name: "ゴミ箱が空の場合は空のスライスを返すこと",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindDeleted().Return(nil, entity.ErrPostNotFound)
+				mockTags.EXPECT().FindDeleted().Return(nil, entity.ErrTagNotFound)
+			},
+			wantPosts: []*dto.PostDTO{},
+			wantTags:  []*dto.TagDTO{},
+			wantErr:   false,
+		},
+		{
+			name: "投稿の取得に失敗した場合はエラーを返すこと",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindDeleted().Return(nil, errors.New("dummy error"))
+			},
+			wantPosts: nil,
+			wantTags:  nil,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mt := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockRepoFn(mp, mt)
+			tr := NewTrashUseCase(mp, mt)
+
+			gotPosts, gotTags, err := tr.GetTrash()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTrash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantPosts, gotPosts); diff != "" {
+				t.Errorf("GetTrash() posts mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantTags, gotTags); diff != "" {
+				t.Errorf("GetTrash() tags mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTrashUseCase_Purge(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	tests := []struct {
+		name              string
+		days              int
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag)
+		wantPostCount     int
+		wantTagCount      int
+		wantErr           bool
+	}{
+		{
+			name: "指定した日数より前にゴミ箱に移動した投稿とタグを削除すること",
+			days: 30,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				before := time.Date(2020, 12, 23, 0, 0, 0, 0, loc)
+				mockPosts.EXPECT().Purge(before).Return(2, nil)
+				mockTags.EXPECT().Purge(before).Return(1, nil)
+			},
+			wantPostCount: 2,
+			wantTagCount:  1,
+			wantErr:       false,
+		},
+		{
+			name: "投稿の削除に失敗した場合はエラーを返すこと",
+			days: 30,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Purge(gomock.Any()).Return(0, errors.New("dummy error"))
+			},
+			wantPostCount: 0,
+			wantTagCount:  0,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mt := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockRepoFn(mp, mt)
+			tr := NewTrashUseCase(mp, mt)
+
+			postCount, tagCount, err := tr.Purge(tt.days)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Purge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if postCount != tt.wantPostCount || tagCount != tt.wantTagCount {
+				t.Errorf("Purge() got = (%d, %d), want = (%d, %d)", postCount, tagCount, tt.wantPostCount, tt.wantTagCount)
+			}
+		})
+	}
+}
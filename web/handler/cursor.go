@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/domain/entity"
+)
+
+// cursorPagination は page を指定せずに page-size を指定した場合に，カーソルでページネーションするための設定を読み取ります
+// page を指定した場合は従来どおりオフセットでページネーションするので ok が false になります
+func cursorPagination(c *gin.Context) (pageSize int, cursor *entity.Cursor, ok bool, err error) {
+	if c.Query("page") != "" || c.Query("page-size") == "" {
+		return 0, nil, false, nil
+	}
+	pageSize, err = strconv.Atoi(c.Query("page-size"))
+	if err != nil {
+		return 0, nil, false, fmt.Errorf("page-size invalid: %w", err)
+	}
+	if pageSize <= 0 {
+		return 0, nil, false, errors.New("page-size must be greater than 0")
+	}
+	if c.Query("cursor") != "" {
+		cursor, err = entity.DecodeCursor(c.Query("cursor"))
+		if err != nil {
+			return 0, nil, false, err
+		}
+	}
+	return pageSize, cursor, true, nil
+}
+
+// setCursors はレスポンスに次と前のページのカーソルを加えます．ページがない場合は加えません
+func setCursors(res gin.H, nextCursor, prevCursor string) gin.H {
+	if nextCursor != "" {
+		res["nextCursor"] = nextCursor
+	}
+	if prevCursor != "" {
+		res["prevCursor"] = prevCursor
+	}
+	return res
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"crypto/sha1" // nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/constant"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/feed"
+	"github.com/masibw/blog-server/log"
+	"github.com/masibw/blog-server/usecase"
+)
+
+type FeedHandler struct {
+	postUC *usecase.PostUseCase
+	tagUC  *usecase.TagUseCase
+	site   *feed.Site
+}
+
+func NewFeedHandler(postUC *usecase.PostUseCase, tagUC *usecase.TagUseCase, site *feed.Site) *FeedHandler {
+	return &FeedHandler{
+		postUC: postUC,
+		tagUC:  tagUC,
+		site:   site,
+	}
+}
+
+// GetRSS は GET /feed.xml に対応するハンドラーです。
+func (f *FeedHandler) GetRSS(c *gin.Context) {
+	f.renderXML(c, "application/rss+xml; charset=utf-8", "", func(feedURL string, posts []*dto.PostDTO) interface{} {
+		return feed.NewRSS(f.site, feedURL, posts)
+	})
+}
+
+// GetTagRSS は GET /tags/:name/feed.xml に対応するハンドラーです。
+// 存在しないタグの場合は空のフィードではなく404を返します
+func (f *FeedHandler) GetTagRSS(c *gin.Context) {
+	logger := log.GetLogger()
+	name := c.Param("name")
+	if _, err := f.tagUC.GetTagByName(name); err != nil {
+		if errors.Is(err, entity.ErrTagNotFound) {
+			logger.Debug("get tag feed not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrTagNotFound.Error()})
+			return
+		}
+		logger.Errorf("get tag feed", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	f.renderXML(c, "application/rss+xml; charset=utf-8", name, func(feedURL string, posts []*dto.PostDTO) interface{} {
+		return feed.NewRSS(f.site, feedURL, posts)
+	})
+}
+
+// GetAtom は GET /atom.xml に対応するハンドラーです。
+func (f *FeedHandler) GetAtom(c *gin.Context) {
+	f.renderXML(c, "application/atom+xml; charset=utf-8", "", func(feedURL string, posts []*dto.PostDTO) interface{} {
+		return feed.NewAtom(f.site, feedURL, posts)
+	})
+}
+
+// GetJSONFeed は GET /feed.json に対応するハンドラーです。
+func (f *FeedHandler) GetJSONFeed(c *gin.Context) {
+	logger := log.GetLogger()
+	posts, err := f.getPublishedPosts("")
+	if err != nil {
+		logger.Errorf("get json feed", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	body, err := json.Marshal(feed.NewJSONFeed(f.site, f.site.URL+c.Request.URL.Path, posts))
+	if err != nil {
+		logger.Errorf("marshal json feed", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+	writeFeed(c, "application/feed+json; charset=utf-8", body, feed.LastModified(posts))
+}
+
+func (f *FeedHandler) renderXML(c *gin.Context, contentType, tagName string, build func(feedURL string, posts []*dto.PostDTO) interface{}) {
+	logger := log.GetLogger()
+	posts, err := f.getPublishedPosts(tagName)
+	if err != nil {
+		logger.Errorf("get feed tag=%v", tagName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	body, err := xml.MarshalIndent(build(f.site.URL+c.Request.URL.Path, posts), "", "  ")
+	if err != nil {
+		logger.Errorf("marshal feed", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+	writeFeed(c, contentType, append([]byte(xml.Header), body...), feed.LastModified(posts))
+}
+
+// getPublishedPosts は公開済みの投稿を新しい順に取得します
+func (f *FeedHandler) getPublishedPosts(tagName string) ([]*dto.PostDTO, error) {
+	condition := "posts.is_draft = ? AND posts.published_at <= ?"
+	params := []interface{}{false, flextime.Now()}
+	var tagFilter *entity.TagFilter
+	if tagName != "" {
+		tagFilter = &entity.TagFilter{Names: []string{tagName}}
+	}
+
+	posts, _, err := f.postUC.GetPosts(0, constant.FeedSize, condition, params, tagFilter, "posts.published_at desc", nil, true)
+	if err != nil && !errors.Is(err, entity.ErrPostNotFound) {
+		return nil, fmt.Errorf("get published posts: %w", err)
+	}
+	return posts, nil
+}
+
+// writeFeed はETagとLast-Modifiedを付けてフィードを返します
+// クライアントが同じフィードを持っている場合は304を返します
+func writeFeed(c *gin.Context, contentType string, body []byte, lastModified time.Time) {
+	sum := sha1.Sum(body) // nolint:gosec
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if isNotModified(c.Request, etag, lastModified) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+func isNotModified(req *http.Request, etag string, lastModified time.Time) bool {
+	// If-None-Matchが指定されている場合はIf-Modified-Sinceより優先する
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := req.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+	return false
+}
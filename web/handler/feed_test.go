@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+	"github.com/masibw/blog-server/feed"
+	"github.com/masibw/blog-server/usecase"
+)
+
+func TestFeedHandler_GetRSS(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	existsPosts := func() []*entity.Post {
+		return []*entity.Post{{
+			ID:           "abcdefghijklmnopqrstuvwxyz",
+			Title:        "new_post",
+			ThumbnailURL: "new_thumbnail_url",
+			Content:      "new_content",
+			Permalink:    "new_permalink",
+			IsDraft:      false,
+			CreatedAt:    flextime.Now(),
+			UpdatedAt:    flextime.Now(),
+			PublishedAt:  flextime.Now(),
+		}}
+	}
+
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		header                map[string]string
+		wantCode              int
+		wantBody              string
+	}{
+		{
+			name: "正常にRSSフィードを取得できる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(0, 20, gomock.Any(), gomock.Any(), nil, "posts.published_at desc", nil).Return(existsPosts(), nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: "<link>https://mesimasi.com/posts/new_permalink</link>",
+		},
+		{
+			name: "投稿が0件の時は空のフィードを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, entity.ErrPostNotFound)
+			},
+			wantCode: http.StatusOK,
+			wantBody: "<title>mesimasi.com</title>",
+		},
+		{
+			name: "If-Modified-Sinceが最終更新日時以降であればStatusNotModifiedを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts(), nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+			},
+			header:   map[string]string{"If-Modified-Since": flextime.Now().UTC().Format(http.TimeFormat)},
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name: "If-Modified-Sinceが最終更新日時より前であればフィードを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts(), nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+			},
+			header:   map[string]string{"If-Modified-Since": flextime.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)},
+			wantCode: http.StatusOK,
+			wantBody: "<title>new_post</title>",
+		},
+		{
+			name: "投稿の取得に失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			postUC := usecase.NewPostUseCase(mr, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodGet, "/feed.xml", nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			c.Request = req
+
+			f := NewFeedHandler(postUC, usecase.NewTagUseCase(mock_repository.NewMockTag(ctrl)), feed.NewSite("mesimasi.com", "", "https://mesimasi.com", "masibw"))
+			f.GetRSS(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetRSS() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("GetRSS() body = %s, want to contain %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestFeedHandler_GetTagRSS(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	tests := []struct {
+		name                  string
+		prepareMockTagRepoFn  func(mock *mock_repository.MockTag)
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		wantCode              int
+		wantBody              string
+	}{
+		{
+			name: "正常にタグのRSSフィードを取得できる",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByName("go").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "go"}, nil)
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(0, 20, gomock.Any(), gomock.Any(), &entity.TagFilter{Names: []string{"go"}}, "posts.published_at desc", nil).Return([]*entity.Post{{
+					ID:          "abcdefghijklmnopqrstuvwxyz",
+					Title:       "new_post",
+					Permalink:   "new_permalink",
+					UpdatedAt:   flextime.Now(),
+					PublishedAt: flextime.Now(),
+				}}, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: "<link>https://mesimasi.com/posts/new_permalink</link>",
+		},
+		{
+			name: "存在しないタグの場合はStatusNotFoundエラーが返る",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByName("go").Return(nil, entity.ErrTagNotFound)
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {},
+			wantCode:              http.StatusNotFound,
+			wantBody:              entity.ErrTagNotFound.Error(),
+		},
+		{
+			name: "タグの取得に失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByName("go").Return(nil, errors.New("dummy error"))
+			},
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {},
+			wantCode:              http.StatusInternalServerError,
+			wantBody:              entity.ErrInternalServerError.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mt := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockTagRepoFn(mt)
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			postUC := usecase.NewPostUseCase(mr, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodGet, "/tags/go/feed.xml", nil)
+			c.Request = req
+			c.Params = gin.Params{{Key: "name", Value: "go"}}
+
+			f := NewFeedHandler(postUC, usecase.NewTagUseCase(mt), feed.NewSite("mesimasi.com", "", "https://mesimasi.com", "masibw"))
+			f.GetTagRSS(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetTagRSS() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("GetTagRSS() body = %s, want to contain %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestFeedHandler_GetJSONFeed(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr := mock_repository.NewMockPost(ctrl)
+	mr.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entity.Post{{
+		ID:          "abcdefghijklmnopqrstuvwxyz",
+		Title:       "new_post",
+		Content:     "new_content",
+		Permalink:   "new_permalink",
+		UpdatedAt:   flextime.Now(),
+		PublishedAt: flextime.Now(),
+	}}, nil).Times(2)
+	mr.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil).Times(2)
+	postUC := usecase.NewPostUseCase(mr, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
+	f := NewFeedHandler(postUC, usecase.NewTagUseCase(mock_repository.NewMockTag(ctrl)), feed.NewSite("mesimasi.com", "", "https://mesimasi.com", "masibw"))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/feed.json", nil)
+	f.GetJSONFeed(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GetJSONFeed() code = %d, want = %d", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/feed+json") {
+		t.Errorf("GetJSONFeed() Content-Type = %s, want application/feed+json", got)
+	}
+
+	// 同じETagを送ると304が返る
+	etag := w.Header().Get("ETag")
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/feed.json", nil)
+	c.Request.Header.Set("If-None-Match", etag)
+	f.GetJSONFeed(c)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("GetJSONFeed() code = %d, want = %d", w.Code, http.StatusNotModified)
+	}
+}
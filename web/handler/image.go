@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/constant"
+	"github.com/masibw/blog-server/domain/dto"
 	"github.com/masibw/blog-server/domain/entity"
 	"github.com/masibw/blog-server/log"
 	"github.com/masibw/blog-server/usecase"
@@ -28,8 +33,13 @@ func (i *ImageHandler) GetPresignedURL(c *gin.Context) {
 		contentType = c.Query("contentType")
 	}
 
-	url, err := i.imageUC.CreatePresignedURL(&fileName, &contentType)
+	url, err := i.imageUC.CreatePresignedURL(&fileName, &contentType, mailAddress(c))
 	if err != nil {
+		if errors.Is(err, entity.ErrImageKeyInvalid) {
+			logger.Debug("create presigned url invalid object name", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrImageKeyInvalid.Error()})
+			return
+		}
 		logger.Errorf("create presigned url", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
 		return
@@ -37,5 +47,217 @@ func (i *ImageHandler) GetPresignedURL(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{
 		"signedUrl": url,
+		"url":       i.imageUC.ImageURL(fileName),
 	})
 }
+
+// UploadImage は POST /images に対応するハンドラーです。
+// multipartの image フィールドで受け取った画像を縮小・変換して保存します
+func (i *ImageHandler) UploadImage(c *gin.Context) {
+	logger := log.GetLogger()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constant.MaxImageSize)
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		if isRequestBodyTooLarge(err) {
+			logger.Debug("upload image too large", err)
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": entity.ErrImageTooLarge.Error()})
+			return
+		}
+		logger.Debug("upload image form file", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "image is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Errorf("upload image open", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+	defer file.Close()
+
+	imageDTO, err := i.imageUC.UploadImage(file, mailAddress(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrImageTypeUnsupported):
+			logger.Debug("upload image unsupported", err)
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": entity.ErrImageTypeUnsupported.Error()})
+		case errors.Is(err, entity.ErrImageTooLarge):
+			logger.Debug("upload image too large", err)
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": entity.ErrImageTooLarge.Error()})
+		default:
+			logger.Errorf("upload image", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"image": imageDTO})
+}
+
+// GetImages は GET /images/library に対応するハンドラーです。
+// メディアライブラリの画像を新しい順に返します．q でキーか代替テキストを検索できます
+func (i *ImageHandler) GetImages(c *gin.Context) {
+	logger := log.GetLogger()
+	var offset, pageSize int
+	if c.Query("page") != "" && c.Query("page-size") != "" {
+		page, err := strconv.Atoi(c.Query("page"))
+		if err != nil {
+			logger.Errorf("page invalid, %v : %v", c.Query("page"), err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		pageSize, err = strconv.Atoi(c.Query("page-size"))
+		if err != nil {
+			logger.Errorf("page-size invalid, %v : %v", c.Query("page-size"), err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if page == 0 {
+			page = 1
+		}
+		offset = (page - 1) * pageSize
+	}
+
+	images, count, err := i.imageUC.GetImages(offset, pageSize, strings.TrimSpace(c.Query("q")))
+	if err != nil {
+		logger.Errorf("get images", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"images": images,
+		"count":  count,
+	})
+}
+
+// GetImage は GET /images/library/:id に対応するハンドラーです。
+func (i *ImageHandler) GetImage(c *gin.Context) {
+	logger := log.GetLogger()
+	image, err := i.imageUC.GetImage(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, entity.ErrImageNotFound) {
+			logger.Debug("get image not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrImageNotFound.Error()})
+			return
+		}
+		logger.Errorf("get image", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"image": image,
+	})
+}
+
+// UpdateImage は PUT /images/library/:id に対応するハンドラーです。
+// 画像の代替テキストと大きさを変更します
+func (i *ImageHandler) UpdateImage(c *gin.Context) {
+	type request struct {
+		AltText string `json:"altText"`
+		Width   int    `json:"width" binding:"min=0"`
+		Height  int    `json:"height" binding:"min=0"`
+	}
+
+	logger := log.GetLogger()
+	req := &request{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		logger.Errorf("failed to bind", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	image, err := i.imageUC.UpdateImage(c.Param("id"), &dto.ImageDTO{
+		AltText: req.AltText,
+		Width:   req.Width,
+		Height:  req.Height,
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrImageNotFound) {
+			logger.Debug("update image not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrImageNotFound.Error()})
+			return
+		}
+		logger.Errorf("update image", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"image": image,
+	})
+}
+
+// DeleteImage は DELETE /images/library/:id に対応するハンドラーです。
+// 投稿で使われている画像は，使っている投稿を返して削除しません．force=true で使われていても削除します
+func (i *ImageHandler) DeleteImage(c *gin.Context) {
+	logger := log.GetLogger()
+	var force bool
+	if c.Query("force") != "" {
+		var err error
+		force, err = strconv.ParseBool(c.Query("force"))
+		if err != nil {
+			logger.Errorf("force invalid, %v : %v", c.Query("force"), err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	image, err := i.imageUC.DeleteImage(c.Param("id"), force)
+	if err != nil {
+		if errors.Is(err, entity.ErrImageNotFound) {
+			logger.Debug("delete image not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrImageNotFound.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrImageInUse) {
+			logger.Debug("delete image in use", err)
+			c.JSON(http.StatusConflict, gin.H{
+				"error":       entity.ErrImageInUse.Error(),
+				"usedBy":      image.UsedBy,
+				"usedByPages": image.UsedByPages,
+			})
+			return
+		}
+		logger.Errorf("delete image", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully deleted",
+	})
+}
+
+// ScanImageUsages は POST /images/library/scan に対応するハンドラーです。
+// すべての画像について，本文かサムネイルで使っている投稿を調べ直します
+func (i *ImageHandler) ScanImageUsages(c *gin.Context) {
+	logger := log.GetLogger()
+	count, err := i.imageUC.ScanImageUsages()
+	if err != nil {
+		logger.Errorf("scan image usages", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"scanned": count,
+	})
+}
+
+// mailAddress はログインしているユーザーのメールアドレスを返します
+func mailAddress(c *gin.Context) string {
+	if v, ok := c.Get(constant.IdentityKey); ok {
+		if user, ok := v.(*dto.UserDTO); ok {
+			return user.MailAddress
+		}
+	}
+	return ""
+}
+
+// isRequestBodyTooLarge は http.MaxBytesReader の上限を超えたエラーかを返します
+// 専用のエラー型が無いのでメッセージで判定します
+func isRequestBodyTooLarge(err error) bool {
+	return strings.Contains(err.Error(), "request body too large")
+}
@@ -1,11 +1,17 @@
 package handler
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
 	"github.com/masibw/blog-server/mock_usecase"
 
 	"github.com/golang/mock/gomock"
@@ -23,7 +29,8 @@ func TestImageHandler_StoreImage(t *testing.T) {
 		{
 			name: "正常にタグを保存できる",
 			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
-				mock.EXPECT().CreatePresignedURL(gomock.Any(), gomock.Any()).Return("url", nil)
+				mock.EXPECT().CreatePresignedURL(gomock.Any(), gomock.Any(), gomock.Any()).Return("url", nil)
+				mock.EXPECT().ImageURL("image").Return("https://example.com/image")
 			},
 			queryParam: `objectName=image&contentType=image%2Fpng`,
 			wantCode:   http.StatusCreated,
@@ -31,11 +38,19 @@ func TestImageHandler_StoreImage(t *testing.T) {
 		{
 			name: "urlの作成に失敗した時はStatusInternalServerErrorエラーが返る",
 			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
-				mock.EXPECT().CreatePresignedURL(gomock.Any(), gomock.Any()).Return("", errors.New("dummy error"))
+				mock.EXPECT().CreatePresignedURL(gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("dummy error"))
 			},
 			queryParam: `objectName=image&contentType=image%2Fpng`,
 			wantCode:   http.StatusInternalServerError,
 		},
+		{
+			name: "不正なobjectNameの時はStatusBadRequestエラーが返る",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().CreatePresignedURL(gomock.Any(), gomock.Any(), gomock.Any()).Return("", fmt.Errorf("create presigned url: %w", entity.ErrImageKeyInvalid))
+			},
+			queryParam: `objectName=..%2Fimage&contentType=image%2Fpng`,
+			wantCode:   http.StatusBadRequest,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -62,3 +77,188 @@ func TestImageHandler_StoreImage(t *testing.T) {
 		})
 	}
 }
+
+func TestImageHandler_UploadImage(t *testing.T) {
+	tests := []struct {
+		name                 string
+		prepareMockImageUCFn func(mock *mock_usecase.MockImage)
+		fieldName            string
+		wantCode             int
+	}{
+		{
+			name: "正常に画像を保存できる",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().UploadImage(gomock.Any(), gomock.Any()).Return(&dto.ImageDTO{ID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+			},
+			fieldName: "image",
+			wantCode:  http.StatusCreated,
+		},
+		{
+			name:                 "imageフィールドが無い時はStatusBadRequestエラーが返る",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {},
+			fieldName:            "file",
+			wantCode:             http.StatusBadRequest,
+		},
+		{
+			name: "対応していない形式の時はStatusUnsupportedMediaTypeエラーが返る",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().UploadImage(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("upload image: %w", entity.ErrImageTypeUnsupported))
+			},
+			fieldName: "image",
+			wantCode:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "画像が大きすぎる時はStatusRequestEntityTooLargeエラーが返る",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().UploadImage(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("upload image: %w", entity.ErrImageTooLarge))
+			},
+			fieldName: "image",
+			wantCode:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "保存に失敗した時はStatusInternalServerErrorエラーが返る",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().UploadImage(gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			fieldName: "image",
+			wantCode:  http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mu := mock_usecase.NewMockImage(ctrl)
+			tt.prepareMockImageUCFn(mu)
+
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			fw, err := mw.CreateFormFile(tt.fieldName, "image.png")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = fw.Write([]byte("dummy image")); err != nil {
+				t.Fatal(err)
+			}
+			if err = mw.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/images", &body)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			c.Request = req
+
+			p := &ImageHandler{
+				imageUC: mu,
+			}
+			p.UploadImage(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("UploadImage() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestImageHandler_DeleteImage(t *testing.T) {
+	imageID := "abcdefghijklmnopqrstuvwxyz"
+	tests := []struct {
+		name                 string
+		prepareMockImageUCFn func(mock *mock_usecase.MockImage)
+		queryParam           string
+		wantCode             int
+		wantBody             string
+	}{
+		{
+			name: "正常に画像を削除できる",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().DeleteImage(imageID, false).Return(nil, nil)
+			},
+			queryParam: "",
+			wantCode:   http.StatusOK,
+			wantBody:   "successfully deleted",
+		},
+		{
+			name: "投稿で使われている時はStatusConflictエラーと使っている投稿が返る",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().DeleteImage(imageID, false).Return(&dto.ImageDTO{ID: imageID, UsedBy: []string{"post"}}, fmt.Errorf("delete image: %w", entity.ErrImageInUse))
+			},
+			queryParam: "",
+			wantCode:   http.StatusConflict,
+			wantBody:   `"usedBy":["post"]`,
+		},
+		{
+			name: "固定ページだけで使われている時はStatusConflictエラーと使っている固定ページが返る",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().DeleteImage(imageID, false).Return(&dto.ImageDTO{ID: imageID, UsedByPages: []string{"about"}}, fmt.Errorf("delete image: %w", entity.ErrImageInUse))
+			},
+			queryParam: "",
+			wantCode:   http.StatusConflict,
+			wantBody:   `"usedByPages":["about"]`,
+		},
+		{
+			name: "forceを付けると使われていても削除できる",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().DeleteImage(imageID, true).Return(nil, nil)
+			},
+			queryParam: "force=true",
+			wantCode:   http.StatusOK,
+			wantBody:   "successfully deleted",
+		},
+		{
+			name:                 "不正なforceの時はStatusBadRequestエラーが返る",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {},
+			queryParam:           "force=yes",
+			wantCode:             http.StatusBadRequest,
+			wantBody:             "",
+		},
+		{
+			name: "存在しない画像の時はStatusNotFoundエラーが返る",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().DeleteImage(imageID, false).Return(nil, fmt.Errorf("delete image: %w", entity.ErrImageNotFound))
+			},
+			queryParam: "",
+			wantCode:   http.StatusNotFound,
+			wantBody:   entity.ErrImageNotFound.Error(),
+		},
+		{
+			name: "削除に失敗した時はStatusInternalServerErrorエラーが返る",
+			prepareMockImageUCFn: func(mock *mock_usecase.MockImage) {
+				mock.EXPECT().DeleteImage(imageID, false).Return(nil, errors.New("dummy error"))
+			},
+			queryParam: "",
+			wantCode:   http.StatusInternalServerError,
+			wantBody:   entity.ErrInternalServerError.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mu := mock_usecase.NewMockImage(ctrl)
+			tt.prepareMockImageUCFn(mu)
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodDelete, "/api/v1/images/library/"+imageID+"?"+tt.queryParam, nil)
+			c.Request = req
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: imageID})
+
+			p := &ImageHandler{
+				imageUC: mu,
+			}
+			p.DeleteImage(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("DeleteImage() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("DeleteImage() body = %s, want to contain %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/feed"
+	"github.com/masibw/blog-server/log"
+	"github.com/masibw/blog-server/meta"
+	"github.com/masibw/blog-server/usecase"
+)
+
+type MetaHandler struct {
+	postUC *usecase.PostUseCase
+	tagUC  *usecase.TagUseCase
+	site   *feed.Site
+}
+
+func NewMetaHandler(postUC *usecase.PostUseCase, tagUC *usecase.TagUseCase, site *feed.Site) *MetaHandler {
+	return &MetaHandler{
+		postUC: postUC,
+		tagUC:  tagUC,
+		site:   site,
+	}
+}
+
+// GetPostMeta は GET /posts/:permalink/meta に対応するハンドラーです。
+// format=html の場合は<head>にそのまま埋め込めるHTMLを返します
+func (m *MetaHandler) GetPostMeta(c *gin.Context) {
+	logger := log.GetLogger()
+	permalink := c.Param("permalink")
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "html" {
+		logger.Debugf("format invalid, %v", format)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or html"})
+		return
+	}
+
+	post, err := m.postUC.GetPost(permalink, true)
+	if err != nil {
+		if errors.Is(err, entity.ErrPostMoved) {
+			logger.Debug("get post meta moved", err)
+			location := path.Join(path.Dir(path.Dir(c.Request.URL.Path)), url.PathEscape(post.Permalink), "meta")
+			if c.Request.URL.RawQuery != "" {
+				location += "?" + c.Request.URL.RawQuery
+			}
+			c.Header("Location", location)
+			c.JSON(http.StatusMovedPermanently, gin.H{"error": entity.ErrPostMoved.Error(), "movedTo": post.Permalink})
+			return
+		}
+		if errors.Is(err, entity.ErrPostNotFound) {
+			logger.Debug("get post meta not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
+			return
+		}
+		logger.Errorf("get post meta", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+	// 下書きは公開しているページが無いのでメタデータも返さない
+	if *post.IsDraft {
+		logger.Debug("get post meta draft", permalink)
+		c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
+		return
+	}
+
+	tags, err := m.tagUC.GetPostTags(post.ID)
+	if err != nil {
+		logger.Errorf("get post meta tags", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	postMeta := meta.NewPostMeta(m.site, post, tags)
+	if format == "html" {
+		head, err := postMeta.HTML()
+		if err != nil {
+			logger.Errorf("get post meta html", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(head))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"meta": postMeta,
+	})
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+	"github.com/masibw/blog-server/feed"
+	"github.com/masibw/blog-server/usecase"
+)
+
+func TestMetaHandler_GetPostMeta(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	newPost := func(isDraft bool) *entity.Post {
+		return &entity.Post{
+			ID:           "abcdefghijklmnopqrstuvwxyz",
+			Title:        "new_post",
+			ThumbnailURL: "https://example.com/thumbnail.png",
+			Content:      "new_content",
+			Permalink:    "new_permalink",
+			IsDraft:      isDraft,
+			CreatedAt:    flextime.Now(),
+			UpdatedAt:    flextime.Now(),
+			PublishedAt:  flextime.Now(),
+		}
+	}
+
+	tests := []struct {
+		name              string
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect, mockTags *mock_repository.MockTag)
+		permalink         string
+		query             string
+		wantCode          int
+		wantContentType   string
+		wantBody          string
+		wantLocation      string
+	}{
+		{
+			name: "投稿のメタデータをJSONで返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindByPermalink("new_permalink").Return(newPost(false), nil)
+				mockTags.EXPECT().FindAll(0, 0, "posts_tags.post_id = ?", []interface{}{"abcdefghijklmnopqrstuvwxyz"}, "").Return([]*entity.Tag{{Name: "go"}}, nil)
+			},
+			permalink:       "new_permalink",
+			query:           "",
+			wantCode:        http.StatusOK,
+			wantContentType: "application/json; charset=utf-8",
+			wantBody:        `"content":"go"`,
+		},
+		{
+			name: "format=htmlの場合は<head>に埋め込むHTMLを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindByPermalink("new_permalink").Return(newPost(false), nil)
+				mockTags.EXPECT().FindAll(0, 0, "posts_tags.post_id = ?", []interface{}{"abcdefghijklmnopqrstuvwxyz"}, "").Return(nil, entity.ErrTagNotFound)
+			},
+			permalink:       "new_permalink",
+			query:           "?format=html",
+			wantCode:        http.StatusOK,
+			wantContentType: "text/html; charset=utf-8",
+			wantBody:        `<meta property="og:image" content="https://example.com/thumbnail.png">`,
+		},
+		{
+			name: "下書きの場合はStatusNotFoundを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindByPermalink("new_permalink").Return(newPost(true), nil)
+			},
+			permalink: "new_permalink",
+			query:     "",
+			wantCode:  http.StatusNotFound,
+		},
+		{
+			name: "投稿がない場合はStatusNotFoundを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindByPermalink("not_found").Return(nil, entity.ErrPostNotFound)
+				mockRedirects.EXPECT().FindByPermalink("not_found").Return(nil, entity.ErrPermalinkRedirectNotFound)
+			},
+			permalink: "not_found",
+			query:     "",
+			wantCode:  http.StatusNotFound,
+		},
+		{
+			name: "変更前のパーマリンクの場合は現在のパーマリンクのメタデータへStatusMovedPermanentlyでリダイレクトする",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindByPermalink("old_permalink").Return(nil, entity.ErrPostNotFound)
+				mockRedirects.EXPECT().FindByPermalink("old_permalink").Return(&entity.PermalinkRedirect{
+					ID:        "abcdefghijklmnopqrstuvwxy1",
+					PostID:    "abcdefghijklmnopqrstuvwxyz",
+					Permalink: "old_permalink",
+				}, nil)
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(newPost(false), nil)
+			},
+			permalink:    "old_permalink",
+			query:        "?format=html",
+			wantCode:     http.StatusMovedPermanently,
+			wantLocation: "/api/v1/posts/new_permalink/meta?format=html",
+		},
+		{
+			name: "タグの取得に失敗した場合はStatusInternalServerErrorを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindByPermalink("new_permalink").Return(newPost(false), nil)
+				mockTags.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			permalink: "new_permalink",
+			query:     "",
+			wantCode:  http.StatusInternalServerError,
+		},
+		{
+			name: "formatが不正な場合はStatusBadRequestを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect, mockTags *mock_repository.MockTag) {
+			},
+			permalink: "new_permalink",
+			query:     "?format=xml",
+			wantCode:  http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mpr := mock_repository.NewMockPermalinkRedirect(ctrl)
+			mt := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockRepoFn(mp, mpr, mt)
+			postUC := usecase.NewPostUseCase(mp, mock_repository.NewMockPostRevision(ctrl), mpr)
+			tagUC := usecase.NewTagUseCase(mt)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/posts/"+tt.permalink+"/meta"+tt.query, nil)
+			c.Request = req
+			c.Params = gin.Params{{Key: "permalink", Value: tt.permalink}}
+
+			m := NewMetaHandler(postUC, tagUC, feed.NewSite("mesimasi.com", "description", "https://mesimasi.com", "masibw"))
+			m.GetPostMeta(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetPostMeta() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+			if tt.wantContentType != "" && w.Header().Get("Content-Type") != tt.wantContentType {
+				t.Errorf("GetPostMeta() Content-Type = %v, want = %v", w.Header().Get("Content-Type"), tt.wantContentType)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("GetPostMeta() body = %v, want to contain %v", w.Body.String(), tt.wantBody)
+			}
+			if got := w.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("GetPostMeta() Location = %v, want = %v", got, tt.wantLocation)
+			}
+		})
+	}
+}
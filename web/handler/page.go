@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/log"
+	"github.com/masibw/blog-server/usecase"
+)
+
+type PageHandler struct {
+	pageUC *usecase.PageUseCase
+}
+
+func NewPageHandler(pageUC *usecase.PageUseCase) *PageHandler {
+	return &PageHandler{pageUC: pageUC}
+}
+
+// StorePage は POST /pages に対応するハンドラーです。
+func (p *PageHandler) StorePage(c *gin.Context) {
+	logger := log.GetLogger()
+	pageDTO := &dto.PageDTO{}
+	if err := c.ShouldBindJSON(pageDTO); err != nil {
+		logger.Errorf("failed to bind", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := p.pageUC.StorePage(pageDTO)
+	if err != nil {
+		if errors.Is(err, entity.ErrPagePathInvalid) || errors.Is(err, entity.ErrPagePathAlreadyExisted) || errors.Is(err, entity.ErrPageParentNotFound) {
+			logger.Debug("store page invalid", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		logger.Errorf("store page", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"page": page,
+	})
+}
+
+// GetPages は GET /pages に対応するハンドラーです。
+// parent を指定した場合はそのパスの1つ下の階層の固定ページだけを返します
+func (p *PageHandler) GetPages(c *gin.Context) {
+	logger := log.GetLogger()
+
+	var isDraft *bool
+	if c.Query("is-draft") != "" {
+		b, err := strconv.ParseBool(c.Query("is-draft"))
+		if err != nil {
+			logger.Errorf("is-draft invalid, %v : %v", c.Query("is-draft"), err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		isDraft = &b
+	}
+
+	pages, err := p.pageUC.GetPages(isDraft, c.Query("parent"))
+	if err != nil {
+		if errors.Is(err, entity.ErrPagePathInvalid) {
+			logger.Debug("get pages parent invalid", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrPagePathInvalid.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrPageNotFound) {
+			logger.Debug("get pages not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPageNotFound.Error()})
+			return
+		}
+		logger.Errorf("get pages", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pages": pages,
+	})
+}
+
+// GetPage は GET /pages/*path に対応するハンドラーです。
+// 本文は投稿と同じようにHTMLにして返し，is-markdown=true の場合はMarkdownのまま返します
+func (p *PageHandler) GetPage(c *gin.Context) {
+	logger := log.GetLogger()
+
+	isMarkdown := false
+	if c.Query("is-markdown") != "" {
+		var err error
+		isMarkdown, err = strconv.ParseBool(c.Query("is-markdown"))
+		if err != nil {
+			logger.Errorf("is-markdown invalid, %v : %v", c.Query("is-markdown"), err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	page, children, err := p.pageUC.GetPage(c.Param("path"), isMarkdown)
+	if err != nil {
+		if errors.Is(err, entity.ErrPageNotFound) {
+			logger.Debug("get page not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPageNotFound.Error()})
+			return
+		}
+		logger.Errorf("get page", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":     page,
+		"children": children,
+	})
+}
+
+// UpdatePage は PUT /pages/:id に対応するハンドラーです。
+func (p *PageHandler) UpdatePage(c *gin.Context) {
+	logger := log.GetLogger()
+	pageDTO := &dto.PageDTO{}
+	if err := c.ShouldBindJSON(pageDTO); err != nil {
+		logger.Errorf("failed to bind", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	pageDTO.ID = c.Param("id")
+
+	page, err := p.pageUC.UpdatePage(pageDTO)
+	if err != nil {
+		if errors.Is(err, entity.ErrPageNotFound) {
+			logger.Debug("update page not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPageNotFound.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrPagePathInvalid) || errors.Is(err, entity.ErrPagePathAlreadyExisted) || errors.Is(err, entity.ErrPageParentNotFound) || errors.Is(err, entity.ErrPageHasChildren) {
+			logger.Debug("update page invalid", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		logger.Errorf("update page", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"page": page,
+	})
+}
+
+// DeletePage は DELETE /pages/:id に対応するハンドラーです。
+func (p *PageHandler) DeletePage(c *gin.Context) {
+	logger := log.GetLogger()
+	id := c.Param("id")
+	if err := p.pageUC.DeletePage(id); err != nil {
+		if errors.Is(err, entity.ErrPageNotFound) {
+			logger.Debug("delete page not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPageNotFound.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrPageHasChildren) {
+			logger.Debug("delete page has children", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrPageHasChildren.Error()})
+			return
+		}
+		logger.Errorf("delete page", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully deleted",
+	})
+}
@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+	"github.com/masibw/blog-server/usecase"
+)
+
+func TestPageHandler_StorePage(t *testing.T) {
+	tests := []struct {
+		name                  string
+		prepareMockPageRepoFn func(mock *mock_repository.MockPage)
+		body                  string
+		wantCode              int
+	}{
+		{
+			name: "正常に固定ページを保存できる",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().Store(gomock.Any()).Return(nil)
+			},
+			body: `{
+				"title" : "About",
+				"content" : "# About",
+				"path" : "about",
+				"isDraft" : false
+			}`,
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:                  "パスが正しくない時はStatusBadRequestエラーが返る",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {},
+			body: `{
+				"title" : "About",
+				"path" : "About Us",
+				"isDraft" : false
+			}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "親ページがない時はStatusBadRequestエラーが返る",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByPath("company").Return(nil, entity.ErrPageNotFound)
+			},
+			body: `{
+				"title" : "About",
+				"path" : "company/about",
+				"isDraft" : false
+			}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "パスが既に使われている時はStatusBadRequestエラーが返る",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().Store(gomock.Any()).Return(entity.ErrPagePathAlreadyExisted)
+			},
+			body: `{
+				"title" : "About",
+				"path" : "about",
+				"isDraft" : false
+			}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "保存に失敗した時はStatusInternalServerErrorエラーが返る",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().Store(gomock.Any()).Return(errors.New("dummy error"))
+			},
+			body: `{
+				"title" : "About",
+				"path" : "about",
+				"isDraft" : false
+			}`,
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPage(ctrl)
+			tt.prepareMockPageRepoFn(mp)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			body := bytes.NewBufferString(tt.body)
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/pages", body)
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+
+			p := &PageHandler{
+				pageUC: usecase.NewPageUseCase(mp),
+			}
+			p.StorePage(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("StorePage() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPageHandler_GetPage(t *testing.T) {
+	tests := []struct {
+		name                  string
+		path                  string
+		prepareMockPageRepoFn func(mock *mock_repository.MockPage)
+		wantCode              int
+	}{
+		{
+			name: "存在する固定ページを取得できる",
+			path: "/company/about",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByPath("company/about").Return(&entity.Page{ID: "abcdefghijklmnopqrstuvwxyz", Path: "company/about"}, nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, entity.ErrPageNotFound)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "存在しない固定ページの場合はStatusNotFoundエラーが返る",
+			path: "/company/about",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByPath("company/about").Return(nil, entity.ErrPageNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:                  "パスが正しくない場合はStatusNotFoundエラーが返る",
+			path:                  "/Company",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {},
+			wantCode:              http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPage(ctrl)
+			tt.prepareMockPageRepoFn(mp)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/pages"+tt.path, nil)
+			c.Request = req
+			c.Params = append(c.Params, gin.Param{Key: "path", Value: tt.path})
+
+			p := &PageHandler{
+				pageUC: usecase.NewPageUseCase(mp),
+			}
+			p.GetPage(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetPage() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPageHandler_UpdatePage(t *testing.T) {
+	pageID := "abcdefghijklmnopqrstuvwxyz"
+	tests := []struct {
+		name                  string
+		prepareMockPageRepoFn func(mock *mock_repository.MockPage)
+		body                  string
+		wantCode              int
+	}{
+		{
+			name: "固定ページを更新できる",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID(pageID).Return(&entity.Page{ID: pageID, Path: "about"}, nil)
+				mock.EXPECT().Update(gomock.Any()).Return(nil)
+			},
+			body: `{
+				"title" : "About",
+				"path" : "about",
+				"isDraft" : false
+			}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "存在しない固定ページの場合はStatusNotFoundエラーが返る",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID(pageID).Return(nil, entity.ErrPageNotFound)
+			},
+			body: `{
+				"title" : "About",
+				"path" : "about",
+				"isDraft" : false
+			}`,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "子ページがある固定ページのパスを変えた場合はStatusBadRequestエラーが返る",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID(pageID).Return(&entity.Page{ID: pageID, Path: "company"}, nil)
+				mock.EXPECT().FindAll("pages.path LIKE ?", []interface{}{"company/%"}).Return([]*entity.Page{{Path: "company/about"}}, nil)
+			},
+			body: `{
+				"title" : "Company",
+				"path" : "corporate",
+				"isDraft" : false
+			}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPage(ctrl)
+			tt.prepareMockPageRepoFn(mp)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			body := bytes.NewBufferString(tt.body)
+			req, _ := http.NewRequest(http.MethodPut, "/api/v1/pages/"+pageID, body)
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: pageID})
+
+			p := &PageHandler{
+				pageUC: usecase.NewPageUseCase(mp),
+			}
+			p.UpdatePage(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("UpdatePage() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPageHandler_DeletePage(t *testing.T) {
+	pageID := "abcdefghijklmnopqrstuvwxyz"
+	tests := []struct {
+		name                  string
+		prepareMockPageRepoFn func(mock *mock_repository.MockPage)
+		wantCode              int
+	}{
+		{
+			name: "固定ページを削除できる",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID(pageID).Return(&entity.Page{ID: pageID, Path: "about"}, nil)
+				mock.EXPECT().FindAll("pages.path LIKE ?", []interface{}{"about/%"}).Return(nil, entity.ErrPageNotFound)
+				mock.EXPECT().Delete(pageID).Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "存在しない固定ページの場合はStatusNotFoundエラーが返る",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID(pageID).Return(nil, entity.ErrPageNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "子ページがある場合はStatusBadRequestエラーが返る",
+			prepareMockPageRepoFn: func(mock *mock_repository.MockPage) {
+				mock.EXPECT().FindByID(pageID).Return(&entity.Page{ID: pageID, Path: "company"}, nil)
+				mock.EXPECT().FindAll("pages.path LIKE ?", []interface{}{"company/%"}).Return([]*entity.Page{{Path: "company/about"}}, nil)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPage(ctrl)
+			tt.prepareMockPageRepoFn(mp)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodDelete, "/api/v1/pages/"+pageID, nil)
+			c.Request = req
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: pageID})
+
+			p := &PageHandler{
+				pageUC: usecase.NewPageUseCase(mp),
+			}
+			p.DeletePage(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("DeletePage() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
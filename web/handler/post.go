@@ -2,11 +2,17 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Songmu/flextime"
+
 	"github.com/masibw/blog-server/domain/service"
 
 	"github.com/masibw/blog-server/domain/entity"
@@ -19,15 +25,41 @@ import (
 	"github.com/masibw/blog-server/log"
 )
 
+// postFieldColumns は fields で指定できる投稿のフィールドとカラムの対応です
+var postFieldColumns = map[string]string{
+	"id":           "id",
+	"title":        "title",
+	"thumbnailUrl": "thumbnail_url",
+	"content":      "content",
+	"excerpt":      "excerpt",
+	"charCount":    "char_count",
+	"wordCount":    "word_count",
+	"readingTime":  "reading_time",
+	"permalink":    "permalink",
+	"isDraft":      "is_draft",
+	"isScheduled":  "is_scheduled",
+	"isPinned":     "is_pinned",
+	"featuredRank": "featured_rank",
+	"createdAt":    "created_at",
+	"updatedAt":    "updated_at",
+	"publishedAt":  "published_at",
+}
+
 type PostHandler struct {
 	postUC           *usecase.PostUseCase
+	tagUC            *usecase.TagUseCase
+	seriesUC         *usecase.SeriesUseCase
 	postsTagsService *service.PostsTagsService
+	thumbnailUC      *usecase.ThumbnailUseCase
 }
 
-func NewPostHandler(postUC *usecase.PostUseCase, postsTagsservice *service.PostsTagsService) *PostHandler {
+func NewPostHandler(postUC *usecase.PostUseCase, tagUC *usecase.TagUseCase, seriesUC *usecase.SeriesUseCase, postsTagsservice *service.PostsTagsService, thumbnailUC *usecase.ThumbnailUseCase) *PostHandler {
 	return &PostHandler{
 		postUC:           postUC,
+		tagUC:            tagUC,
+		seriesUC:         seriesUC,
 		postsTagsService: postsTagsservice,
+		thumbnailUC:      thumbnailUC,
 	}
 }
 
@@ -47,6 +79,8 @@ func (p *PostHandler) StorePost(c *gin.Context) {
 }
 
 // UpdatePost は PUT /posts/:id に対応するハンドラーです。
+// 更新の競合は version で確認します．If-Match ヘッダーでETagが送られた場合はETagのバージョンを使い，
+// どちらも無い場合は updatedAt で確認します
 func (p *PostHandler) UpdatePost(c *gin.Context) {
 
 	type postReq struct {
@@ -59,6 +93,7 @@ func (p *PostHandler) UpdatePost(c *gin.Context) {
 		CreatedAt    time.Time `json:"createdAt" binding:"required"`
 		UpdatedAt    time.Time `json:"updatedAt" binding:"required"`
 		PublishedAt  time.Time `json:"publishedAt" `
+		Version      int       `json:"version"`
 	}
 
 	type request struct {
@@ -84,10 +119,31 @@ func (p *PostHandler) UpdatePost(c *gin.Context) {
 		CreatedAt:    req.Post.CreatedAt,
 		UpdatedAt:    req.Post.UpdatedAt,
 		PublishedAt:  req.Post.PublishedAt,
+		Version:      req.Post.Version,
+	}
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" && ifMatch != "*" {
+		version, err := parsePostETag(ifMatch)
+		if err != nil {
+			logger.Debugf("If-Match invalid, %v : %v", ifMatch, err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		postDTO.Version = version
 	}
 	post, err := p.postUC.UpdatePost(postDTO)
 
 	if err != nil {
+		if errors.Is(err, entity.ErrPostVersionConflict) {
+			logger.Debugf("update post version conflict", err)
+			body := gin.H{"error": entity.ErrPostVersionConflict.Error()}
+			if post != nil {
+				c.Header("ETag", postETag(post.Version))
+				body["post"] = post
+			}
+			c.JSON(http.StatusConflict, body)
+			return
+		}
+
 		if errors.Is(err, entity.ErrPermalinkAlreadyExisted) {
 			logger.Debugf("update post already existed", err)
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -104,34 +160,38 @@ func (p *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
+	var tags []*entity.Tag
 	if req.Tags == nil || len(req.Tags) == 0 {
 		logger.Debug("tags nil")
-		c.JSON(http.StatusOK, gin.H{
-			"post": post,
-			"tags": nil,
-		})
-		return
-	}
-	var tags []*entity.Tag
-	tags, err = p.postsTagsService.LinkPostTags(req.Post.ID, req.Tags)
-	if err != nil {
-		if errors.Is(err, entity.ErrPostsTagsAlreadyExisted) {
-			logger.Debugf("update post tags already exists", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-
-		if errors.Is(err, entity.ErrPostNotFound) {
-			logger.Debugf("update post not found", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		tags, err = p.postsTagsService.LinkPostTags(req.Post.ID, req.Tags)
+		if err != nil {
+			if errors.Is(err, entity.ErrPostsTagsAlreadyExisted) {
+				logger.Debugf("update post tags already exists", err)
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if errors.Is(err, entity.ErrPostNotFound) {
+				logger.Debugf("update post not found", err)
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			logger.Errorf("update post", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
 			return
 		}
+	}
 
-		logger.Errorf("update post", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
-		return
+	// タグを付け終わってからサムネイルを作る．作れなくても投稿は保存できているのでエラーにはしない
+	if thumbnailPost, err := p.thumbnailUC.GenerateThumbnail(post); err != nil {
+		logger.Errorf("update post generate thumbnail", err)
+	} else {
+		post = thumbnailPost
 	}
 
+	c.Header("ETag", postETag(post.Version))
 	c.JSON(http.StatusOK, gin.H{
 		"post": post,
 		"tags": tags,
@@ -170,6 +230,14 @@ func (p *PostHandler) GetPosts(c *gin.Context) {
 		offset = (page - 1) * pageSize
 	}
 
+	// pageを指定しない場合はカーソルでページネーションする
+	cursorPageSize, cursor, useCursor, err := cursorPagination(c)
+	if err != nil {
+		logger.Debugf("cursor pagination invalid, %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	if c.Query("is-draft") != "" {
 		isDraft, err := strconv.ParseBool(c.Query("is-draft"))
 		if err != nil {
@@ -182,19 +250,109 @@ func (p *PostHandler) GetPosts(c *gin.Context) {
 		params = append(params, isDraft)
 	}
 
-	if c.Query("tag") != "" {
-		tagName := c.Query("tag")
-		conditions = append(conditions, "tags.name = ?")
-		params = append(params, tagName)
+	// featured=true の場合はトップページのおすすめに表示する投稿だけをおすすめの順番で返す
+	var featured bool
+	if c.Query("featured") != "" {
+		featured, err = strconv.ParseBool(c.Query("featured"))
+		if err != nil {
+			logger.Debugf("featured invalid, %v : %v", c.Query("featured"), err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if featured {
+			conditions = append(conditions, "posts.featured_rank > 0")
+		} else {
+			conditions = append(conditions, "posts.featured_rank = 0")
+		}
+	}
+
+	// 公開日時の範囲で絞り込む．published-after はその日時を含み，published-before は含まない
+	for _, v := range []struct {
+		query     string
+		condition string
+	}{
+		{"published-after", "posts.published_at >= ?"},
+		{"published-before", "posts.published_at < ?"},
+	} {
+		if c.Query(v.query) == "" {
+			continue
+		}
+		publishedAt, err := parsePublishedAt(c.Query(v.query))
+		if err != nil {
+			logger.Debugf("%v invalid, %v : %v", v.query, c.Query(v.query), err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrPublishedAtInvalid.Error()})
+			return
+		}
+		conditions = append(conditions, v.condition)
+		params = append(params, publishedAt)
+	}
+
+	// 一覧ではデフォルトで本文を返さず抜粋だけを返す
+	var withContent bool
+	if c.Query("content") != "" {
+		withContent, err = strconv.ParseBool(c.Query("content"))
+		if err != nil {
+			logger.Errorf("content invalid, %v : %v", c.Query("content"), err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// fieldsを指定した場合は指定したフィールドだけを返す
+	var fields []string
+	var columns []string
+	if c.Query("fields") != "" {
+		for _, field := range strings.Split(c.Query("fields"), ",") {
+			field = strings.TrimSpace(field)
+			column, ok := postFieldColumns[field]
+			if !ok {
+				logger.Debugf("fields invalid, %v", field)
+				c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrPostColumnNotFound.Error()})
+				return
+			}
+			fields = append(fields, field)
+			columns = append(columns, "posts."+column)
+			if field == "content" {
+				withContent = true
+			}
+		}
+	}
+
+	var includeTags bool
+	if c.Query("include") != "" {
+		for _, include := range strings.Split(c.Query("include"), ",") {
+			switch strings.TrimSpace(include) {
+			case "tags":
+				includeTags = true
+			default:
+				logger.Debugf("include invalid, %v", include)
+				c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrPostIncludeInvalid.Error()})
+				return
+			}
+		}
+	}
+
+	// 公開日時がまだ来ていない投稿は一覧に含めない
+	conditions = append(conditions, "(posts.is_draft = true OR posts.published_at <= ?)")
+	params = append(params, flextime.Now())
+
+	tagFilter, err := parseTagFilter(c)
+	if err != nil {
+		logger.Debug("parse tag filter", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	var sortCondition string
+	sortColumn := "id"
+	sortDesc := false
 	if c.Query("sort") != "" {
 		sort := c.Query("sort")
 		order := " asc"
 		if strings.HasPrefix(sort, "-") {
 			order = " desc"
 			sort = sort[1:]
+			sortDesc = true
 		}
 
 		var column string
@@ -221,14 +379,62 @@ func (p *PostHandler) GetPosts(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrPostColumnNotFound.Error()})
 			return
 		}
+		sortColumn = column
 		sortCondition = column + order
 	} else {
 		sortCondition = "id asc"
 	}
+	// 固定した投稿は並べ替えに関わらず先頭に並べ，おすすめはおすすめの順番で並べる
+	orderColumn := "posts.is_pinned"
+	if featured {
+		orderColumn = "posts.featured_rank"
+		sortCondition = orderColumn + " asc, " + sortCondition
+	} else {
+		sortCondition = orderColumn + " desc, " + sortCondition
+	}
+
+	// タグをまとめて取得するためにIDを，DISTINCTと一緒に並べ替えるために並べ替えるカラムを必ず取得する
+	if len(columns) > 0 {
+		for _, column := range []string{"posts.id", "posts." + sortColumn, orderColumn} {
+			if !containsString(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
 
 	condition := strings.Join(conditions, " AND ")
-	posts, count, err := p.postUC.GetPosts(offset, pageSize, condition, params, sortCondition)
+	var posts []*dto.PostDTO
+	var count int
+	var nextCursor, prevCursor string
+	// 検索語が指定された場合は関連度順に並べるためsortは使わない
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		// 関連度はカーソルにできないので，検索ではカーソルを使えない
+		if cursor != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrCursorInvalid.Error()})
+			return
+		}
+		if useCursor {
+			pageSize = cursorPageSize
+		}
+		posts, count, err = p.postUC.SearchPosts(q, offset, pageSize, condition, params, tagFilter, withContent)
+	} else if featured && useCursor {
+		// おすすめの順番はカーソルにできないので，おすすめではカーソルを使えない
+		if cursor != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrCursorInvalid.Error()})
+			return
+		}
+		posts, count, err = p.postUC.GetPosts(0, cursorPageSize, condition, params, tagFilter, sortCondition, columns, withContent)
+	} else if useCursor {
+		posts, count, nextCursor, prevCursor, err = p.postUC.GetPostsByCursor(cursorPageSize, condition, params, tagFilter, sortColumn, sortDesc, true, cursor, columns, withContent)
+	} else {
+		posts, count, err = p.postUC.GetPosts(offset, pageSize, condition, params, tagFilter, sortCondition, columns, withContent)
+	}
 	if err != nil {
+		if errors.Is(err, entity.ErrCursorInvalid) {
+			logger.Debug("get posts cursor invalid", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrCursorInvalid.Error()})
+			return
+		}
 		if errors.Is(err, entity.ErrPostNotFound) {
 			logger.Debug("get posts not found", err)
 			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
@@ -238,9 +444,162 @@ func (p *PostHandler) GetPosts(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
 		return
 	}
+
+	if len(fields) == 0 && !includeTags {
+		c.JSON(http.StatusOK, setCursors(gin.H{
+			"posts": posts,
+			"count": count,
+		}, nextCursor, prevCursor))
+		return
+	}
+
+	var tags map[string][]*dto.TagDTO
+	if includeTags {
+		postIDs := make([]string, 0, len(posts))
+		for _, post := range posts {
+			postIDs = append(postIDs, post.ID)
+		}
+		tags, err = p.tagUC.GetPostsTags(postIDs)
+		if err != nil {
+			logger.Errorf("get posts tags", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+			return
+		}
+	}
+	postFields := make([]gin.H, 0, len(posts))
+	for _, post := range posts {
+		postField := selectPostFields(post, fields)
+		if includeTags {
+			postField["tags"] = tags[post.ID]
+		}
+		postFields = append(postFields, postField)
+	}
+	c.JSON(http.StatusOK, setCursors(gin.H{
+		"posts": postFields,
+		"count": count,
+	}, nextCursor, prevCursor))
+}
+
+// selectPostFields は投稿のうち fields で指定したフィールドだけを返します．fields が空の場合はすべてのフィールドを返します
+func selectPostFields(post *dto.PostDTO, fields []string) gin.H {
+	all := gin.H{
+		"id":           post.ID,
+		"title":        post.Title,
+		"thumbnailUrl": post.ThumbnailURL,
+		"content":      post.Content,
+		"excerpt":      post.Excerpt,
+		"charCount":    post.CharCount,
+		"wordCount":    post.WordCount,
+		"readingTime":  post.ReadingTime,
+		"permalink":    post.Permalink,
+		"isDraft":      post.IsDraft,
+		"isScheduled":  post.IsScheduled,
+		"isPinned":     post.IsPinned,
+		"featuredRank": post.FeaturedRank,
+		"createdAt":    post.CreatedAt,
+		"updatedAt":    post.UpdatedAt,
+		"publishedAt":  post.PublishedAt,
+	}
+	if post.Snippet != "" {
+		all["snippet"] = post.Snippet
+	}
+	if len(fields) == 0 {
+		return all
+	}
+
+	selected := gin.H{}
+	for _, field := range fields {
+		selected[field] = all[field]
+	}
+	if post.Snippet != "" {
+		selected["snippet"] = post.Snippet
+	}
+	return selected
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePublishedAt はRFC3339の日時か，JSTの日付(YYYY-MM-DD)の0時を返します
+func parsePublishedAt(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
+
+// parseTagFilter は tag，-tag，tag-mode からタグで絞り込む条件を作ります
+// tag-mode が all の場合は tag のすべて，any(デフォルト)の場合はいずれかのタグが付いた投稿に絞り込みます
+func parseTagFilter(c *gin.Context) (*entity.TagFilter, error) {
+	tagFilter := &entity.TagFilter{
+		Names:         nonEmptyStrings(c.QueryArray("tag")),
+		ExcludedNames: nonEmptyStrings(c.QueryArray("-tag")),
+	}
+	switch c.Query("tag-mode") {
+	case "", "any":
+	case "all":
+		tagFilter.MatchAll = true
+	default:
+		return nil, entity.ErrTagModeInvalid
+	}
+	if tagFilter.IsEmpty() {
+		return nil, nil
+	}
+	return tagFilter, nil
+}
+
+// nonEmptyStrings は空白を取り除いて空でない値だけを返します
+func nonEmptyStrings(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// GetArchives は GET /archives に対応するハンドラーです。
+func (p *PostHandler) GetArchives(c *gin.Context) {
+	logger := log.GetLogger()
+
+	archives, err := p.postUC.GetArchives()
+	if err != nil {
+		logger.Errorf("get archives", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"archives": archives,
+	})
+}
+
+// GetRelatedPosts は GET /posts/:permalink/related に対応するハンドラーです。
+func (p *PostHandler) GetRelatedPosts(c *gin.Context) {
+	logger := log.GetLogger()
+	permalink := c.Param("permalink")
+
+	posts, err := p.postUC.GetRelatedPosts(permalink)
+	if err != nil {
+		if errors.Is(err, entity.ErrPostNotFound) {
+			logger.Debug("get related posts not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
+			return
+		}
+		logger.Errorf("get related posts", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"posts": posts,
-		"count": count,
 	})
 }
 
@@ -261,6 +620,17 @@ func (p *PostHandler) GetPost(c *gin.Context) {
 
 	post, err := p.postUC.GetPost(permalink, isMarkdown)
 	if err != nil {
+		// 変更前のパーマリンクであれば現在のパーマリンクへ恒久的にリダイレクトする
+		if errors.Is(err, entity.ErrPostMoved) {
+			logger.Debug("get post moved", err)
+			location := path.Join(path.Dir(c.Request.URL.Path), url.PathEscape(post.Permalink))
+			if c.Request.URL.RawQuery != "" {
+				location += "?" + c.Request.URL.RawQuery
+			}
+			c.Header("Location", location)
+			c.JSON(http.StatusMovedPermanently, gin.H{"error": entity.ErrPostMoved.Error(), "movedTo": post.Permalink})
+			return
+		}
 		if errors.Is(err, entity.ErrPostNotFound) {
 			logger.Debug("get post not found", err)
 			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
@@ -271,8 +641,17 @@ func (p *PostHandler) GetPost(c *gin.Context) {
 		return
 	}
 
+	series, err := p.seriesUC.GetPostSeries(post.ID)
+	if err != nil {
+		logger.Errorf("get post series", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.Header("ETag", postETag(post.Version))
 	c.JSON(http.StatusOK, gin.H{
-		"post": post,
+		"post":   post,
+		"series": series,
 	})
 }
 
@@ -295,3 +674,283 @@ func (p *PostHandler) DeletePost(c *gin.Context) {
 		"message": "successfully deleted",
 	})
 }
+
+// RestorePost は POST /posts/:id/restore に対応するハンドラーです。
+func (p *PostHandler) RestorePost(c *gin.Context) {
+	logger := log.GetLogger()
+	id := c.Param("id")
+	post, err := p.postUC.RestorePost(id)
+	if err != nil {
+		if errors.Is(err, entity.ErrPostNotFound) {
+			logger.Debug("restore post not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrPermalinkAlreadyExisted) {
+			logger.Debug("restore post permalink already existed", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrPermalinkAlreadyExisted.Error()})
+			return
+		}
+		logger.Errorf("restore post", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"post": post,
+	})
+}
+
+// PinPost は PUT /posts/:id/pin に対応するハンドラーです。
+// featuredRank を指定した場合はトップページのおすすめにもその順番で表示します
+func (p *PostHandler) PinPost(c *gin.Context) {
+	type request struct {
+		FeaturedRank int `json:"featuredRank"`
+	}
+
+	logger := log.GetLogger()
+	id := c.Param("id")
+
+	req := &request{}
+	if err := c.ShouldBindJSON(req); err != nil && !errors.Is(err, io.EOF) {
+		logger.Errorf("failed to bind", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	post, err := p.postUC.PinPost(id, req.FeaturedRank)
+	if err != nil {
+		if errors.Is(err, entity.ErrPostNotFound) {
+			logger.Debug("pin post not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrFeaturedRankInvalid) {
+			logger.Debug("pin post featured rank invalid", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrFeaturedRankInvalid.Error()})
+			return
+		}
+		logger.Errorf("pin post", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"post": post,
+	})
+}
+
+// UnpinPost は DELETE /posts/:id/pin に対応するハンドラーです。
+func (p *PostHandler) UnpinPost(c *gin.Context) {
+	logger := log.GetLogger()
+	id := c.Param("id")
+	post, err := p.postUC.UnpinPost(id)
+	if err != nil {
+		if errors.Is(err, entity.ErrPostNotFound) {
+			logger.Debug("unpin post not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
+			return
+		}
+		logger.Errorf("unpin post", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"post": post,
+	})
+}
+
+// postIDParam は投稿IDのパスパラメータを返します
+// gin は同じ位置に異なる名前のワイルドカードを登録できないため，GETのルートでは :permalink に投稿IDが入ります
+func postIDParam(c *gin.Context) string {
+	if id := c.Param("id"); id != "" {
+		return id
+	}
+	return c.Param("permalink")
+}
+
+// GetPostRevisions は GET /posts/:id/revisions に対応するハンドラーです。
+func (p *PostHandler) GetPostRevisions(c *gin.Context) {
+	logger := log.GetLogger()
+	postID := postIDParam(c)
+
+	var offset int
+	var pageSize int
+	var err error
+
+	// ページネーションの設定
+	if c.Query("page") != "" && c.Query("page-size") != "" {
+		var page int
+		page, err = strconv.Atoi(c.Query("page"))
+		if err != nil {
+			logger.Errorf("page invalid, %v : %v", c.Query("page"), err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		pageSize, err = strconv.Atoi(c.Query("page-size"))
+		if err != nil {
+			logger.Errorf("page-size invalid, %v : %v", c.Query("page-size"), err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if page == 0 {
+			page = 1
+		}
+
+		offset = (page - 1) * pageSize
+	}
+
+	revisions, count, err := p.postUC.GetPostRevisions(postID, offset, pageSize)
+	if err != nil {
+		if errors.Is(err, entity.ErrPostRevisionNotFound) {
+			logger.Debug("get post revisions not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostRevisionNotFound.Error()})
+			return
+		}
+		logger.Errorf("get post revisions", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"revisions": revisions,
+		"count":     count,
+	})
+}
+
+// GetPostRevisionDiff は GET /posts/:id/revisions/diff に対応するハンドラーです。
+// toを省略した場合は現在の投稿との差分を返します
+func (p *PostHandler) GetPostRevisionDiff(c *gin.Context) {
+	logger := log.GetLogger()
+	postID := postIDParam(c)
+
+	from := c.Query("from")
+	if from == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from is required"})
+		return
+	}
+	to := c.Query("to")
+
+	diff, err := p.postUC.GetPostRevisionDiff(postID, from, to)
+	if err != nil {
+		if errors.Is(err, entity.ErrPostRevisionNotFound) {
+			logger.Debug("get post revision diff not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostRevisionNotFound.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrPostNotFound) {
+			logger.Debug("get post revision diff post not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
+			return
+		}
+		logger.Errorf("get post revision diff", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"diff": diff,
+	})
+}
+
+// RestorePostRevision は POST /posts/:id/revisions/:revisionId/restore に対応するハンドラーです。
+func (p *PostHandler) RestorePostRevision(c *gin.Context) {
+	logger := log.GetLogger()
+	postID := postIDParam(c)
+	revisionID := c.Param("revisionId")
+
+	post, err := p.postUC.RestorePostRevision(postID, revisionID)
+	if err != nil {
+		if errors.Is(err, entity.ErrPostRevisionNotFound) {
+			logger.Debug("restore post revision not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostRevisionNotFound.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrPostNotFound) {
+			logger.Debug("restore post revision post not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrPermalinkAlreadyExisted) || errors.Is(err, entity.ErrPostHasEmptyField) {
+			logger.Debug("restore post revision", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrPostVersionConflict) {
+			logger.Debug("restore post revision version conflict", err)
+			c.JSON(http.StatusConflict, gin.H{"error": entity.ErrPostVersionConflict.Error()})
+			return
+		}
+		logger.Errorf("restore post revision", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"post": post,
+	})
+}
+
+// GetPermalinkRedirects は GET /posts/:id/redirects に対応するハンドラーです。
+func (p *PostHandler) GetPermalinkRedirects(c *gin.Context) {
+	logger := log.GetLogger()
+	postID := postIDParam(c)
+
+	redirects, err := p.postUC.GetPermalinkRedirects(postID)
+	if err != nil {
+		if errors.Is(err, entity.ErrPermalinkRedirectNotFound) {
+			logger.Debug("get permalink redirects not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPermalinkRedirectNotFound.Error()})
+			return
+		}
+		logger.Errorf("get permalink redirects", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"redirects": redirects,
+	})
+}
+
+// DeletePermalinkRedirect は DELETE /posts/:id/redirects/:redirectId に対応するハンドラーです。
+func (p *PostHandler) DeletePermalinkRedirect(c *gin.Context) {
+	logger := log.GetLogger()
+	postID := c.Param("id")
+	redirectID := c.Param("redirectId")
+
+	err := p.postUC.DeletePermalinkRedirect(postID, redirectID)
+	if err != nil {
+		if errors.Is(err, entity.ErrPermalinkRedirectNotFound) {
+			logger.Debug("delete permalink redirect not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPermalinkRedirectNotFound.Error()})
+			return
+		}
+		logger.Errorf("delete permalink redirect", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully deleted",
+	})
+}
+
+// postETag は投稿のバージョンから更新の競合を確認するためのETagを作ります
+func postETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parsePostETag は postETag で作ったETagからバージョンを取り出します
+func parsePostETag(etag string) (int, error) {
+	etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	version, err := strconv.Atoi(strings.Trim(etag, `"`))
+	if err != nil {
+		return 0, fmt.Errorf("parse post etag=%v: %w", etag, err)
+	}
+	if version < 1 {
+		return 0, fmt.Errorf("parse post etag=%v: version must be positive", etag)
+	}
+	return version, nil
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/masibw/blog-server/domain/dto"
 	"github.com/masibw/blog-server/domain/service"
 
 	"github.com/Songmu/flextime"
@@ -16,6 +18,7 @@ import (
 	"github.com/masibw/blog-server/domain/entity"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 	"github.com/masibw/blog-server/domain/mock_repository"
 
 	"github.com/gin-gonic/gin"
@@ -54,7 +57,7 @@ func TestPostHandler_StorePost(t *testing.T) {
 			defer ctrl.Finish()
 			mr := mock_repository.NewMockPost(ctrl)
 			tt.prepareMockPostRepoFn(mr)
-			postUC := usecase.NewPostUseCase(mr)
+			postUC := usecase.NewPostUseCase(mr, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
 
 			// HTTPRequestをテストするために必要な部分
 			w := httptest.NewRecorder()
@@ -76,13 +79,182 @@ func TestPostHandler_StorePost(t *testing.T) {
 }
 
 func TestPostHandler_UpdatePost(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// リクエストのupdatedAtと保存されている投稿の更新日時を揃える
+	flextime.Fix(time.Date(2021, 1, 27, 14, 48, 55, 0, loc))
+	defer flextime.Restore()
+
 	tests := []struct {
 		name              string
 		prepareMockRepoFn func(mockTags *mock_repository.MockTag, mockPosts *mock_repository.MockPost, mockPT *mock_repository.MockPostsTags)
 		ID                string
+		header            map[string]string
 		body              string
 		wantCode          int
 	}{
+		{
+			name: "編集を始めた後に投稿が更新されていた場合はStatusConflictを返す",
+			prepareMockRepoFn: func(mockTags *mock_repository.MockTag, mockPosts *mock_repository.MockPost, mockPT *mock_repository.MockPostsTags) {
+				mockPosts.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "updated_content",
+					Permalink:    "new_permalink",
+					IsDraft:      true,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now().Add(time.Minute),
+					PublishedAt:  time.Time{},
+				}, nil)
+			},
+			ID: "abcdefghijklmnopqrstuvwxyz",
+			body: `{
+				"post": {
+					"id": "abcdefghijklmnopqrstuvwxyz",
+					"title": "new_post",
+					"thumbnailUrl": "new_thumbnail_url",
+					"content": "new_content",
+					"permalink": "new_permalink",
+					"isDraft": true,
+					"createdAt": "2021-01-24T17:49:01+09:00",
+					"updatedAt": "2021-01-27T14:48:55+09:00",
+					"publishedAt": "0001-01-01T00:00:00Z"
+				}
+			}`,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "If-MatchのETagが保存されている投稿と異なる場合はStatusConflictを返す",
+			prepareMockRepoFn: func(mockTags *mock_repository.MockTag, mockPosts *mock_repository.MockPost, mockPT *mock_repository.MockPostsTags) {
+				mockPosts.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "new_permalink",
+					IsDraft:      true,
+					CreatedAt:    flextime.Now(),
+					Version:      3,
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  time.Time{},
+				}, nil)
+			},
+			ID:     "abcdefghijklmnopqrstuvwxyz",
+			header: map[string]string{"If-Match": postETag(2)},
+			body: `{
+				"post": {
+					"id": "abcdefghijklmnopqrstuvwxyz",
+					"title": "new_post",
+					"thumbnailUrl": "new_thumbnail_url",
+					"content": "new_content",
+					"permalink": "new_permalink",
+					"isDraft": true,
+					"createdAt": "2021-01-24T17:49:01+09:00",
+					"updatedAt": "2021-01-27T14:48:55+09:00",
+					"publishedAt": "0001-01-01T00:00:00Z"
+				}
+			}`,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "確認した後に他で更新されて保存に失敗した場合もStatusConflictを返す",
+			prepareMockRepoFn: func(mockTags *mock_repository.MockTag, mockPosts *mock_repository.MockPost, mockPT *mock_repository.MockPostsTags) {
+				stored := &entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "new_permalink",
+					IsDraft:      true,
+					Version:      3,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  time.Time{},
+				}
+				mockPosts.EXPECT().FindByID(gomock.Any()).Return(stored, nil)
+				mockPosts.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
+				mockPosts.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.ErrPostVersionConflict)
+				mockPosts.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz", Version: 4}, nil)
+			},
+			ID:     "abcdefghijklmnopqrstuvwxyz",
+			header: map[string]string{"If-Match": postETag(3)},
+			body: `{
+				"post": {
+					"id": "abcdefghijklmnopqrstuvwxyz",
+					"title": "new_post",
+					"thumbnailUrl": "new_thumbnail_url",
+					"content": "new_content",
+					"permalink": "new_permalink",
+					"isDraft": true,
+					"createdAt": "2021-01-24T17:49:01+09:00",
+					"updatedAt": "2021-01-27T14:48:55+09:00",
+					"publishedAt": "0001-01-01T00:00:00Z"
+				}
+			}`,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "確認した後に他で更新されて保存に失敗し，現在の投稿の取得にも失敗した場合はStatusInternalServerErrorを返す",
+			prepareMockRepoFn: func(mockTags *mock_repository.MockTag, mockPosts *mock_repository.MockPost, mockPT *mock_repository.MockPostsTags) {
+				stored := &entity.Post{
+					ID:           "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "new_permalink",
+					IsDraft:      true,
+					Version:      3,
+					CreatedAt:    flextime.Now(),
+					UpdatedAt:    flextime.Now(),
+					PublishedAt:  time.Time{},
+				}
+				mockPosts.EXPECT().FindByID(gomock.Any()).Return(stored, nil)
+				mockPosts.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
+				mockPosts.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.ErrPostVersionConflict)
+				mockPosts.EXPECT().FindByID(gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			ID:     "abcdefghijklmnopqrstuvwxyz",
+			header: map[string]string{"If-Match": postETag(3)},
+			body: `{
+				"post": {
+					"id": "abcdefghijklmnopqrstuvwxyz",
+					"title": "new_post",
+					"thumbnailUrl": "new_thumbnail_url",
+					"content": "new_content",
+					"permalink": "new_permalink",
+					"isDraft": true,
+					"createdAt": "2021-01-24T17:49:01+09:00",
+					"updatedAt": "2021-01-27T14:48:55+09:00",
+					"publishedAt": "0001-01-01T00:00:00Z"
+				}
+			}`,
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "If-MatchのETagが不正な場合はStatusBadRequestを返す",
+			prepareMockRepoFn: func(mockTags *mock_repository.MockTag, mockPosts *mock_repository.MockPost, mockPT *mock_repository.MockPostsTags) {
+			},
+			ID:     "abcdefghijklmnopqrstuvwxyz",
+			header: map[string]string{"If-Match": `"invalid"`},
+			body: `{
+				"post": {
+					"id": "abcdefghijklmnopqrstuvwxyz",
+					"title": "new_post",
+					"thumbnailUrl": "new_thumbnail_url",
+					"content": "new_content",
+					"permalink": "new_permalink",
+					"isDraft": true,
+					"createdAt": "2021-01-24T17:49:01+09:00",
+					"updatedAt": "2021-01-27T14:48:55+09:00",
+					"publishedAt": "0001-01-01T00:00:00Z"
+				}
+			}`,
+			wantCode: http.StatusBadRequest,
+		},
 		{
 			name: "正常に投稿を更新できる",
 			prepareMockRepoFn: func(mockTags *mock_repository.MockTag, mockPosts *mock_repository.MockPost, mockPT *mock_repository.MockPostsTags) {
@@ -98,7 +270,7 @@ func TestPostHandler_UpdatePost(t *testing.T) {
 					PublishedAt:  time.Time{},
 				}, nil)
 				mockPosts.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
-				mockPosts.EXPECT().Update(gomock.Any()).Return(nil)
+				mockPosts.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				mockPosts.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{}, nil)
 				mockPT.EXPECT().DeleteByPostID(gomock.Any()).Return(nil)
 				mockTags.EXPECT().FindByName("a").Return(&entity.Tag{
@@ -151,7 +323,7 @@ func TestPostHandler_UpdatePost(t *testing.T) {
 					PublishedAt:  time.Time{},
 				}, nil)
 				mockPosts.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
-				mockPosts.EXPECT().Update(gomock.Any()).Return(nil)
+				mockPosts.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			ID: "abcdefghijklmnopqrstuvwxyz",
 			body: `{
@@ -184,7 +356,7 @@ func TestPostHandler_UpdatePost(t *testing.T) {
 					PublishedAt:  time.Time{},
 				}, nil)
 				mockPosts.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
-				mockPosts.EXPECT().Update(gomock.Any()).Return(nil)
+				mockPosts.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				mockPosts.EXPECT().FindByID(gomock.Any()).Return(&entity.Post{}, nil)
 				mockPT.EXPECT().DeleteByPostID(gomock.Any()).Return(nil)
 				mockTags.EXPECT().FindByName("a").Return(&entity.Tag{
@@ -237,7 +409,7 @@ func TestPostHandler_UpdatePost(t *testing.T) {
 					PublishedAt:  time.Time{},
 				}, nil)
 				mockPosts.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
-				mockPosts.EXPECT().Update(gomock.Any()).Return(errors.New("dummy error"))
+				mockPosts.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("dummy error"))
 			},
 			ID: "abcdefghijklmnopqrstuvwxyz",
 			body: `{
@@ -281,7 +453,10 @@ func TestPostHandler_UpdatePost(t *testing.T) {
 			tt.prepareMockRepoFn(mT, mP, mPT)
 
 			pTS := service.NewPostsTagsService(mPT, mP, mT)
-			postUC := usecase.NewPostUseCase(mP)
+			postUC := usecase.NewPostUseCase(mP, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
+			mS := mock_repository.NewMockImageStorage(ctrl)
+			mS.EXPECT().URL(gomock.Any()).Return("https://example.com/ogp.png").AnyTimes()
+			thumbnailUC := usecase.NewThumbnailUseCase(mP, mT, mS, nil)
 
 			// HTTPRequestをテストするために必要な部分
 			w := httptest.NewRecorder()
@@ -289,11 +464,15 @@ func TestPostHandler_UpdatePost(t *testing.T) {
 			body := bytes.NewBufferString(tt.body)
 			req, _ := http.NewRequest(http.MethodPut, "/api/v1/posts/"+tt.ID, body)
 			req.Header.Set("Content-Type", "application/json")
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
 			c.Request = req
 
 			p := &PostHandler{
 				postUC:           postUC,
 				postsTagsService: pTS,
+				thumbnailUC:      thumbnailUC,
 			}
 			p.UpdatePost(c)
 			if w.Code != tt.wantCode {
@@ -337,18 +516,20 @@ func TestPostHandler_GetPosts(t *testing.T) {
 	tests := []struct {
 		name                  string
 		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		prepareMockTagRepoFn  func(mock *mock_repository.MockTag)
 		params                []struct {
 			name  string
 			value string
 		}
-		isDraft  string
-		wantCode int
+		isDraft      string
+		wantCode     int
+		wantPostKeys []string
 	}{
 		{
 			name: "正常に投稿を取得できる",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts, nil)
-				mock.EXPECT().Count(gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
 			},
 			params:   nil,
 			wantCode: http.StatusOK,
@@ -356,7 +537,7 @@ func TestPostHandler_GetPosts(t *testing.T) {
 		{
 			name: "投稿が0件の時はhttp.StatusNotFoundを返す",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, entity.ErrPostNotFound)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, entity.ErrPostNotFound)
 			},
 			params:   nil,
 			wantCode: http.StatusNotFound,
@@ -364,7 +545,7 @@ func TestPostHandler_GetPosts(t *testing.T) {
 		{
 			name: "投稿の取得に失敗した場合はStatusInternalServerErrorエラーが返る",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
 			},
 			params:   nil,
 			wantCode: http.StatusInternalServerError,
@@ -372,8 +553,8 @@ func TestPostHandler_GetPosts(t *testing.T) {
 		{
 			name: "ページングを指定した時も正しく取得できる",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts[1:], nil)
-				mock.EXPECT().Count(gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts[1:], nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
 			},
 			params: []struct {
 				name  string
@@ -392,8 +573,8 @@ func TestPostHandler_GetPosts(t *testing.T) {
 		{
 			name: "tagを指定した時も正しく取得できる",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts[1:], nil)
-				mock.EXPECT().Count(gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), &entity.TagFilter{Names: []string{"a"}}, gomock.Any(), gomock.Any()).Return(existsPosts[1:], nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), &entity.TagFilter{Names: []string{"a"}}).Return(len(existsPosts), nil)
 			},
 			params: []struct {
 				name  string
@@ -405,11 +586,84 @@ func TestPostHandler_GetPosts(t *testing.T) {
 				},
 			},
 			wantCode: http.StatusOK,
+		}, {
+			name: "tag-mode=allで複数のtagを指定した時はすべてのタグが付いた投稿に絞り込む",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				tagFilter := &entity.TagFilter{Names: []string{"go", "mysql"}, MatchAll: true, ExcludedNames: []string{"php"}}
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), tagFilter, gomock.Any(), gomock.Any()).Return(existsPosts[1:], nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), tagFilter).Return(1, nil)
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{"tag", "go"},
+				{"tag", "mysql"},
+				{"-tag", "php"},
+				{"tag-mode", "all"},
+			},
+			wantCode: http.StatusOK,
+		}, {
+			name: "published-afterとpublished-beforeを指定した時は公開日時の範囲で絞り込む",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				after, _ := time.ParseInLocation("2006-01-02", "2020-03-01", time.Local)
+				before, _ := time.Parse(time.RFC3339, "2020-04-01T00:00:00Z")
+				params := []interface{}{after, before, flextime.Now()}
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), params, gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts[1:], nil)
+				mock.EXPECT().Count(gomock.Any(), params, gomock.Any()).Return(1, nil)
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{"published-after", "2020-03-01"},
+				{"published-before", "2020-04-01T00:00:00Z"},
+			},
+			wantCode: http.StatusOK,
+		}, {
+			name: "published-afterの形式が不正な場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{"published-after", "2020/03/01"},
+			},
+			wantCode: http.StatusBadRequest,
+		}, {
+			name: "tag-modeにallとany以外を指定した場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{"tag", "go"},
+				{"tag-mode", "none"},
+			},
+			wantCode: http.StatusBadRequest,
+		}, {
+			name: "qを指定した時は全文検索で取得できる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().Search("new_content", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil).Return(existsPosts[1:], nil)
+				mock.EXPECT().CountSearch("new_content", gomock.Any(), gomock.Any(), nil).Return(1, nil)
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"q",
+					"new_content",
+				},
+			},
+			wantCode: http.StatusOK,
 		}, {
 			name: "is-draftを指定した時も正しく取得できる",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts[1:], nil)
-				mock.EXPECT().Count(gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts[1:], nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
 			},
 			params: []struct {
 				name  string
@@ -450,86 +704,923 @@ func TestPostHandler_GetPosts(t *testing.T) {
 			wantCode: http.StatusBadRequest,
 		},
 		{
-			name: "pageにintに変換できない値が入っていた場合はStatusBadRequestを返す",
+			name: "contentを指定した時は本文も取得できる",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
 			},
 			params: []struct {
 				name  string
 				value string
 			}{
 				{
-					"page",
+					"content",
+					"true",
+				},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "contentにboolに変換できない値が入っていた場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"content",
 					"can't_parse",
-				}, {
-					"page-size",
-					"0",
-				}, {
-					"is-draft",
-					"false",
 				},
 			},
 			wantCode: http.StatusBadRequest,
 		},
 		{
-			name: "sortを指定した時も正しく取得できる",
+			name: "fieldsを指定した時は指定したフィールドだけを取得できる",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				sort.Slice(existsPosts, func(i, j int) bool {
-					return existsPosts[i].CreatedAt.After(existsPosts[j].CreatedAt)
-				})
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "created_at desc").Return(existsPosts, nil)
-				mock.EXPECT().Count(gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil, "posts.is_pinned desc, id asc", []string{"posts.title", "posts.permalink", "posts.id", "posts.is_pinned"}).Return(existsPosts, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"fields",
+					"title,permalink",
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantPostKeys: []string{"permalink", "title"},
+		},
+		{
+			name: "fieldsとsortを指定した時は並べ替えるカラムも取得する",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil, "posts.is_pinned desc, published_at desc", []string{"posts.title", "posts.id", "posts.published_at", "posts.is_pinned"}).Return(existsPosts, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
 			},
 			params: []struct {
 				name  string
 				value string
 			}{
 				{
+					"fields",
+					"title",
+				}, {
 					"sort",
-					"-createdAt",
+					"-publishedAt",
 				},
 			},
-			wantCode: http.StatusOK,
+			wantCode:     http.StatusOK,
+			wantPostKeys: []string{"title"},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-
-			// Repositoryのモック
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-			mr := mock_repository.NewMockPost(ctrl)
-			tt.prepareMockPostRepoFn(mr)
-			postUC := usecase.NewPostUseCase(mr)
-
-			// HTTPRequestをテストするために必要な部分
-			w := httptest.NewRecorder()
-			c, _ := gin.CreateTestContext(w)
-
-			var queryParam string
-			for _, v := range tt.params {
-				queryParam += "&" + v.name + "=" + v.value
-			}
-			if queryParam != "" {
-				queryParam = "?" + queryParam[1:]
-			}
-
-			req, _ := http.NewRequest(http.MethodGet, "/api/v1/posts"+queryParam, nil)
-			req.Header.Set("Content-Type", "application/json")
-			c.Request = req
-
-			p := &PostHandler{
-				postUC: postUC,
-			}
-			p.GetPosts(c)
-			if w.Code != tt.wantCode {
-				t.Errorf("GetPosts() code = %d, want = %d", w.Code, tt.wantCode)
-			}
-
-		})
-	}
-}
-
-func TestPostHandler_GetPost(t *testing.T) {
+		{
+			name: "fieldsに存在しないフィールドを指定した場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"fields",
+					"title,password",
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "include=tagsを指定した時はタグも取得できる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+			},
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByPostIDs([]string{"abcdefghijklmnopqrstuvwxyz", "abcdefghijklmnopqrstuvwxy2"}).Return(map[string][]*entity.Tag{
+					"abcdefghijklmnopqrstuvwxyz": {{ID: "abcdefghijklmnopqrstuvwxy3", Name: "new_tag"}},
+				}, nil)
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"fields",
+					"title",
+				}, {
+					"include",
+					"tags",
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantPostKeys: []string{"tags", "title"},
+		},
+		{
+			name: "タグの取得に失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsPosts, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+			},
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByPostIDs(gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"include",
+					"tags",
+				},
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "includeに対応していないものを指定した場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"include",
+					"comments",
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "pageを指定せずにpage-sizeを指定した時はカーソルでページングできる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(0, 2, gomock.Any(), gomock.Any(), nil, "posts.is_pinned desc, posts.published_at desc, posts.id desc", gomock.Any()).Return(existsPosts, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"page-size",
+					"1",
+				}, {
+					"sort",
+					"-publishedAt",
+				},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "cursorが不正な場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"page-size",
+					"1",
+				}, {
+					"cursor",
+					"invalid",
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "並べ替えるカラムとcursorのカラムが異なる場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"page-size",
+					"1",
+				}, {
+					"sort",
+					"title",
+				}, {
+					"cursor",
+					entity.NewCursor("published_at", flextime.Now(), "abcdefghijklmnopqrstuvwxy1", false).Encode(),
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "カーソルに使えないカラムで並べ替えてpage-sizeだけを指定した場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"page-size",
+					"1",
+				}, {
+					"sort",
+					"content",
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "カーソルに使えないカラムでもpageを指定した場合は並べ替えられる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(0, 1, gomock.Any(), gomock.Any(), nil, "posts.is_pinned desc, thumbnail_url asc", gomock.Any()).Return(existsPosts, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"page",
+					"1",
+				}, {
+					"page-size",
+					"1",
+				}, {
+					"sort",
+					"thumbnailUrl",
+				},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "qとcursorを指定した場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"page-size",
+					"1",
+				}, {
+					"q",
+					"new_content",
+				}, {
+					"cursor",
+					entity.NewCursor("id", "abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy1", false).Encode(),
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "pageにintに変換できない値が入っていた場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"page",
+					"can't_parse",
+				}, {
+					"page-size",
+					"0",
+				}, {
+					"is-draft",
+					"false",
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "sortを指定した時も正しく取得できる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				sort.Slice(existsPosts, func(i, j int) bool {
+					return existsPosts[i].CreatedAt.After(existsPosts[j].CreatedAt)
+				})
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil, "posts.is_pinned desc, created_at desc", nil).Return(existsPosts, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"sort",
+					"-createdAt",
+				},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "featuredを指定した時はおすすめの投稿だけをおすすめの順番で取得できる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindAll(0, 3, "posts.featured_rank > 0 AND (posts.is_draft = true OR posts.published_at <= ?)", gomock.Any(), nil, "posts.featured_rank asc, id asc", nil).Return(existsPosts, nil)
+				mock.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(len(existsPosts), nil)
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"featured",
+					"true",
+				}, {
+					"page-size",
+					"3",
+				},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "featuredとcursorを同時に指定した場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"featured",
+					"true",
+				}, {
+					"page-size",
+					"3",
+				}, {
+					"cursor",
+					entity.NewCursor("id", "abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy1", false).Encode(),
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "featuredにboolに変換できない値が入っていた場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"featured",
+					"can't_parse",
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			postUC := usecase.NewPostUseCase(mr, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
+			mt := mock_repository.NewMockTag(ctrl)
+			if tt.prepareMockTagRepoFn != nil {
+				tt.prepareMockTagRepoFn(mt)
+			}
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			var queryParam string
+			for _, v := range tt.params {
+				queryParam += "&" + v.name + "=" + v.value
+			}
+			if queryParam != "" {
+				queryParam = "?" + queryParam[1:]
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/posts"+queryParam, nil)
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+
+			p := &PostHandler{
+				postUC: postUC,
+				tagUC:  usecase.NewTagUseCase(mt),
+			}
+			p.GetPosts(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetPosts() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+
+			if tt.wantPostKeys != nil {
+				var body struct {
+					Posts []map[string]json.RawMessage `json:"posts"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+					t.Fatal(err)
+				}
+				for _, post := range body.Posts {
+					keys := make([]string, 0, len(post))
+					for key := range post {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					if diff := cmp.Diff(tt.wantPostKeys, keys); diff != "" {
+						t.Errorf("GetPosts() keys mismatch (-want +got):\n%s", diff)
+					}
+				}
+			}
+
+		})
+	}
+}
+
+func TestPostHandler_GetPost(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	existsPost := &entity.Post{
+		ID:           "abcdefghijklmnopqrstuvwxyz",
+		Title:        "new_post",
+		ThumbnailURL: "new_thumbnail_url",
+		Content:      "new_content",
+		Permalink:    "new_permalink",
+		IsDraft:      false,
+		CreatedAt:    flextime.Now(),
+		UpdatedAt:    flextime.Now(),
+		PublishedAt:  flextime.Now(),
+	}
+
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect)
+		prepareMockSeriesFn   func(mockSeries *mock_repository.MockSeries, mockSeriesPosts *mock_repository.MockSeriesPost)
+		permalink             string
+		params                []struct {
+			name  string
+			value string
+		}
+		wantCode     int
+		wantLocation string
+		wantSeries   *dto.PostSeriesDTO
+	}{
+		{
+			name: "正常に投稿を取得できる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink(gomock.Any()).Return(existsPost, nil)
+			},
+			permalink: "new_permalink",
+			wantCode:  http.StatusOK,
+		},
+		{
+			name: "連載に含まれる投稿の場合は何回目かと前後の投稿も返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink(gomock.Any()).Return(existsPost, nil)
+				mock.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), nil, gomock.Any(), nil).Return([]*entity.Post{
+					{ID: "abcdefghijklmnopqrstuvwxy1", Title: "part1", Permalink: "part1"},
+					existsPost,
+				}, nil)
+			},
+			prepareMockSeriesFn: func(mockSeries *mock_repository.MockSeries, mockSeriesPosts *mock_repository.MockSeriesPost) {
+				mockSeriesPosts.EXPECT().FindByPostID(existsPost.ID).Return(&entity.SeriesPost{ID: "abcdefghijklmnopqrstuvwsp2", SeriesID: "abcdefghijklmnopqrstuvwxse", PostID: existsPost.ID, Position: 3}, nil)
+				mockSeries.EXPECT().FindByID("abcdefghijklmnopqrstuvwxse").Return(&entity.Series{ID: "abcdefghijklmnopqrstuvwxse", Title: "series"}, nil)
+				// 2回目の投稿は下書きに戻したので飛ばす
+				mockSeriesPosts.EXPECT().FindBySeriesID("abcdefghijklmnopqrstuvwxse").Return([]*entity.SeriesPost{
+					{PostID: "abcdefghijklmnopqrstuvwxy1", Position: 1},
+					{PostID: "abcdefghijklmnopqrstuvwxy2", Position: 2},
+					{PostID: existsPost.ID, Position: 3},
+				}, nil)
+			},
+			permalink: "new_permalink",
+			wantCode:  http.StatusOK,
+			wantSeries: &dto.PostSeriesDTO{
+				ID:    "abcdefghijklmnopqrstuvwxse",
+				Title: "series",
+				Part:  2,
+				Total: 2,
+				Prev:  &dto.SeriesPartDTO{Title: "part1", Permalink: "part1"},
+			},
+		},
+		{
+			name: "投稿がない場合はStatusNotFoundを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
+				mockRedirects.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPermalinkRedirectNotFound)
+			},
+			permalink: "not_found",
+			wantCode:  http.StatusNotFound,
+		},
+		{
+			name: "変更前のパーマリンクの場合は現在のパーマリンクへStatusMovedPermanentlyでリダイレクトする",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink("old_permalink").Return(nil, entity.ErrPostNotFound)
+				mockRedirects.EXPECT().FindByPermalink("old_permalink").Return(&entity.PermalinkRedirect{
+					ID:        "abcdefghijklmnopqrstuvwxy1",
+					PostID:    "abcdefghijklmnopqrstuvwxyz",
+					Permalink: "old_permalink",
+				}, nil)
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(existsPost, nil)
+			},
+			permalink: "old_permalink",
+			params: []struct {
+				name  string
+				value string
+			}{{
+				"is-markdown",
+				"true",
+			},
+			},
+			wantCode:     http.StatusMovedPermanently,
+			wantLocation: "/api/v1/posts/new_permalink?is-markdown=true",
+		},
+		{
+			name: "投稿の取得に失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			permalink: "not_found",
+			wantCode:  http.StatusInternalServerError,
+		}, {
+			name: "is-markdownにboolに変換できない値が入っていた場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost, mockRedirects *mock_repository.MockPermalinkRedirect) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{{
+				"is-markdown",
+				"can't_parse",
+			},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			mpr := mock_repository.NewMockPermalinkRedirect(ctrl)
+			tt.prepareMockPostRepoFn(mr, mpr)
+			postUC := usecase.NewPostUseCase(mr, mock_repository.NewMockPostRevision(ctrl), mpr)
+			ms := mock_repository.NewMockSeries(ctrl)
+			msp := mock_repository.NewMockSeriesPost(ctrl)
+			if tt.prepareMockSeriesFn != nil {
+				tt.prepareMockSeriesFn(ms, msp)
+			} else {
+				msp.EXPECT().FindByPostID(gomock.Any()).Return(nil, entity.ErrSeriesPostNotFound).AnyTimes()
+			}
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			var queryParam string
+			for _, v := range tt.params {
+				queryParam += "&" + v.name + "=" + v.value
+			}
+			if queryParam != "" {
+				queryParam = "?" + queryParam[1:]
+			}
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/posts/"+tt.permalink+queryParam, nil)
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+			c.Params = gin.Params{{Key: "permalink", Value: tt.permalink}}
+
+			p := &PostHandler{
+				postUC:   postUC,
+				seriesUC: usecase.NewSeriesUseCase(ms, msp, mr),
+			}
+			p.GetPost(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetPost() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+			if tt.wantSeries != nil {
+				var body struct {
+					Series *dto.PostSeriesDTO `json:"series"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tt.wantSeries, body.Series); diff != "" {
+					t.Errorf("GetPost() series mismatch (-want +got):\n%s", diff)
+				}
+			}
+			if got := w.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("GetPost() Location = %v, want = %v", got, tt.wantLocation)
+			}
+
+		})
+	}
+}
+
+func TestPostHandler_DeletePost(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		ID                    string
+		wantCode              int
+	}{
+		{
+			name: "正常に投稿を削除できる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().Delete(gomock.Any()).Return(nil)
+			},
+			ID:       "abcdefghijklmnopqrstuvwxyz",
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "投稿がない場合はStatusNotFoundを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().Delete(gomock.Any()).Return(entity.ErrPostNotFound)
+			},
+			ID:       "not_found",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "投稿の削除に失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().Delete(gomock.Any()).Return(errors.New("dummy error"))
+			},
+			ID:       "not_found",
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			postUC := usecase.NewPostUseCase(mr, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodDelete, "/api/v1/posts/"+tt.ID, nil)
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+
+			p := &PostHandler{
+				postUC: postUC,
+			}
+			p.DeletePost(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetPost() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+
+		})
+	}
+}
+
+func TestPostHandler_RestorePost(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		wantCode              int
+	}{
+		{
+			name: "正常に投稿を元に戻せる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindDeletedByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mock.EXPECT().Restore("abcdefghijklmnopqrstuvwxyz").Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "ゴミ箱に投稿がない場合はStatusNotFoundを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindDeletedByID(gomock.Any()).Return(nil, entity.ErrPostNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "同じパーマリンクの投稿が存在する場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindDeletedByID(gomock.Any()).Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz", Permalink: "new_permalink"}, nil)
+				mock.EXPECT().FindByPermalink("new_permalink").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxy2"}, nil)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "元に戻すのに失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindDeletedByID(gomock.Any()).Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mock.EXPECT().Restore(gomock.Any()).Return(errors.New("dummy error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mr)
+			postUC := usecase.NewPostUseCase(mr, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/posts/abcdefghijklmnopqrstuvwxyz/restore", nil)
+			c.Request = req
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "abcdefghijklmnopqrstuvwxyz"})
+
+			p := &PostHandler{
+				postUC: postUC,
+			}
+			p.RestorePost(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("RestorePost() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPostHandler_GetPostRevisions(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	tests := []struct {
+		name                          string
+		prepareMockPostRevisionRepoFn func(mock *mock_repository.MockPostRevision)
+		ID                            string
+		queryParam                    string
+		wantCode                      int
+	}{
+		{
+			name: "正常にリビジョンの一覧を取得できる",
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByPostID("abcdefghijklmnopqrstuvwxyz", 0, 10).Return([]*entity.PostRevision{
+					{
+						ID:           "abcdefghijklmnopqrstuvwxy1",
+						PostID:       "abcdefghijklmnopqrstuvwxyz",
+						Title:        "new_post",
+						ThumbnailURL: "new_thumbnail_url",
+						Content:      "new_content",
+						Permalink:    "new_permalink",
+						CreatedAt:    flextime.Now(),
+						UpdatedAt:    flextime.Now(),
+					},
+				}, nil)
+				mock.EXPECT().CountByPostID("abcdefghijklmnopqrstuvwxyz").Return(1, nil)
+			},
+			ID:         "abcdefghijklmnopqrstuvwxyz",
+			queryParam: "page=1&page-size=10",
+			wantCode:   http.StatusOK,
+		},
+		{
+			name: "リビジョンがない場合はStatusNotFoundを返す",
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByPostID("not_found", 0, 0).Return(nil, entity.ErrPostRevisionNotFound)
+			},
+			ID:       "not_found",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:                          "page-sizeが数値でない場合はStatusBadRequestを返す",
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {},
+			ID:                            "abcdefghijklmnopqrstuvwxyz",
+			queryParam:                    "page=1&page-size=can't_parse",
+			wantCode:                      http.StatusBadRequest,
+		},
+		{
+			name: "リビジョンの取得に失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByPostID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			ID:       "abcdefghijklmnopqrstuvwxyz",
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			mrr := mock_repository.NewMockPostRevision(ctrl)
+			tt.prepareMockPostRevisionRepoFn(mrr)
+			postUC := usecase.NewPostUseCase(mr, mrr, mock_repository.NewMockPermalinkRedirect(ctrl))
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/posts/"+tt.ID+"/revisions?"+tt.queryParam, nil)
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+			c.Params = gin.Params{{Key: "permalink", Value: tt.ID}}
+
+			p := &PostHandler{
+				postUC: postUC,
+			}
+			p.GetPostRevisions(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetPostRevisions() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPostHandler_GetPostRevisionDiff(t *testing.T) {
+	tests := []struct {
+		name                          string
+		prepareMockPostRevisionRepoFn func(mock *mock_repository.MockPostRevision)
+		queryParam                    string
+		wantCode                      int
+	}{
+		{
+			name: "正常にリビジョン間の差分を取得できる",
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.PostRevision{
+					ID:      "abcdefghijklmnopqrstuvwxy1",
+					PostID:  "abcdefghijklmnopqrstuvwxyz",
+					Content: "new_content",
+				}, nil)
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy2").Return(&entity.PostRevision{
+					ID:      "abcdefghijklmnopqrstuvwxy2",
+					PostID:  "abcdefghijklmnopqrstuvwxyz",
+					Content: "new_content2",
+				}, nil)
+			},
+			queryParam: "from=abcdefghijklmnopqrstuvwxy1&to=abcdefghijklmnopqrstuvwxy2",
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:                          "fromが指定されていない場合はStatusBadRequestを返す",
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {},
+			queryParam:                    "to=abcdefghijklmnopqrstuvwxy2",
+			wantCode:                      http.StatusBadRequest,
+		},
+		{
+			name: "リビジョンが存在しない場合はStatusNotFoundを返す",
+			prepareMockPostRevisionRepoFn: func(mock *mock_repository.MockPostRevision) {
+				mock.EXPECT().FindByID("not_found").Return(nil, entity.ErrPostRevisionNotFound)
+			},
+			queryParam: "from=not_found",
+			wantCode:   http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			mrr := mock_repository.NewMockPostRevision(ctrl)
+			tt.prepareMockPostRevisionRepoFn(mrr)
+			postUC := usecase.NewPostUseCase(mr, mrr, mock_repository.NewMockPermalinkRedirect(ctrl))
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/posts/abcdefghijklmnopqrstuvwxyz/revisions/diff?"+tt.queryParam, nil)
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+			c.Params = gin.Params{{Key: "permalink", Value: "abcdefghijklmnopqrstuvwxyz"}}
+
+			p := &PostHandler{
+				postUC: postUC,
+			}
+			p.GetPostRevisionDiff(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetPostRevisionDiff() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPostHandler_RestorePostRevision(t *testing.T) {
 
 	loc, err := time.LoadLocation("Asia/Tokyo")
 	if err != nil {
@@ -542,60 +1633,118 @@ func TestPostHandler_GetPost(t *testing.T) {
 		ID:           "abcdefghijklmnopqrstuvwxyz",
 		Title:        "new_post",
 		ThumbnailURL: "new_thumbnail_url",
-		Content:      "new_content",
+		Content:      "new_content2",
 		Permalink:    "new_permalink",
-		IsDraft:      false,
+		IsDraft:      true,
 		CreatedAt:    flextime.Now(),
 		UpdatedAt:    flextime.Now(),
-		PublishedAt:  flextime.Now(),
+		PublishedAt:  time.Time{},
 	}
 
 	tests := []struct {
-		name                  string
-		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
-		permalink             string
-		params                []struct {
-			name  string
-			value string
-		}
-		wantCode int
+		name              string
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockRevisions *mock_repository.MockPostRevision)
+		revisionID        string
+		wantCode          int
 	}{
 		{
-			name: "正常に投稿を取得できる",
-			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindByPermalink(gomock.Any()).Return(existsPost, nil)
+			name: "正常にリビジョンを復元できる",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockRevisions *mock_repository.MockPostRevision) {
+				mockRevisions.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.PostRevision{
+					ID:           "abcdefghijklmnopqrstuvwxy1",
+					PostID:       "abcdefghijklmnopqrstuvwxyz",
+					Title:        "new_post",
+					ThumbnailURL: "new_thumbnail_url",
+					Content:      "new_content",
+					Permalink:    "new_permalink",
+				}, nil)
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(existsPost, nil).Times(2)
+				mockPosts.EXPECT().FindByPermalink("new_permalink").Return(existsPost, nil)
+				mockPosts.EXPECT().UpdateWithHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
-			permalink: "new_permalink",
-			wantCode:  http.StatusOK,
+			revisionID: "abcdefghijklmnopqrstuvwxy1",
+			wantCode:   http.StatusOK,
 		},
 		{
-			name: "投稿がない場合はStatusNotFoundを返す",
-			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, entity.ErrPostNotFound)
+			name: "リビジョンが存在しない場合はStatusNotFoundを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockRevisions *mock_repository.MockPostRevision) {
+				mockRevisions.EXPECT().FindByID("not_found").Return(nil, entity.ErrPostRevisionNotFound)
 			},
-			permalink: "not_found",
-			wantCode:  http.StatusNotFound,
+			revisionID: "not_found",
+			wantCode:   http.StatusNotFound,
 		},
 		{
-			name: "投稿の取得に失敗した場合はStatusInternalServerErrorエラーが返る",
-			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().FindByPermalink(gomock.Any()).Return(nil, errors.New("dummy error"))
+			name: "リビジョンのPermalinkが別の投稿で使われている場合はStatusBadRequestを返す",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockRevisions *mock_repository.MockPostRevision) {
+				mockRevisions.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.PostRevision{
+					ID:        "abcdefghijklmnopqrstuvwxy1",
+					PostID:    "abcdefghijklmnopqrstuvwxyz",
+					Permalink: "used_permalink",
+				}, nil)
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(existsPost, nil).Times(2)
+				mockPosts.EXPECT().FindByPermalink("used_permalink").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxy9"}, nil)
 			},
-			permalink: "not_found",
-			wantCode:  http.StatusInternalServerError,
-		}, {
-			name: "is-markdownにboolに変換できない値が入っていた場合はStatusBadRequestを返す",
-			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+			revisionID: "abcdefghijklmnopqrstuvwxy1",
+			wantCode:   http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockPost(ctrl)
+			mrr := mock_repository.NewMockPostRevision(ctrl)
+			tt.prepareMockRepoFn(mr, mrr)
+			postUC := usecase.NewPostUseCase(mr, mrr, mock_repository.NewMockPermalinkRedirect(ctrl))
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/posts/abcdefghijklmnopqrstuvwxyz/revisions/"+tt.revisionID+"/restore", nil)
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: "abcdefghijklmnopqrstuvwxyz"}, {Key: "revisionId", Value: tt.revisionID}}
+
+			p := &PostHandler{
+				postUC: postUC,
+			}
+			p.RestorePostRevision(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("RestorePostRevision() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPostHandler_DeletePermalinkRedirect(t *testing.T) {
+	tests := []struct {
+		name                      string
+		prepareMockRedirectRepoFn func(mock *mock_repository.MockPermalinkRedirect)
+		wantCode                  int
+	}{
+		{
+			name: "リダイレクトを削除できる",
+			prepareMockRedirectRepoFn: func(mock *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.PermalinkRedirect{ID: "abcdefghijklmnopqrstuvwxy1", PostID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mock.EXPECT().Delete("abcdefghijklmnopqrstuvwxy1").Return(nil)
 			},
-			params: []struct {
-				name  string
-				value string
-			}{{
-				"is-markdown",
-				"can't_parse",
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "別の投稿のリダイレクトの場合はStatusNotFoundを返す",
+			prepareMockRedirectRepoFn: func(mock *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.PermalinkRedirect{ID: "abcdefghijklmnopqrstuvwxy1", PostID: "abcdefghijklmnopqrstuvwxy2"}, nil)
 			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "リダイレクトの削除に失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockRedirectRepoFn: func(mock *mock_repository.MockPermalinkRedirect) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(nil, errors.New("dummy error"))
 			},
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
@@ -604,39 +1753,91 @@ func TestPostHandler_GetPost(t *testing.T) {
 			// Repositoryのモック
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
-			mr := mock_repository.NewMockPost(ctrl)
-			tt.prepareMockPostRepoFn(mr)
-			postUC := usecase.NewPostUseCase(mr)
+			mpr := mock_repository.NewMockPermalinkRedirect(ctrl)
+			tt.prepareMockRedirectRepoFn(mpr)
+			postUC := usecase.NewPostUseCase(mock_repository.NewMockPost(ctrl), mock_repository.NewMockPostRevision(ctrl), mpr)
 
 			// HTTPRequestをテストするために必要な部分
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/posts/abcdefghijklmnopqrstuvwxyz/redirects/abcdefghijklmnopqrstuvwxy1", nil)
+			c.Params = gin.Params{{Key: "id", Value: "abcdefghijklmnopqrstuvwxyz"}, {Key: "redirectId", Value: "abcdefghijklmnopqrstuvwxy1"}}
 
-			var queryParam string
-			for _, v := range tt.params {
-				queryParam += "&" + v.name + "=" + v.value
+			p := &PostHandler{
+				postUC: postUC,
 			}
-			if queryParam != "" {
-				queryParam = "?" + queryParam[1:]
+			p.DeletePermalinkRedirect(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("DeletePermalinkRedirect() code = %d, want = %d", w.Code, tt.wantCode)
 			}
-			req, _ := http.NewRequest(http.MethodGet, "/api/v1/posts/"+tt.permalink+queryParam, nil)
-			req.Header.Set("Content-Type", "application/json")
-			c.Request = req
+		})
+	}
+}
+
+func TestPostHandler_GetArchives(t *testing.T) {
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		wantCode              int
+		wantBody              string
+	}{
+		{
+			name: "年ごとと月ごとの投稿数を取得できる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().CountByMonth(gomock.Any(), gomock.Any()).Return([]*entity.MonthlyPostCount{
+					{Year: 2021, Month: 1, Count: 2},
+					{Year: 2020, Month: 12, Count: 3},
+				}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"archives":[{"year":2021,"count":2,"months":[{"month":1,"count":2}]},{"year":2020,"count":3,"months":[{"month":12,"count":3}]}]}`,
+		},
+		{
+			name: "公開済みの投稿がない場合は空の配列を返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().CountByMonth(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"archives":[]}`,
+		},
+		{
+			name: "取得に失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().CountByMonth(gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mp)
+			postUC := usecase.NewPostUseCase(mp, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/archives", nil)
 
 			p := &PostHandler{
 				postUC: postUC,
 			}
-			p.GetPost(c)
+			p.GetArchives(c)
 			if w.Code != tt.wantCode {
-				t.Errorf("GetPost() code = %d, want = %d", w.Code, tt.wantCode)
+				t.Errorf("GetArchives() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("GetArchives() body = %s, want = %s", w.Body.String(), tt.wantBody)
 			}
-
 		})
 	}
 }
 
-func TestPostHandler_DeletePost(t *testing.T) {
-
+func TestPostHandler_GetRelatedPosts(t *testing.T) {
 	loc, err := time.LoadLocation("Asia/Tokyo")
 	if err != nil {
 		t.Fatal(err)
@@ -644,37 +1845,104 @@ func TestPostHandler_DeletePost(t *testing.T) {
 	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
 	defer flextime.Restore()
 
+	target := &entity.Post{ID: "abcdefghijklmnopqrstuvwxy0", Title: "Go", Permalink: "go", PublishedAt: flextime.Now()}
 	tests := []struct {
 		name                  string
 		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
-		ID                    string
 		wantCode              int
 	}{
 		{
-			name: "正常に投稿を削除できる",
+			name: "関連する投稿を取得できる",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().Delete(gomock.Any()).Return(nil)
+				mock.EXPECT().FindByPermalink("go").Return(target, nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entity.Post{target}, nil)
+				mock.EXPECT().CountSharedTags(target.ID).Return(map[string]int{"abcdefghijklmnopqrstuvwxy1": 1}, nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entity.Post{{ID: "abcdefghijklmnopqrstuvwxy1"}}, nil)
 			},
-			ID:       "abcdefghijklmnopqrstuvwxyz",
 			wantCode: http.StatusOK,
 		},
 		{
-			name: "投稿がない場合はStatusNotFoundを返す",
+			name: "投稿が存在しない場合はStatusNotFoundを返す",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().Delete(gomock.Any()).Return(entity.ErrPostNotFound)
+				mock.EXPECT().FindByPermalink("go").Return(nil, entity.ErrPostNotFound)
 			},
-			ID:       "not_found",
 			wantCode: http.StatusNotFound,
 		},
 		{
-			name: "投稿の削除に失敗した場合はStatusInternalServerErrorエラーが返る",
+			name: "取得に失敗した場合はStatusInternalServerErrorエラーが返る",
 			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
-				mock.EXPECT().Delete(gomock.Any()).Return(errors.New("dummy error"))
+				mock.EXPECT().FindByPermalink("go").Return(nil, errors.New("dummy error"))
 			},
-			ID:       "not_found",
 			wantCode: http.StatusInternalServerError,
 		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockPostRepoFn(mp)
+			postUC := usecase.NewPostUseCase(mp, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/posts/go/related", nil)
+			c.Params = gin.Params{{Key: "permalink", Value: "go"}}
+
+			p := &PostHandler{
+				postUC: postUC,
+			}
+			p.GetRelatedPosts(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetRelatedPosts() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPostHandler_PinPost(t *testing.T) {
+	tests := []struct {
+		name                  string
+		prepareMockPostRepoFn func(mock *mock_repository.MockPost)
+		body                  string
+		wantCode              int
+	}{
+		{
+			name: "おすすめの順番を指定して投稿を固定できる",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mock.EXPECT().UpdatePin("abcdefghijklmnopqrstuvwxyz", true, 1).Return(nil)
+			},
+			body:     `{"featuredRank": 1}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "bodyがない場合はおすすめにせずに固定する",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mock.EXPECT().UpdatePin("abcdefghijklmnopqrstuvwxyz", true, 0).Return(nil)
+			},
+			body:     "",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:                  "おすすめの順番が負の数の場合はStatusBadRequestを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {},
+			body:                  `{"featuredRank": -1}`,
+			wantCode:              http.StatusBadRequest,
+		},
+		{
+			name: "投稿が存在しない場合はStatusNotFoundを返す",
+			prepareMockPostRepoFn: func(mock *mock_repository.MockPost) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(nil, entity.ErrPostNotFound)
+			},
+			body:     "",
+			wantCode: http.StatusNotFound,
+		},
+	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
@@ -683,23 +1951,46 @@ func TestPostHandler_DeletePost(t *testing.T) {
 			defer ctrl.Finish()
 			mr := mock_repository.NewMockPost(ctrl)
 			tt.prepareMockPostRepoFn(mr)
-			postUC := usecase.NewPostUseCase(mr)
+			postUC := usecase.NewPostUseCase(mr, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
 
 			// HTTPRequestをテストするために必要な部分
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
-			req, _ := http.NewRequest(http.MethodDelete, "/api/v1/posts/"+tt.ID, nil)
+			req, _ := http.NewRequest(http.MethodPut, "/api/v1/posts/abcdefghijklmnopqrstuvwxyz/pin", bytes.NewBufferString(tt.body))
 			req.Header.Set("Content-Type", "application/json")
 			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: "abcdefghijklmnopqrstuvwxyz"}}
 
 			p := &PostHandler{
 				postUC: postUC,
 			}
-			p.DeletePost(c)
+			p.PinPost(c)
 			if w.Code != tt.wantCode {
-				t.Errorf("GetPost() code = %d, want = %d", w.Code, tt.wantCode)
+				t.Errorf("PinPost() code = %d, want = %d", w.Code, tt.wantCode)
 			}
-
 		})
 	}
 }
+
+func TestPostHandler_UnpinPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr := mock_repository.NewMockPost(ctrl)
+	mr.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz", IsPinned: true, FeaturedRank: 1}, nil)
+	mr.EXPECT().UpdatePin("abcdefghijklmnopqrstuvwxyz", false, 0).Return(nil)
+	postUC := usecase.NewPostUseCase(mr, mock_repository.NewMockPostRevision(ctrl), mock_repository.NewMockPermalinkRedirect(ctrl))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/posts/abcdefghijklmnopqrstuvwxyz/pin", nil)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "abcdefghijklmnopqrstuvwxyz"}}
+
+	p := &PostHandler{
+		postUC: postUC,
+	}
+	p.UnpinPost(c)
+	if w.Code != http.StatusOK {
+		t.Errorf("UnpinPost() code = %d, want = %d", w.Code, http.StatusOK)
+	}
+}
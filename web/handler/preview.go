@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/log"
+	"github.com/masibw/blog-server/usecase"
+)
+
+type PreviewHandler struct {
+	previewUC  *usecase.PreviewUseCase
+	defaultTTL time.Duration
+}
+
+func NewPreviewHandler(previewUC *usecase.PreviewUseCase, defaultTTL time.Duration) *PreviewHandler {
+	return &PreviewHandler{
+		previewUC:  previewUC,
+		defaultTTL: defaultTTL,
+	}
+}
+
+// CreatePreviewToken は POST /posts/:id/previews に対応するハンドラーです。
+// expiresIn(例: 24h)を省略した場合はデフォルトの有効期間になります
+func (p *PreviewHandler) CreatePreviewToken(c *gin.Context) {
+	type request struct {
+		ExpiresIn string `json:"expiresIn"`
+	}
+
+	logger := log.GetLogger()
+	postID := c.Param("id")
+
+	req := &request{}
+	if err := c.ShouldBindJSON(req); err != nil && !errors.Is(err, io.EOF) {
+		logger.Errorf("failed to bind", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ttl := p.defaultTTL
+	if req.ExpiresIn != "" {
+		var err error
+		ttl, err = time.ParseDuration(req.ExpiresIn)
+		if err != nil || ttl <= 0 {
+			logger.Debugf("expiresIn invalid, %v : %v", req.ExpiresIn, err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "expiresIn must be a positive duration"})
+			return
+		}
+	}
+
+	previewToken, err := p.previewUC.CreatePreviewToken(postID, ttl)
+	if err != nil {
+		if errors.Is(err, entity.ErrPostNotFound) {
+			logger.Debug("create preview token post not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPostNotFound.Error()})
+			return
+		}
+		logger.Errorf("create preview token", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"previewToken": previewToken,
+	})
+}
+
+// GetPreviewTokens は GET /posts/:id/previews に対応するハンドラーです。
+func (p *PreviewHandler) GetPreviewTokens(c *gin.Context) {
+	logger := log.GetLogger()
+	postID := postIDParam(c)
+
+	previewTokens, err := p.previewUC.GetPreviewTokens(postID)
+	if err != nil {
+		if errors.Is(err, entity.ErrPreviewTokenNotFound) {
+			logger.Debug("get preview tokens not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPreviewTokenNotFound.Error()})
+			return
+		}
+		logger.Errorf("get preview tokens", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"previewTokens": previewTokens,
+	})
+}
+
+// RevokePreviewToken は DELETE /posts/:id/previews/:previewId に対応するハンドラーです。
+func (p *PreviewHandler) RevokePreviewToken(c *gin.Context) {
+	logger := log.GetLogger()
+	postID := c.Param("id")
+	previewTokenID := c.Param("previewId")
+
+	err := p.previewUC.RevokePreviewToken(postID, previewTokenID)
+	if err != nil {
+		if errors.Is(err, entity.ErrPreviewTokenNotFound) {
+			logger.Debug("revoke preview token not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPreviewTokenNotFound.Error()})
+			return
+		}
+		logger.Errorf("revoke preview token", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully revoked",
+	})
+}
+
+// GetPreview は GET /previews/:token に対応するハンドラーです。
+// ログインしていなくても下書きを読めるため，検索エンジンやキャッシュに残らないようにしています
+func (p *PreviewHandler) GetPreview(c *gin.Context) {
+	logger := log.GetLogger()
+	token := c.Param("token")
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex")
+
+	post, err := p.previewUC.GetPreview(token)
+	if err != nil {
+		if errors.Is(err, entity.ErrPreviewTokenExpired) {
+			logger.Debug("get preview expired", err)
+			c.JSON(http.StatusGone, gin.H{"error": entity.ErrPreviewTokenExpired.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrPreviewTokenInvalid) || errors.Is(err, entity.ErrPreviewTokenNotFound) || errors.Is(err, entity.ErrPostNotFound) {
+			logger.Debug("get preview not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrPreviewTokenNotFound.Error()})
+			return
+		}
+		logger.Errorf("get preview", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"post": post,
+	})
+}
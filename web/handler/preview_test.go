@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+	"github.com/masibw/blog-server/usecase"
+)
+
+var previewSecret = []byte("secret")
+
+func TestPreviewHandler_CreatePreviewToken(t *testing.T) {
+	tests := []struct {
+		name              string
+		body              string
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken)
+		wantCode          int
+	}{
+		{
+			name: "本文を省略した場合はデフォルトの有効期間で発行できる",
+			body: "",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mockPreviewTokens.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name: "expiresInを指定して発行できる",
+			body: `{"expiresIn":"24h"}`,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mockPreviewTokens.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:              "expiresInが不正な場合はStatusBadRequestが返る",
+			body:              `{"expiresIn":"-1h"}`,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {},
+			wantCode:          http.StatusBadRequest,
+		},
+		{
+			name: "投稿が存在しない場合はStatusNotFoundが返る",
+			body: "",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(nil, entity.ErrPostNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mpt := mock_repository.NewMockPreviewToken(ctrl)
+			tt.prepareMockRepoFn(mp, mpt)
+			previewUC := usecase.NewPreviewUseCase(mp, mpt, previewSecret)
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/posts/abcdefghijklmnopqrstuvwxyz/previews", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: "abcdefghijklmnopqrstuvwxyz"}}
+
+			p := NewPreviewHandler(previewUC, 72*time.Hour)
+			p.CreatePreviewToken(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("CreatePreviewToken() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPreviewHandler_RevokePreviewToken(t *testing.T) {
+	tests := []struct {
+		name              string
+		prepareMockRepoFn func(mockPreviewTokens *mock_repository.MockPreviewToken)
+		wantCode          int
+	}{
+		{
+			name: "プレビューリンクを無効にできる",
+			prepareMockRepoFn: func(mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPreviewTokens.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.PreviewToken{ID: "abcdefghijklmnopqrstuvwxy1", PostID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mockPreviewTokens.EXPECT().Delete("abcdefghijklmnopqrstuvwxy1").Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "プレビューリンクが存在しない場合はStatusNotFoundが返る",
+			prepareMockRepoFn: func(mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPreviewTokens.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(nil, entity.ErrPreviewTokenNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "無効にするのに失敗した場合はStatusInternalServerErrorが返る",
+			prepareMockRepoFn: func(mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPreviewTokens.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.PreviewToken{ID: "abcdefghijklmnopqrstuvwxy1", PostID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+				mockPreviewTokens.EXPECT().Delete("abcdefghijklmnopqrstuvwxy1").Return(errors.New("dummy error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mpt := mock_repository.NewMockPreviewToken(ctrl)
+			tt.prepareMockRepoFn(mpt)
+			previewUC := usecase.NewPreviewUseCase(mp, mpt, previewSecret)
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/posts/abcdefghijklmnopqrstuvwxyz/previews/abcdefghijklmnopqrstuvwxy1", nil)
+			c.Params = gin.Params{{Key: "id", Value: "abcdefghijklmnopqrstuvwxyz"}, {Key: "previewId", Value: "abcdefghijklmnopqrstuvwxy1"}}
+
+			p := NewPreviewHandler(previewUC, 72*time.Hour)
+			p.RevokePreviewToken(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("RevokePreviewToken() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPreviewHandler_GetPreview(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	validToken := &entity.PreviewToken{
+		ID:        "abcdefghijklmnopqrstuvwxy1",
+		PostID:    "abcdefghijklmnopqrstuvwxyz",
+		ExpiresAt: time.Date(2021, 1, 25, 0, 0, 0, 0, loc),
+	}
+	expiredToken := &entity.PreviewToken{
+		ID:        "abcdefghijklmnopqrstuvwxy2",
+		PostID:    "abcdefghijklmnopqrstuvwxyz",
+		ExpiresAt: time.Date(2021, 1, 21, 0, 0, 0, 0, loc),
+	}
+
+	tests := []struct {
+		name              string
+		token             string
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken)
+		wantCode          int
+	}{
+		{
+			name:  "有効なトークンで下書きを取得できる",
+			token: validToken.Sign(previewSecret),
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPreviewTokens.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(validToken, nil)
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Post{ID: "abcdefghijklmnopqrstuvwxyz", Permalink: "draft", IsDraft: true}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:              "有効期限が切れている場合はStatusGoneが返る",
+			token:             expiredToken.Sign(previewSecret),
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {},
+			wantCode:          http.StatusGone,
+		},
+		{
+			name:              "署名が正しくない場合はStatusNotFoundが返る",
+			token:             "invalid",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {},
+			wantCode:          http.StatusNotFound,
+		},
+		{
+			name:  "無効にされたプレビューリンクの場合はStatusNotFoundが返る",
+			token: validToken.Sign(previewSecret),
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockPreviewTokens *mock_repository.MockPreviewToken) {
+				mockPreviewTokens.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(nil, entity.ErrPreviewTokenNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mpt := mock_repository.NewMockPreviewToken(ctrl)
+			tt.prepareMockRepoFn(mp, mpt)
+			previewUC := usecase.NewPreviewUseCase(mp, mpt, previewSecret)
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/previews/"+tt.token, nil)
+			c.Params = gin.Params{{Key: "token", Value: tt.token}}
+
+			p := NewPreviewHandler(previewUC, 72*time.Hour)
+			p.GetPreview(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetPreview() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+			if got := w.Header().Get("Cache-Control"); got != "no-store" {
+				t.Errorf("GetPreview() Cache-Control = %v, want = no-store", got)
+			}
+		})
+	}
+}
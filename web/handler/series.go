@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/domain/dto"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/service"
+	"github.com/masibw/blog-server/log"
+	"github.com/masibw/blog-server/usecase"
+)
+
+type SeriesHandler struct {
+	seriesUC           *usecase.SeriesUseCase
+	seriesPostsService *service.SeriesPostsService
+}
+
+func NewSeriesHandler(seriesUC *usecase.SeriesUseCase, seriesPostsService *service.SeriesPostsService) *SeriesHandler {
+	return &SeriesHandler{
+		seriesUC:           seriesUC,
+		seriesPostsService: seriesPostsService,
+	}
+}
+
+// StoreSeries は POST /series に対応するハンドラーです。
+func (s *SeriesHandler) StoreSeries(c *gin.Context) {
+	logger := log.GetLogger()
+	seriesDTO := &dto.SeriesDTO{}
+	if err := c.ShouldBindJSON(seriesDTO); err != nil {
+		logger.Errorf("failed to bind", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series, err := s.seriesUC.StoreSeries(seriesDTO)
+	if err != nil {
+		logger.Errorf("store series", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"series": series,
+	})
+}
+
+// GetSeriesList は GET /series に対応するハンドラーです。
+func (s *SeriesHandler) GetSeriesList(c *gin.Context) {
+	logger := log.GetLogger()
+	seriesList, err := s.seriesUC.GetSeriesList()
+	if err != nil {
+		if errors.Is(err, entity.ErrSeriesNotFound) {
+			logger.Debug("get series list not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrSeriesNotFound.Error()})
+			return
+		}
+		logger.Errorf("get series list", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"series": seriesList,
+	})
+}
+
+// GetSeries は GET /series/:id に対応するハンドラーです。
+func (s *SeriesHandler) GetSeries(c *gin.Context) {
+	logger := log.GetLogger()
+	id := c.Param("id")
+	series, err := s.seriesUC.GetSeries(id)
+	if err != nil {
+		if errors.Is(err, entity.ErrSeriesNotFound) {
+			logger.Debug("get series not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrSeriesNotFound.Error()})
+			return
+		}
+		logger.Errorf("get series", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"series": series,
+	})
+}
+
+// UpdateSeries は PUT /series/:id に対応するハンドラーです。
+// postIds を指定した場合は連載に含まれる投稿をその順番で置き換えます
+func (s *SeriesHandler) UpdateSeries(c *gin.Context) {
+	type request struct {
+		Title       string   `json:"title" binding:"required"`
+		Description string   `json:"description"`
+		PostIDs     []string `json:"postIds"`
+	}
+
+	req := &request{}
+	logger := log.GetLogger()
+	if err := c.ShouldBindJSON(req); err != nil {
+		logger.Errorf("failed to bind", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id := c.Param("id")
+	series, err := s.seriesUC.UpdateSeries(&dto.SeriesDTO{
+		ID:          id,
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrSeriesNotFound) {
+			logger.Debug("update series not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrSeriesNotFound.Error()})
+			return
+		}
+		logger.Errorf("update series", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	if req.PostIDs != nil {
+		_, err = s.seriesPostsService.LinkSeriesPosts(id, req.PostIDs)
+		if err != nil {
+			if errors.Is(err, entity.ErrSeriesPostAlreadyExisted) || errors.Is(err, entity.ErrPostNotFound) {
+				logger.Debug("update series posts invalid", err)
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			logger.Errorf("update series posts", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"series": series,
+	})
+}
+
+// DeleteSeries は DELETE /series/:id に対応するハンドラーです。
+func (s *SeriesHandler) DeleteSeries(c *gin.Context) {
+	logger := log.GetLogger()
+	id := c.Param("id")
+	if err := s.seriesUC.DeleteSeries(id); err != nil {
+		if errors.Is(err, entity.ErrSeriesNotFound) {
+			logger.Debug("delete series not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrSeriesNotFound.Error()})
+			return
+		}
+		logger.Errorf("delete series", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully deleted",
+	})
+}
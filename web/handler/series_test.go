@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+	"github.com/masibw/blog-server/domain/service"
+	"github.com/masibw/blog-server/usecase"
+)
+
+func TestSeriesHandler_StoreSeries(t *testing.T) {
+	tests := []struct {
+		name                    string
+		prepareMockSeriesRepoFn func(mock *mock_repository.MockSeries)
+		body                    string
+		wantCode                int
+	}{
+		{
+			name: "正常に連載を保存できる",
+			prepareMockSeriesRepoFn: func(mock *mock_repository.MockSeries) {
+				mock.EXPECT().Store(gomock.Any()).Return(nil)
+			},
+			body: `{
+				"title" : "Go入門",
+				"description" : "Goの基本を順番に説明します"
+			}`,
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:                    "タイトルがない時はStatusBadRequestエラーが返る",
+			prepareMockSeriesRepoFn: func(mock *mock_repository.MockSeries) {},
+			body: `{
+				"description" : "Goの基本を順番に説明します"
+			}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "保存に失敗した時はStatusInternalServerErrorエラーが返る",
+			prepareMockSeriesRepoFn: func(mock *mock_repository.MockSeries) {
+				mock.EXPECT().Store(gomock.Any()).Return(errors.New("dummy error"))
+			},
+			body: `{
+				"title" : "Go入門"
+			}`,
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ms := mock_repository.NewMockSeries(ctrl)
+			tt.prepareMockSeriesRepoFn(ms)
+			seriesUC := usecase.NewSeriesUseCase(ms, mock_repository.NewMockSeriesPost(ctrl), mock_repository.NewMockPost(ctrl))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			body := bytes.NewBufferString(tt.body)
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/series", body)
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+
+			s := &SeriesHandler{
+				seriesUC: seriesUC,
+			}
+			s.StoreSeries(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("StoreSeries() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSeriesHandler_GetSeries(t *testing.T) {
+	seriesID := "abcdefghijklmnopqrstuvwxyz"
+	tests := []struct {
+		name          string
+		prepareMockFn func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost)
+		wantCode      int
+	}{
+		{
+			name: "存在する連載を取得できる",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID}, nil)
+				mockSP.EXPECT().FindBySeriesID(seriesID).Return(nil, entity.ErrSeriesPostNotFound)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "存在しない連載の場合はStatusNotFoundエラーが返る",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(nil, entity.ErrSeriesNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ms := mock_repository.NewMockSeries(ctrl)
+			msp := mock_repository.NewMockSeriesPost(ctrl)
+			tt.prepareMockFn(ms, msp)
+			seriesUC := usecase.NewSeriesUseCase(ms, msp, mock_repository.NewMockPost(ctrl))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/series/"+seriesID, nil)
+			c.Request = req
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: seriesID})
+
+			s := &SeriesHandler{
+				seriesUC: seriesUC,
+			}
+			s.GetSeries(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetSeries() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSeriesHandler_UpdateSeries(t *testing.T) {
+	seriesID := "abcdefghijklmnopqrstuvwxyz"
+	tests := []struct {
+		name          string
+		prepareMockFn func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost)
+		body          string
+		wantCode      int
+	}{
+		{
+			name: "タイトルと連載に含まれる投稿を更新できる",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID}, nil).Times(2)
+				mockSeries.EXPECT().Update(gomock.Any()).Return(nil)
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.Post{}, nil)
+				mockSP.EXPECT().FindByPostID("abcdefghijklmnopqrstuvwxy1").Return(nil, entity.ErrSeriesPostNotFound)
+				mockSP.EXPECT().ReplaceBySeriesID(seriesID, gomock.Any()).Return(nil)
+			},
+			body: `{
+				"title" : "Go入門",
+				"postIds" : ["abcdefghijklmnopqrstuvwxy1"]
+			}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "postIdsを指定しない場合は連載に含まれる投稿を変えない",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID}, nil)
+				mockSeries.EXPECT().Update(gomock.Any()).Return(nil)
+			},
+			body: `{
+				"title" : "Go入門"
+			}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "別の連載に含まれる投稿を指定した場合はStatusBadRequestエラーが返る",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(&entity.Series{ID: seriesID}, nil).Times(2)
+				mockSeries.EXPECT().Update(gomock.Any()).Return(nil)
+				mockPosts.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy1").Return(&entity.Post{}, nil)
+				mockSP.EXPECT().FindByPostID("abcdefghijklmnopqrstuvwxy1").Return(&entity.SeriesPost{SeriesID: "abcdefghijklmnopqrstuvwxy9"}, nil)
+			},
+			body: `{
+				"title" : "Go入門",
+				"postIds" : ["abcdefghijklmnopqrstuvwxy1"]
+			}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "存在しない連載の場合はStatusNotFoundエラーが返る",
+			prepareMockFn: func(mockSeries *mock_repository.MockSeries, mockSP *mock_repository.MockSeriesPost, mockPosts *mock_repository.MockPost) {
+				mockSeries.EXPECT().FindByID(seriesID).Return(nil, entity.ErrSeriesNotFound)
+			},
+			body: `{
+				"title" : "Go入門"
+			}`,
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ms := mock_repository.NewMockSeries(ctrl)
+			msp := mock_repository.NewMockSeriesPost(ctrl)
+			mp := mock_repository.NewMockPost(ctrl)
+			tt.prepareMockFn(ms, msp, mp)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			body := bytes.NewBufferString(tt.body)
+			req, _ := http.NewRequest(http.MethodPut, "/api/v1/series/"+seriesID, body)
+			req.Header.Set("Content-Type", "application/json")
+			c.Request = req
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: seriesID})
+
+			s := &SeriesHandler{
+				seriesUC:           usecase.NewSeriesUseCase(ms, msp, mp),
+				seriesPostsService: service.NewSeriesPostsService(msp, ms, mp),
+			}
+			s.UpdateSeries(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("UpdateSeries() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSeriesHandler_DeleteSeries(t *testing.T) {
+	seriesID := "abcdefghijklmnopqrstuvwxyz"
+	tests := []struct {
+		name                    string
+		prepareMockSeriesRepoFn func(mock *mock_repository.MockSeries)
+		wantCode                int
+	}{
+		{
+			name: "連載を削除できる",
+			prepareMockSeriesRepoFn: func(mock *mock_repository.MockSeries) {
+				mock.EXPECT().Delete(seriesID).Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "存在しない連載の場合はStatusNotFoundエラーが返る",
+			prepareMockSeriesRepoFn: func(mock *mock_repository.MockSeries) {
+				mock.EXPECT().Delete(seriesID).Return(entity.ErrSeriesNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ms := mock_repository.NewMockSeries(ctrl)
+			tt.prepareMockSeriesRepoFn(ms)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodDelete, "/api/v1/series/"+seriesID, nil)
+			c.Request = req
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: seriesID})
+
+			s := &SeriesHandler{
+				seriesUC: usecase.NewSeriesUseCase(ms, mock_repository.NewMockSeriesPost(ctrl), mock_repository.NewMockPost(ctrl)),
+			}
+			s.DeleteSeries(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("DeleteSeries() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
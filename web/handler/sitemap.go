@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/log"
+	"github.com/masibw/blog-server/sitemap"
+	"github.com/masibw/blog-server/usecase"
+)
+
+type SitemapHandler struct {
+	sitemapUC *usecase.SitemapUseCase
+	siteURL   string
+	robotsTxt string
+}
+
+func NewSitemapHandler(sitemapUC *usecase.SitemapUseCase, siteURL, robotsTxt string) *SitemapHandler {
+	// robots.txtにサイトマップの場所が書かれていなければ追記する
+	if !strings.Contains(strings.ToLower(robotsTxt), "sitemap:") {
+		robotsTxt = strings.TrimRight(robotsTxt, "\n") + "\n\nSitemap: " + siteURL + "/sitemap.xml\n"
+	}
+	return &SitemapHandler{
+		sitemapUC: sitemapUC,
+		siteURL:   siteURL,
+		robotsTxt: robotsTxt,
+	}
+}
+
+// GetSitemap は GET /sitemap.xml に対応するハンドラーです。
+// URLが1つのサイトマップに収まらない場合はサイトマップインデックスを返します
+func (s *SitemapHandler) GetSitemap(c *gin.Context) {
+	logger := log.GetLogger()
+	count, err := s.sitemapUC.CountSitemaps()
+	if err != nil {
+		logger.Errorf("count sitemaps", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	if count > 1 {
+		s.renderXML(c, sitemap.NewIndex(s.siteURL, count))
+		return
+	}
+	s.renderPage(c, 1)
+}
+
+// GetSitemapPage は GET /sitemaps/:page に対応するハンドラーです。
+func (s *SitemapHandler) GetSitemapPage(c *gin.Context) {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("page"), ".xml"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrSitemapNotFound.Error()})
+		return
+	}
+	s.renderPage(c, page)
+}
+
+// GetRobotsTxt は GET /robots.txt に対応するハンドラーです。
+func (s *SitemapHandler) GetRobotsTxt(c *gin.Context) {
+	c.String(http.StatusOK, s.robotsTxt)
+}
+
+func (s *SitemapHandler) renderPage(c *gin.Context, page int) {
+	logger := log.GetLogger()
+	entries, err := s.sitemapUC.GetSitemapEntries(page)
+	if err != nil {
+		if errors.Is(err, entity.ErrSitemapNotFound) {
+			logger.Debug("get sitemap not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrSitemapNotFound.Error()})
+			return
+		}
+		logger.Errorf("get sitemap", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+	s.renderXML(c, sitemap.NewURLSet(s.siteURL, entries))
+}
+
+func (s *SitemapHandler) renderXML(c *gin.Context, v interface{}) {
+	logger := log.GetLogger()
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		logger.Errorf("marshal sitemap", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/masibw/blog-server/constant"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+	"github.com/masibw/blog-server/usecase"
+)
+
+func TestSitemapHandler_GetSitemap(t *testing.T) {
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flextime.Fix(time.Date(2021, 1, 22, 0, 0, 0, 0, loc))
+	defer flextime.Restore()
+
+	defaultMaxURLs := constant.SitemapMaxURLs
+	defer func() { constant.SitemapMaxURLs = defaultMaxURLs }()
+
+	tests := []struct {
+		name              string
+		maxURLs           int
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag)
+		wantCode          int
+		wantBody          string
+	}{
+		{
+			name:    "URLが1つのサイトマップに収まる場合はURLの一覧を返す",
+			maxURLs: 50000,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil).Times(2)
+				mockPosts.EXPECT().FindAll(0, 49999, gomock.Any(), gomock.Any(), nil, "posts.id asc", nil).Return([]*entity.Post{{
+					ID:        "abcdefghijklmnopqrstuvwxyz",
+					Permalink: "new_permalink",
+					UpdatedAt: flextime.Now(),
+				}}, nil)
+				mockTags.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), "").Return(nil, entity.ErrTagNotFound).Times(2)
+			},
+			wantCode: http.StatusOK,
+			wantBody: "<loc>https://mesimasi.com/posts/new_permalink</loc>",
+		},
+		{
+			name:    "URLが1つのサイトマップに収まらない場合はサイトマップインデックスを返す",
+			maxURLs: 1,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+				mockTags.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), "").Return(nil, entity.ErrTagNotFound)
+			},
+			wantCode: http.StatusOK,
+			wantBody: "<loc>https://mesimasi.com/sitemaps/2.xml</loc>",
+		},
+		{
+			name:    "投稿数の取得に失敗した場合はStatusInternalServerErrorを返す",
+			maxURLs: 50000,
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(0, errors.New("dummy error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			constant.SitemapMaxURLs = tt.maxURLs
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mt := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockRepoFn(mp, mt)
+			sitemapUC := usecase.NewSitemapUseCase(mp, mt)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/sitemap.xml", nil)
+
+			s := NewSitemapHandler(sitemapUC, "https://mesimasi.com", "")
+			s.GetSitemap(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetSitemap() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("GetSitemap() body = %s, want to contain %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestSitemapHandler_GetSitemapPage(t *testing.T) {
+	tests := []struct {
+		name              string
+		page              string
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag)
+		wantCode          int
+	}{
+		{
+			name: "存在するページのサイトマップを取得できる",
+			page: "1.xml",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(0, nil)
+				mockTags.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), "").Return(nil, entity.ErrTagNotFound)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "存在しないページの場合はStatusNotFoundを返す",
+			page: "2.xml",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().Count(gomock.Any(), gomock.Any(), gomock.Any()).Return(0, nil)
+				mockTags.EXPECT().FindAll(0, 0, gomock.Any(), gomock.Any(), "").Return(nil, entity.ErrTagNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:              "ページ番号が数値でない場合はStatusNotFoundを返す",
+			page:              "abc.xml",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {},
+			wantCode:          http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mt := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockRepoFn(mp, mt)
+			sitemapUC := usecase.NewSitemapUseCase(mp, mt)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/sitemaps/"+tt.page, nil)
+			c.Params = append(c.Params, gin.Param{Key: "page", Value: tt.page})
+
+			s := NewSitemapHandler(sitemapUC, "https://mesimasi.com", "")
+			s.GetSitemapPage(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetSitemapPage() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSitemapHandler_GetRobotsTxt(t *testing.T) {
+	tests := []struct {
+		name      string
+		robotsTxt string
+		want      string
+	}{
+		{
+			name:      "サイトマップの場所が書かれていない場合は追記する",
+			robotsTxt: "User-agent: *\nDisallow: /api/\n",
+			want:      "User-agent: *\nDisallow: /api/\n\nSitemap: https://mesimasi.com/sitemap.xml\n",
+		},
+		{
+			name:      "サイトマップの場所が書かれている場合はそのまま返す",
+			robotsTxt: "User-agent: *\nSitemap: https://example.com/sitemap.xml\n",
+			want:      "User-agent: *\nSitemap: https://example.com/sitemap.xml\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/robots.txt", nil)
+
+			s := NewSitemapHandler(nil, "https://mesimasi.com", tt.robotsTxt)
+			s.GetRobotsTxt(c)
+			if w.Code != http.StatusOK {
+				t.Errorf("GetRobotsTxt() code = %d, want = %d", w.Code, http.StatusOK)
+			}
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("GetRobotsTxt() body = %q, want = %q", got, tt.want)
+			}
+		})
+	}
+}
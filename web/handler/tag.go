@@ -40,6 +40,11 @@ func (p *TagHandler) StoreTag(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, entity.ErrTagParentNotFound) {
+			logger.Debug("store tag parent not found", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrTagParentNotFound.Error()})
+			return
+		}
 		logger.Errorf("store tag", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
 		return
@@ -78,6 +83,14 @@ func (p *TagHandler) GetTags(c *gin.Context) {
 		offset = (page - 1) * pageSize
 	}
 
+	// pageを指定しない場合はカーソルでページネーションする
+	cursorPageSize, cursor, useCursor, err := cursorPagination(c)
+	if err != nil {
+		logger.Debugf("cursor pagination invalid, %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	conditions := make([]string, 0)
 	params := make([]interface{}, 0)
 
@@ -88,8 +101,20 @@ func (p *TagHandler) GetTags(c *gin.Context) {
 	}
 	condition := strings.Join(conditions, " AND ")
 
-	tags, count, err := p.tagUC.GetTags(offset, pageSize, condition, params)
+	var tags []*dto.TagDTO
+	var count int
+	var nextCursor, prevCursor string
+	if useCursor {
+		tags, count, nextCursor, prevCursor, err = p.tagUC.GetTagsByCursor(cursorPageSize, condition, params, cursor)
+	} else {
+		tags, count, err = p.tagUC.GetTags(offset, pageSize, condition, params)
+	}
 	if err != nil {
+		if errors.Is(err, entity.ErrCursorInvalid) {
+			logger.Debug("get tags cursor invalid", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrCursorInvalid.Error()})
+			return
+		}
 		if errors.Is(err, entity.ErrTagNotFound) {
 			logger.Debug("get tags not found", err)
 			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrTagNotFound.Error()})
@@ -100,10 +125,10 @@ func (p *TagHandler) GetTags(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusOK, setCursors(gin.H{
 		"tags":  tags,
 		"count": count,
-	})
+	}, nextCursor, prevCursor))
 }
 
 func (p *TagHandler) GetTag(c *gin.Context) {
@@ -145,3 +170,96 @@ func (p *TagHandler) DeleteTag(c *gin.Context) {
 		"message": "successfully deleted",
 	})
 }
+
+// RestoreTag は POST /tags/:id/restore に対応するハンドラーです。
+func (p *TagHandler) RestoreTag(c *gin.Context) {
+	logger := log.GetLogger()
+	id := c.Param("id")
+	tag, err := p.tagUC.RestoreTag(id)
+	if err != nil {
+		if errors.Is(err, entity.ErrTagNotFound) {
+			logger.Debug("restore tag not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrTagNotFound.Error()})
+			return
+		}
+		logger.Errorf("restore tag", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tag": tag,
+	})
+}
+
+// UpdateTag は PUT /tags/:id に対応するハンドラーです。
+// タグの名前と親タグを変更します
+func (p *TagHandler) UpdateTag(c *gin.Context) {
+	logger := log.GetLogger()
+	tagDTO := &dto.TagDTO{}
+	if err := c.ShouldBindJSON(tagDTO); err != nil {
+		logger.Errorf("failed to bind", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tagDTO.ID = c.Param("id")
+
+	tag, err := p.tagUC.UpdateTag(tagDTO)
+	if err != nil {
+		if errors.Is(err, entity.ErrTagNotFound) {
+			logger.Debug("update tag not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrTagNotFound.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrTagNameAlreadyExisted) || errors.Is(err, entity.ErrTagParentNotFound) || errors.Is(err, entity.ErrTagParentCycle) {
+			logger.Debug("update tag invalid", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		logger.Errorf("update tag", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tag": tag,
+	})
+}
+
+// MergeTag は POST /tags/:id/merge に対応するハンドラーです。
+// タグの付いた投稿と子タグを targetId のタグに付け替え，統合元のタグを削除します
+func (p *TagHandler) MergeTag(c *gin.Context) {
+	type request struct {
+		TargetID string `json:"targetId" binding:"required"`
+	}
+
+	logger := log.GetLogger()
+	req := &request{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		logger.Errorf("failed to bind", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id := c.Param("id")
+
+	tag, err := p.tagUC.MergeTag(id, req.TargetID)
+	if err != nil {
+		if errors.Is(err, entity.ErrTagNotFound) {
+			logger.Debug("merge tag not found", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": entity.ErrTagNotFound.Error()})
+			return
+		}
+		if errors.Is(err, entity.ErrTagMergeTargetInvalid) {
+			logger.Debug("merge tag invalid target", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrTagMergeTargetInvalid.Error()})
+			return
+		}
+		logger.Errorf("merge tag", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tag": tag,
+	})
+}
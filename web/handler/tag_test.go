@@ -30,6 +30,7 @@ func TestTagHandler_StoreTag(t *testing.T) {
 			name: "正常にタグを保存できる",
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
 				mock.EXPECT().FindByName(gomock.Any()).Return(nil, entity.ErrTagNotFound)
+				mock.EXPECT().FindDeletedByName(gomock.Any()).Return(nil, entity.ErrTagNotFound)
 				mock.EXPECT().Store(gomock.Any()).Return(nil)
 			},
 			body: `{
@@ -48,6 +49,7 @@ func TestTagHandler_StoreTag(t *testing.T) {
 			name: "保存に失敗した時はStatusInternalServerErrorエラーが返る",
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
 				mock.EXPECT().FindByName("new_tag").Return(nil, nil)
+				mock.EXPECT().FindDeletedByName("new_tag").Return(nil, entity.ErrTagNotFound)
 				mock.EXPECT().Store(gomock.Any()).Return(errors.New("dummy error"))
 			},
 			body: `{
@@ -129,7 +131,7 @@ func TestTagHandler_GetTags(t *testing.T) {
 		{
 			name: "正常にタグを取得できる",
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsTags, nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsTags, nil)
 				mock.EXPECT().Count().Return(len(existsTags), nil)
 			},
 			params:   nil,
@@ -138,7 +140,7 @@ func TestTagHandler_GetTags(t *testing.T) {
 		{
 			name: "タグが0件の時はhttp.StatusNotFoundを返す",
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, entity.ErrTagNotFound)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, entity.ErrTagNotFound)
 			},
 			params:   nil,
 			wantCode: http.StatusNotFound,
@@ -146,7 +148,7 @@ func TestTagHandler_GetTags(t *testing.T) {
 		{
 			name: "タグの取得に失敗した場合はStatusInternalServerErrorエラーが返る",
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
 
 			},
 			params:   nil,
@@ -155,7 +157,7 @@ func TestTagHandler_GetTags(t *testing.T) {
 		{
 			name: "ページングを指定した時も正しく取得できる",
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
-				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsTags[1:], nil)
+				mock.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existsTags[1:], nil)
 				mock.EXPECT().Count().Return(len(existsTags), nil)
 			},
 			params: []struct {
@@ -171,6 +173,42 @@ func TestTagHandler_GetTags(t *testing.T) {
 				},
 			},
 			wantCode: http.StatusOK,
+		}, {
+			name: "pageを指定せずにpage-sizeを指定した時はカーソルでページングできる",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindAll(0, 2, "tags.id > ?", []interface{}{"abcdefghijklmnopqrstuvwxy1"}, "tags.id asc").Return(existsTags[1:], nil)
+				mock.EXPECT().Count().Return(len(existsTags), nil)
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"page-size",
+					"1",
+				}, {
+					"cursor",
+					entity.NewCursor("id", "abcdefghijklmnopqrstuvwxy1", "abcdefghijklmnopqrstuvwxy1", false).Encode(),
+				},
+			},
+			wantCode: http.StatusOK,
+		}, {
+			name: "cursorが不正な場合はStatusBadRequestを返す",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+			},
+			params: []struct {
+				name  string
+				value string
+			}{
+				{
+					"page-size",
+					"1",
+				}, {
+					"cursor",
+					"invalid",
+				},
+			},
+			wantCode: http.StatusBadRequest,
 		}, {
 			name: "pageにint型に変換できない値が入っていた場合はStatusBadRequestを返す",
 			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
@@ -375,3 +413,198 @@ func TestTagHandler_DeleteTag(t *testing.T) {
 		})
 	}
 }
+
+func TestTagHandler_RestoreTag(t *testing.T) {
+	tests := []struct {
+		name                 string
+		prepareMockTagRepoFn func(mock *mock_repository.MockTag)
+		wantCode             int
+	}{
+		{
+			name: "正常にタグを元に戻せる",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().Restore("abcdefghijklmnopqrstuvwxyz").Return(nil)
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz"}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "ゴミ箱にタグがない場合はStatusNotFoundを返す",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().Restore(gomock.Any()).Return(entity.ErrTagNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "元に戻すのに失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().Restore(gomock.Any()).Return(errors.New("dummy error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockTagRepoFn(mr)
+			tagUC := usecase.NewTagUseCase(mr)
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tags/abcdefghijklmnopqrstuvwxyz/restore", nil)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "abcdefghijklmnopqrstuvwxyz"})
+
+			p := &TagHandler{
+				tagUC: tagUC,
+			}
+			p.RestoreTag(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("RestoreTag() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestTagHandler_UpdateTag(t *testing.T) {
+	tests := []struct {
+		name                 string
+		body                 string
+		prepareMockTagRepoFn func(mock *mock_repository.MockTag)
+		wantCode             int
+	}{
+		{
+			name: "正常にタグの名前を変更できる",
+			body: `{"name":"Go"}`,
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "golang"}, nil)
+				mock.EXPECT().FindByName("Go").Return(nil, entity.ErrTagNotFound)
+				mock.EXPECT().Update(gomock.Any()).Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:                 "名前がない場合はStatusBadRequestを返す",
+			body:                 `{}`,
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {},
+			wantCode:             http.StatusBadRequest,
+		},
+		{
+			name: "親タグに自身を指定した場合はStatusBadRequestを返す",
+			body: `{"name":"golang","parentId":"abcdefghijklmnopqrstuvwxyz"}`,
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Times(2).Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "golang"}, nil)
+				mock.EXPECT().FindByName("golang").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "golang"}, nil)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "タグが存在しない場合はStatusNotFoundを返す",
+			body: `{"name":"Go"}`,
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(nil, entity.ErrTagNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockTagRepoFn(mr)
+			tagUC := usecase.NewTagUseCase(mr)
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/tags/abcdefghijklmnopqrstuvwxyz", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "abcdefghijklmnopqrstuvwxyz"})
+
+			p := &TagHandler{
+				tagUC: tagUC,
+			}
+			p.UpdateTag(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("UpdateTag() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestTagHandler_MergeTag(t *testing.T) {
+	tests := []struct {
+		name                 string
+		body                 string
+		prepareMockTagRepoFn func(mock *mock_repository.MockTag)
+		wantCode             int
+	}{
+		{
+			name: "正常にタグを統合できる",
+			body: `{"targetId":"abcdefghijklmnopqrstuvwxy2"}`,
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "golang"}, nil)
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy2").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxy2", Name: "Go"}, nil)
+				mock.EXPECT().Merge("abcdefghijklmnopqrstuvwxyz", "abcdefghijklmnopqrstuvwxy2").Return(nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "自身に統合する場合はStatusBadRequestを返す",
+			body: `{"targetId":"abcdefghijklmnopqrstuvwxyz"}`,
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Times(2).Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "golang"}, nil)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:                 "targetIdがない場合はStatusBadRequestを返す",
+			body:                 `{}`,
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {},
+			wantCode:             http.StatusBadRequest,
+		},
+		{
+			name: "統合に失敗した場合はStatusInternalServerErrorを返す",
+			body: `{"targetId":"abcdefghijklmnopqrstuvwxy2"}`,
+			prepareMockTagRepoFn: func(mock *mock_repository.MockTag) {
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxyz").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxyz", Name: "golang"}, nil)
+				mock.EXPECT().FindByID("abcdefghijklmnopqrstuvwxy2").Return(&entity.Tag{ID: "abcdefghijklmnopqrstuvwxy2", Name: "Go"}, nil)
+				mock.EXPECT().Merge(gomock.Any(), gomock.Any()).Return(errors.New("dummy error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mr := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockTagRepoFn(mr)
+			tagUC := usecase.NewTagUseCase(mr)
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tags/abcdefghijklmnopqrstuvwxyz/merge", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "abcdefghijklmnopqrstuvwxyz"})
+
+			p := &TagHandler{
+				tagUC: tagUC,
+			}
+			p.MergeTag(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("MergeTag() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
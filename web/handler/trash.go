@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/log"
+	"github.com/masibw/blog-server/usecase"
+)
+
+type TrashHandler struct {
+	trashUC *usecase.TrashUseCase
+}
+
+func NewTrashHandler(trashUC *usecase.TrashUseCase) *TrashHandler {
+	return &TrashHandler{trashUC: trashUC}
+}
+
+// GetTrash は GET /trash に対応するハンドラーです。
+func (t *TrashHandler) GetTrash(c *gin.Context) {
+	logger := log.GetLogger()
+	posts, tags, err := t.trashUC.GetTrash()
+	if err != nil {
+		logger.Errorf("get trash", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"posts": posts,
+		"tags":  tags,
+	})
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/domain/mock_repository"
+	"github.com/masibw/blog-server/usecase"
+)
+
+func TestTrashHandler_GetTrash(t *testing.T) {
+	tests := []struct {
+		name              string
+		prepareMockRepoFn func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag)
+		wantCode          int
+	}{
+		{
+			name: "正常にゴミ箱を取得できる",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindDeleted().Return([]*entity.Post{{ID: "abcdefghijklmnopqrstuvwxy1"}}, nil)
+				mockTags.EXPECT().FindDeleted().Return(nil, entity.ErrTagNotFound)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "ゴミ箱の取得に失敗した場合はStatusInternalServerErrorエラーが返る",
+			prepareMockRepoFn: func(mockPosts *mock_repository.MockPost, mockTags *mock_repository.MockTag) {
+				mockPosts.EXPECT().FindDeleted().Return(nil, errors.New("dummy error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Repositoryのモック
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mp := mock_repository.NewMockPost(ctrl)
+			mt := mock_repository.NewMockTag(ctrl)
+			tt.prepareMockRepoFn(mp, mt)
+			trashUC := usecase.NewTrashUseCase(mp, mt)
+
+			// HTTPRequestをテストするために必要な部分
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/trash", nil)
+
+			tr := NewTrashHandler(trashUC)
+			tr.GetTrash(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("GetTrash() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/constant"
+	"github.com/masibw/blog-server/domain/entity"
+	"github.com/masibw/blog-server/log"
+	"github.com/masibw/blog-server/storage"
+)
+
+// UploadHandler は画像をローカルに保存する場合に，署名付きURLへのアップロードを受け付けます
+type UploadHandler struct {
+	storage *storage.LocalStorage
+}
+
+func NewUploadHandler(storage *storage.LocalStorage) *UploadHandler {
+	return &UploadHandler{storage: storage}
+}
+
+// PutImage は PUT /uploads/*filepath に対応するハンドラーです。
+// S3の署名付きURLと同じく，署名したContent-Typeでリクエストボディをそのまま保存します
+func (u *UploadHandler) PutImage(c *gin.Context) {
+	logger := log.GetLogger()
+	key := strings.TrimPrefix(c.Param("filepath"), "/")
+	contentType := c.GetHeader("Content-Type")
+
+	err := u.storage.VerifyPresignedURL(key, contentType, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		logger.Debug("put image forbidden", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, constant.MaxImageSize)
+	err = u.storage.Put(key, contentType, body)
+	if err != nil {
+		if errors.Is(err, entity.ErrImageKeyInvalid) {
+			logger.Debug("put image invalid key", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": entity.ErrImageKeyInvalid.Error()})
+			return
+		}
+		if isRequestBodyTooLarge(err) {
+			logger.Debug("put image too large", err)
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			return
+		}
+		logger.Errorf("put image", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternalServerError.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
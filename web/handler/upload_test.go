@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Songmu/flextime"
+	"github.com/gin-gonic/gin"
+	"github.com/masibw/blog-server/storage"
+)
+
+func TestUploadHandler_PutImage(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		name        string
+		key         string
+		contentType string
+		requestAt   time.Time
+		wantCode    int
+	}{
+		{
+			name:        "署名付きURLにアップロードできる",
+			key:         "image.png",
+			contentType: "image/png",
+			requestAt:   now,
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "署名と異なるContent-Typeの時はStatusForbiddenエラーが返る",
+			key:         "image.png",
+			contentType: "text/html",
+			requestAt:   now,
+			wantCode:    http.StatusForbidden,
+		},
+		{
+			name:        "有効期限が切れている時はStatusForbiddenエラーが返る",
+			key:         "image.png",
+			contentType: "image/png",
+			requestAt:   now.Add(time.Hour),
+			wantCode:    http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := storage.NewLocalStorage(dir, "/api/v1/uploads", []byte("secret"))
+
+			flextime.Fix(now)
+			signedURL, err := s.CreatePresignedURL(tt.key, "image/png", time.Minute)
+			if err != nil {
+				t.Fatalf("CreatePresignedURL() error = %v", err)
+			}
+			signed, _ := url.Parse(signedURL)
+
+			flextime.Fix(tt.requestAt)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			req, _ := http.NewRequest(http.MethodPut, signed.String(), strings.NewReader("body"))
+			req.Header.Set("Content-Type", tt.contentType)
+			c.Request = req
+			c.Params = append(c.Params, gin.Param{Key: "filepath", Value: "/" + tt.key})
+
+			u := &UploadHandler{
+				storage: s,
+			}
+			u.PutImage(c)
+			if w.Code != tt.wantCode {
+				t.Errorf("PutImage() code = %d, want = %d", w.Code, tt.wantCode)
+			}
+
+			_, err = ioutil.ReadFile(filepath.Join(dir, tt.key))
+			if (err == nil) != (tt.wantCode == http.StatusOK) {
+				t.Errorf("PutImage() saved = %v, want = %v", err == nil, tt.wantCode == http.StatusOK)
+			}
+		})
+	}
+}
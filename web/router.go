@@ -8,7 +8,9 @@ import (
 	"github.com/Songmu/flextime"
 	"github.com/gin-contrib/cors"
 	"github.com/masibw/blog-server/constant"
+	"github.com/masibw/blog-server/feed"
 	"github.com/masibw/blog-server/log"
+	"github.com/masibw/blog-server/storage"
 
 	"github.com/masibw/blog-server/domain/service"
 
@@ -24,7 +26,7 @@ type login struct {
 	Password    string `form:"password" json:"password" binding:"required"`
 }
 
-func NewServer(postUC *usecase.PostUseCase, tagUC *usecase.TagUseCase, imageUC *usecase.ImageUseCase, authMW *AuthMiddleware, postsTagsService *service.PostsTagsService) (e *gin.Engine) {
+func NewServer(postUC *usecase.PostUseCase, tagUC *usecase.TagUseCase, imageUC *usecase.ImageUseCase, sitemapUC *usecase.SitemapUseCase, trashUC *usecase.TrashUseCase, previewUC *usecase.PreviewUseCase, thumbnailUC *usecase.ThumbnailUseCase, seriesUC *usecase.SeriesUseCase, pageUC *usecase.PageUseCase, localStorage *storage.LocalStorage, authMW *AuthMiddleware, postsTagsService *service.PostsTagsService, seriesPostsService *service.SeriesPostsService) (e *gin.Engine) {
 	logger := log.GetLogger()
 	e = gin.New()
 	e.Use(gin.Logger())
@@ -68,9 +70,22 @@ func NewServer(postUC *usecase.PostUseCase, tagUC *usecase.TagUseCase, imageUC *
 		logger.Fatal("JWT Error:" + err.Error())
 	}
 
-	postHandler := handler.NewPostHandler(postUC, postsTagsService)
+	postHandler := handler.NewPostHandler(postUC, tagUC, seriesUC, postsTagsService, thumbnailUC)
+	seriesHandler := handler.NewSeriesHandler(seriesUC, seriesPostsService)
+	pageHandler := handler.NewPageHandler(pageUC)
 	tagHandler := handler.NewTagHandler(tagUC)
 	imageHandler := handler.NewImageHandler(imageUC)
+	trashHandler := handler.NewTrashHandler(trashUC)
+	previewHandler := handler.NewPreviewHandler(previewUC, config.PreviewTTL())
+	site := feed.NewSite(config.SiteTitle(), config.SiteDescription(), config.SiteURL(), config.SiteAuthor())
+	feedHandler := handler.NewFeedHandler(postUC, tagUC, site)
+	metaHandler := handler.NewMetaHandler(postUC, tagUC, site)
+
+	robotsTxt, err := config.RobotsTxt()
+	if err != nil {
+		logger.Fatal("robots.txt Error:" + err.Error())
+	}
+	sitemapHandler := handler.NewSitemapHandler(sitemapUC, config.SiteURL(), robotsTxt)
 
 	e.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -78,6 +93,15 @@ func NewServer(postUC *usecase.PostUseCase, tagUC *usecase.TagUseCase, imageUC *
 		})
 	})
 
+	e.GET("/feed.xml", feedHandler.GetRSS)
+	e.GET("/atom.xml", feedHandler.GetAtom)
+	e.GET("/feed.json", feedHandler.GetJSONFeed)
+	e.GET("/tags/:name/feed.xml", feedHandler.GetTagRSS)
+
+	e.GET("/sitemap.xml", sitemapHandler.GetSitemap)
+	e.GET("/sitemaps/:page", sitemapHandler.GetSitemapPage)
+	e.GET("/robots.txt", sitemapHandler.GetRobotsTxt)
+
 	v1 := e.Group("/api/v1")
 
 	v1.POST("/login", authMiddleware.LoginHandler)
@@ -86,27 +110,92 @@ func NewServer(postUC *usecase.PostUseCase, tagUC *usecase.TagUseCase, imageUC *
 	posts := v1.Group("/posts")
 	posts.GET("", postHandler.GetPosts)
 	posts.GET(":permalink", postHandler.GetPost)
+	posts.GET(":permalink/meta", metaHandler.GetPostMeta)
+	posts.GET(":permalink/related", postHandler.GetRelatedPosts)
 
 	posts.Use(authMiddleware.MiddlewareFunc())
 	{
 		posts.POST("", postHandler.StorePost)
 		posts.PUT(":id", postHandler.UpdatePost)
 		posts.DELETE(":id", postHandler.DeletePost)
+		posts.POST(":id/restore", postHandler.RestorePost)
+		posts.PUT(":id/pin", postHandler.PinPost)
+		posts.DELETE(":id/pin", postHandler.UnpinPost)
+
+		posts.GET(":permalink/revisions", postHandler.GetPostRevisions)
+		posts.GET(":permalink/revisions/diff", postHandler.GetPostRevisionDiff)
+		posts.POST(":id/revisions/:revisionId/restore", postHandler.RestorePostRevision)
+
+		posts.GET(":permalink/redirects", postHandler.GetPermalinkRedirects)
+		posts.DELETE(":id/redirects/:redirectId", postHandler.DeletePermalinkRedirect)
+
+		posts.GET(":permalink/previews", previewHandler.GetPreviewTokens)
+		posts.POST(":id/previews", previewHandler.CreatePreviewToken)
+		posts.DELETE(":id/previews/:previewId", previewHandler.RevokePreviewToken)
 	}
 
+	v1.GET("/archives", postHandler.GetArchives)
+
+	previews := v1.Group("/previews")
+	previews.GET(":token", previewHandler.GetPreview)
+
 	tags := v1.Group("/tags")
 	tags.GET("", tagHandler.GetTags)
 	tags.GET(":id", tagHandler.GetTag)
 	tags.Use(authMiddleware.MiddlewareFunc())
 	{
 		tags.POST("", tagHandler.StoreTag)
+		tags.PUT(":id", tagHandler.UpdateTag)
 		tags.DELETE(":id", tagHandler.DeleteTag)
+		tags.POST(":id/restore", tagHandler.RestoreTag)
+		tags.POST(":id/merge", tagHandler.MergeTag)
+	}
+
+	series := v1.Group("/series")
+	series.GET("", seriesHandler.GetSeriesList)
+	series.GET(":id", seriesHandler.GetSeries)
+	series.Use(authMiddleware.MiddlewareFunc())
+	{
+		series.POST("", seriesHandler.StoreSeries)
+		series.PUT(":id", seriesHandler.UpdateSeries)
+		series.DELETE(":id", seriesHandler.DeleteSeries)
+	}
+
+	// 固定ページはパスを / で区切って階層にできるので，取得は残りのパス全体で行う
+	pages := v1.Group("/pages")
+	pages.GET("", pageHandler.GetPages)
+	pages.GET("/*path", pageHandler.GetPage)
+	pages.Use(authMiddleware.MiddlewareFunc())
+	{
+		pages.POST("", pageHandler.StorePage)
+		pages.PUT("/:id", pageHandler.UpdatePage)
+		pages.DELETE("/:id", pageHandler.DeletePage)
+	}
+
+	trash := v1.Group("/trash")
+	trash.Use(authMiddleware.MiddlewareFunc())
+	{
+		trash.GET("", trashHandler.GetTrash)
 	}
 
 	images := v1.Group("/images")
 	images.Use(authMiddleware.MiddlewareFunc())
 	{
 		images.GET("", imageHandler.GetPresignedURL)
+		images.POST("", imageHandler.UploadImage)
+		images.GET("/library", imageHandler.GetImages)
+		images.POST("/library/scan", imageHandler.ScanImageUsages)
+		images.GET("/library/:id", imageHandler.GetImage)
+		images.PUT("/library/:id", imageHandler.UpdateImage)
+		images.DELETE("/library/:id", imageHandler.DeleteImage)
+	}
+
+	// 画像をローカルに保存する場合はこのサーバーが配信とアップロードの受け付けを行う
+	if localStorage != nil {
+		uploadHandler := handler.NewUploadHandler(localStorage)
+		uploads := v1.Group("/uploads")
+		uploads.Static("/", localStorage.Dir())
+		uploads.PUT("/*filepath", uploadHandler.PutImage)
 	}
 
 	return